@@ -7,19 +7,44 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
-	"log"
 	"mime/multipart"
 	"net/http"
 	"os"
 	"strconv"
 	"strings"
 	"time"
+
+	"github.com/graphomania/tg/scheduler"
 )
 
 // RawNoSync lets you call any method of Bot API manually.
 // It also handles API errors, so you only need to unwrap
 // result field from json data.
 func (b *Bot) RawNoSync(method string, payload interface{}) ([]byte, error) {
+	return b.rawNoSync(context.Background(), method, payload)
+}
+
+func (b *Bot) rawNoSync(ctx context.Context, method string, payload interface{}) ([]byte, error) {
+	return b.invoke(ctx, Call{Method: method, Params: payload})
+}
+
+// send is the Invoker at the end of the interceptor chain, sending the call to the API.
+func (b *Bot) send(ctx context.Context, call Call) (data []byte, err error) {
+	start := b.clock.Now()
+	defer func() { b.logCall(ctx, call.Method, start, err) }()
+
+	if len(call.Files) == 0 {
+		return b.postJSON(ctx, call.Method, call.Params)
+	}
+
+	params, ok := call.Params.(map[string]string)
+	if !ok {
+		return nil, fmt.Errorf("telebot: params of %s with files should be map[string]string", call.Method)
+	}
+	return b.postFiles(ctx, call.Method, call.Files, params)
+}
+
+func (b *Bot) postJSON(ctx context.Context, method string, payload interface{}) ([]byte, error) {
 	url := b.URL + "/bot" + b.Token + "/" + method
 
 	var buf bytes.Buffer
@@ -29,14 +54,14 @@ func (b *Bot) RawNoSync(method string, payload interface{}) ([]byte, error) {
 
 	// Cancel the request immediately without waiting for the timeout  when bot is about to stop.
 	// This may become important if doing long polling with long timeout.
-	exit := make(chan struct{})
+	exit, stopClient := make(chan struct{}), b.stopClient
 	defer close(exit)
-	ctx, cancel := context.WithCancel(context.Background())
+	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
 	go func() {
 		select {
-		case <-b.stopClient:
+		case <-stopClient:
 			cancel()
 		case <-exit:
 		}
@@ -44,44 +69,66 @@ func (b *Bot) RawNoSync(method string, payload interface{}) ([]byte, error) {
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, &buf)
 	if err != nil {
-		return nil, wrapError(err)
+		return nil, wrapError(b.redact(err))
 	}
 	req.Header.Set("Content-Type", "application/json")
 
 	resp, err := b.client.Do(req)
 	if err != nil {
-		return nil, wrapError(err)
+		return nil, wrapError(b.redact(err))
 	}
-	resp.Close = true
-	defer resp.Body.Close()
+	defer drain(resp.Body)
 
 	data, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
 		return nil, wrapError(err)
 	}
 
-	if b.verbose {
-		verbose(method, payload, data)
-	}
-
 	// returning data as well
-	return data, extractOk(data)
+	return data, extractResponse(resp.StatusCode, data)
 }
 
 // Raw is a synced wrapper around RawNoSync method
 func (b *Bot) Raw(method string, payload interface{}) ([]byte, error) {
-	switch m := payload.(type) {
-	case map[string]string:
-		if chatID, ok := m["chat_id"]; ok {
-			return b.scheduler.SyncFunc(1, chatID, func() ([]byte, error) {
-				return b.RawNoSync(method, payload)
-			})
-		}
+	return b.RawContext(context.Background(), method, payload)
+}
+
+// RawContext is Raw bound to ctx: the request is dropped with ctx.Err() if ctx
+// is done while it's waiting for the quota, or cancelled if it's already being sent.
+// The scheduler priority is taken from ctx, see scheduler.WithPriority.
+//
+// The failed requests are retried as the retry policy decides, see Settings.RetryPolicy.
+//
+// Sends, edits and deletes go through the outbox, if the bot has one, see Settings.Outbox.
+func (b *Bot) RawContext(ctx context.Context, method string, payload interface{}) ([]byte, error) {
+	if params, ok := payload.(map[string]string); ok && b.outbox.journals(method) {
+		return b.outbox.deliver(ctx, b, method, params, nil, func() ([]byte, error) {
+			return b.rawContext(ctx, method, params)
+		})
 	}
-	return b.RawNoSync(method, payload)
+	return b.rawContext(ctx, method, payload)
 }
 
-func (b *Bot) sendFilesNoSync(method string, files map[string]File, params map[string]string) ([]byte, error) {
+func (b *Bot) rawContext(ctx context.Context, method string, payload interface{}) ([]byte, error) {
+	return b.retry(ctx, method, nil, func() ([]byte, error) {
+		// long polling isn't limited, and it mustn't hold the quota for its timeout
+		if method == "getUpdates" {
+			return b.rawNoSync(ctx, method, payload)
+		}
+
+		// the requests to no chat take the global quota (the empty chat), so that its floods block them
+		params, _ := payload.(map[string]string)
+		return b.scheduler.SyncFuncContext(scheduling(ctx, method, params), 1, params["chat_id"], func() ([]byte, error) {
+			return b.rawNoSync(ctx, method, payload)
+		})
+	})
+}
+
+func (b *Bot) sendFilesNoSync(ctx context.Context, method string, files map[string]File, params map[string]string) ([]byte, error) {
+	return b.invoke(ctx, Call{Method: method, Params: params, Files: files})
+}
+
+func (b *Bot) postFiles(ctx context.Context, method string, files map[string]File, params map[string]string) ([]byte, error) {
 	rawFiles := make(map[string]interface{})
 	for name, f := range files {
 		switch {
@@ -99,17 +146,24 @@ func (b *Bot) sendFilesNoSync(method string, files map[string]File, params map[s
 	}
 
 	if len(rawFiles) == 0 {
-		return b.RawNoSync(method, params)
+		return b.postJSON(ctx, method, params)
 	}
 
 	pipeReader, pipeWriter := io.Pipe()
 	writer := multipart.NewWriter(pipeWriter)
 
+	progress := ProgressFrom(ctx)
 	go func() {
 		defer pipeWriter.Close()
 
+		if progress != nil {
+			// all the files are known before the first one is done
+			for field, file := range rawFiles {
+				progress(Progress{Field: field, Total: sizeOfFile(file)})
+			}
+		}
 		for field, file := range rawFiles {
-			if err := addFileToWriter(writer, files[field].fileName, field, file); err != nil {
+			if err := addFileToWriter(writer, files[field].fileName, field, file, progress); err != nil {
 				pipeWriter.CloseWithError(err)
 				return
 			}
@@ -128,58 +182,75 @@ func (b *Bot) sendFilesNoSync(method string, files map[string]File, params map[s
 
 	url := b.URL + "/bot" + b.Token + "/" + method
 
-	resp, err := b.client.Post(url, writer.FormDataContentType(), pipeReader)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, pipeReader)
 	if err != nil {
-		err = wrapError(err)
 		pipeReader.CloseWithError(err)
-		return nil, err
+		return nil, wrapError(b.redact(err))
 	}
-	resp.Close = true
-	defer resp.Body.Close()
+	req.Header.Set("Content-Type", writer.FormDataContentType())
 
-	if resp.StatusCode == http.StatusInternalServerError {
-		return nil, ErrInternal
+	resp, err := b.client.Do(req)
+	if err != nil {
+		err = wrapError(b.redact(err))
+		pipeReader.CloseWithError(err)
+		return nil, err
 	}
+	defer drain(resp.Body)
 
 	data, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return nil, wrapError(err)
 	}
 
-	return data, extractOk(data)
+	return data, extractResponse(resp.StatusCode, data)
 }
 
-func (b *Bot) sendFilesSynced(method string, files map[string]File, params map[string]string) ([]byte, error) {
-	if chatID, ok := params["chat_id"]; ok {
-		return b.scheduler.SyncFunc(len(files), chatID, func() ([]byte, error) {
-			return b.sendFilesNoSync(method, files, params)
-		})
+// scheduling returns the context the scheduler gets for the request,
+// the quota profiles tell the requests apart by it.
+func scheduling(ctx context.Context, method string, params map[string]string) context.Context {
+	ctx = scheduler.WithMethod(ctx, method)
+	if params["allow_paid_broadcast"] == "true" {
+		ctx = scheduler.WithPaidBroadcast(ctx)
 	}
-	return b.sendFilesNoSync(method, files, params)
+	return ctx
 }
 
-func (b *Bot) sendFilesWithRetries(method string, files map[string]File, params map[string]string, retries int) ([]byte, error) {
-	ret, err := b.sendFilesSynced(method, files, params)
-	if err == nil {
-		return ret, nil
-	}
+func (b *Bot) sendFilesSynced(ctx context.Context, method string, files map[string]File, params map[string]string) ([]byte, error) {
+	return b.scheduler.SyncFuncContext(scheduling(ctx, method, params), len(files), params["chat_id"], func() ([]byte, error) {
+		return b.sendFilesNoSync(ctx, method, files, params)
+	})
+}
 
-	if retries > 0 {
-		var sleepTime time.Duration
-		if _, err := fmt.Sscanf(err.Error(), "telegram: retry after %d (429))", &sleepTime); err != nil {
-			time.Sleep(time.Second * sleepTime)
-		}
-		return b.sendFilesWithRetries(method, files, params, retries-1)
+func (b *Bot) sendFiles(method string, files map[string]File, params map[string]string) ([]byte, error) {
+	return b.sendFilesContext(context.Background(), method, files, params)
+}
+
+func (b *Bot) sendFilesContext(ctx context.Context, method string, files map[string]File, params map[string]string) ([]byte, error) {
+	if b.outbox.journals(method) {
+		return b.outbox.deliver(ctx, b, method, params, files, func() ([]byte, error) {
+			return b.sendFilesRetrying(ctx, method, files, params)
+		})
 	}
+	return b.sendFilesRetrying(ctx, method, files, params)
+}
 
-	return ret, err
+func (b *Bot) sendFilesRetrying(ctx context.Context, method string, files map[string]File, params map[string]string) ([]byte, error) {
+	return b.retry(ctx, method, files, func() ([]byte, error) {
+		return b.sendFilesSynced(ctx, method, files, params)
+	})
 }
 
-func (b *Bot) sendFiles(method string, files map[string]File, params map[string]string) ([]byte, error) {
-	return b.sendFilesWithRetries(method, files, params, b.retries)
+// hasReaders tells whether any of the files is going to be uploaded from its FileReader.
+func hasReaders(files map[string]File) bool {
+	for _, f := range files {
+		if f.fromReader() {
+			return true
+		}
+	}
+	return false
 }
 
-func addFileToWriter(writer *multipart.Writer, filename, field string, file interface{}) error {
+func addFileToWriter(writer *multipart.Writer, filename, field string, file interface{}, progress ProgressFunc) error {
 	var reader io.Reader
 	if r, ok := file.(io.Reader); ok {
 		reader = r
@@ -199,6 +270,13 @@ func addFileToWriter(writer *multipart.Writer, filename, field string, file inte
 		return err
 	}
 
+	if progress != nil {
+		pr := &progressReader{r: reader, fn: progress}
+		pr.progress = Progress{Field: field, Total: sizeOf(reader)}
+		progress(pr.progress)
+		reader = pr
+	}
+
 	_, err = io.Copy(part, reader)
 	return err
 }
@@ -210,7 +288,7 @@ func (b *Bot) sendText(to Recipient, text string, opt *SendOptions) (*Message, e
 	}
 	b.embedSendOptions(params, opt)
 
-	data, err := b.Raw("sendMessage", params)
+	data, err := b.RawContext(opt.context(), "sendMessage", params)
 	if err != nil {
 		return nil, err
 	}
@@ -218,7 +296,7 @@ func (b *Bot) sendText(to Recipient, text string, opt *SendOptions) (*Message, e
 	return extractMessage(data)
 }
 
-func (b *Bot) sendMedia(media Media, params map[string]string, files map[string]File) (*Message, error) {
+func (b *Bot) sendMedia(media Media, params map[string]string, files map[string]File, opt *SendOptions) (*Message, error) {
 	kind := media.MediaType()
 	what := "send" + strings.Title(kind)
 
@@ -231,7 +309,7 @@ func (b *Bot) sendMedia(media Media, params map[string]string, files map[string]
 		sendFiles[k] = v
 	}
 
-	ret, err := b.sendFiles(what, sendFiles, params)
+	ret, err := b.sendFilesContext(opt.context(), what, sendFiles, params)
 	if err != nil {
 		return nil, err
 	}
@@ -239,18 +317,7 @@ func (b *Bot) sendMedia(media Media, params map[string]string, files map[string]
 }
 
 func (b *Bot) getMe() (*User, error) {
-	data, err := b.Raw("getMe", nil)
-	if err != nil {
-		return nil, err
-	}
-
-	var resp struct {
-		Result *User
-	}
-	if err := json.Unmarshal(data, &resp); err != nil {
-		return nil, wrapError(err)
-	}
-	return resp.Result, nil
+	return b.API().GetMe(context.Background())
 }
 
 func (b *Bot) getUpdates(offset, limit int, timeout time.Duration, allowed []string) ([]Update, error) {
@@ -266,7 +333,8 @@ func (b *Bot) getUpdates(offset, limit int, timeout time.Duration, allowed []str
 		params["limit"] = strconv.Itoa(limit)
 	}
 
-	data, err := b.Raw("getUpdates", params)
+	// not retried, the poller backs off on its own
+	data, err := b.rawNoSync(context.Background(), "getUpdates", params)
 	if err != nil {
 		return nil, err
 	}
@@ -326,12 +394,28 @@ func extractOk(data []byte) error {
 			RetryAfter: int(retryAfter.(float64)),
 		}
 	default:
+		if e.Code >= http.StatusInternalServerError || e.Code == http.StatusUnauthorized || e.Code == http.StatusConflict {
+			// telling the failures of the servers and the fatal ones apart, see Retryable and LongPoller
+			return NewError(e.Code, e.Description)
+		}
 		err = fmt.Errorf("telegram: %s (%d)", e.Description, e.Code)
 	}
 
 	return err
 }
 
+// extractResponse is extractOk telling the failures of telegram servers (5xx)
+// even if they come without the JSON, e.g. from the proxy in front of them.
+func extractResponse(status int, data []byte) error {
+	if err := extractOk(data); err != nil || status < http.StatusInternalServerError {
+		return err
+	}
+	if status == http.StatusInternalServerError {
+		return ErrInternal
+	}
+	return NewError(status, http.StatusText(status))
+}
+
 // extractMessage extracts common Message result from given data.
 // Should be called after extractOk or b.Raw() to handle possible errors.
 func extractMessage(data []byte) (*Message, error) {
@@ -352,21 +436,3 @@ func extractMessage(data []byte) (*Message, error) {
 	}
 	return resp.Result, nil
 }
-
-func verbose(method string, payload interface{}, data []byte) {
-	body, _ := json.Marshal(payload)
-	body = bytes.ReplaceAll(body, []byte(`\"`), []byte(`"`))
-	body = bytes.ReplaceAll(body, []byte(`"{`), []byte(`{`))
-	body = bytes.ReplaceAll(body, []byte(`}"`), []byte(`}`))
-
-	indent := func(b []byte) string {
-		var buf bytes.Buffer
-		json.Indent(&buf, b, "", "  ")
-		return buf.String()
-	}
-
-	log.Printf(
-		"[verbose] telebot: sent request\nMethod: %v\nParams: %v\nResponse: %v",
-		method, indent(body), indent(data),
-	)
-}
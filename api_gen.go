@@ -0,0 +1,6144 @@
+// Code generated by tg-apigen from Bot API 7.0; DO NOT EDIT.
+
+package telebot
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+)
+
+// AddStickerToSetRequest is the request of addStickerToSet, see API.AddStickerToSet.
+type AddStickerToSetRequest struct {
+	// User identifier of sticker set owner. Required.
+	UserID int64
+
+	// Sticker set name. Required.
+	Name string
+
+	// A JSON-serialized object with information about the added sticker. If exactly the same
+	// sticker had already been added to the set, then the set isn't changed. Required.
+	Sticker interface{}
+}
+
+func (r *AddStickerToSetRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	params["user_id"] = strconv.FormatInt(int64(r.UserID), 10)
+
+	params["name"] = r.Name
+
+	if r.Sticker != nil {
+		if err := encodeParam(params, "sticker", r.Sticker); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return params, files, nil
+}
+
+// AddStickerToSet calls addStickerToSet, see https://core.telegram.org/bots/api#addstickertoset
+//
+// Use this method to add a new sticker to a set created by the bot. The format of the added
+// sticker must match the format of the other stickers in the set. Emoji sticker sets can have
+// up to 200 stickers. Animated and video sticker sets can have up to 50 stickers. Static
+// sticker sets can have up to 120 stickers. Returns True on success.
+func (api API) AddStickerToSet(ctx context.Context, r *AddStickerToSetRequest) error {
+	return api.call(ctx, "addStickerToSet", r, nil)
+}
+
+// AnswerCallbackQueryRequest is the request of answerCallbackQuery, see API.AnswerCallbackQuery.
+type AnswerCallbackQueryRequest struct {
+	// Unique identifier for the query to be answered. Required.
+	CallbackQueryID string
+
+	// Text of the notification. If not specified, nothing will be shown to the user, 0-200
+	// characters
+	Text string
+
+	// If True, an alert will be shown by the client instead of a notification at the top of the
+	// chat screen.
+	ShowAlert bool
+
+	// URL that will be opened by the user's client.
+	URL string
+
+	// The maximum amount of time in seconds that the result of the callback query may be cached
+	// client-side.
+	CacheTime int
+}
+
+func (r *AnswerCallbackQueryRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	params["callback_query_id"] = r.CallbackQueryID
+
+	if r.Text != "" {
+		params["text"] = r.Text
+	}
+
+	if r.ShowAlert {
+		params["show_alert"] = "true"
+	}
+
+	if r.URL != "" {
+		params["url"] = r.URL
+	}
+
+	if r.CacheTime != 0 {
+		params["cache_time"] = strconv.FormatInt(int64(r.CacheTime), 10)
+	}
+
+	return params, files, nil
+}
+
+// AnswerCallbackQuery calls answerCallbackQuery, see https://core.telegram.org/bots/api#answercallbackquery
+//
+// Use this method to send answers to callback queries sent from inline keyboards. On success,
+// True is returned.
+func (api API) AnswerCallbackQuery(ctx context.Context, r *AnswerCallbackQueryRequest) error {
+	return api.call(ctx, "answerCallbackQuery", r, nil)
+}
+
+// AnswerInlineQueryRequest is the request of answerInlineQuery, see API.AnswerInlineQuery.
+type AnswerInlineQueryRequest struct {
+	// Unique identifier for the answered query. Required.
+	InlineQueryID string
+
+	// A JSON-serialized array of results for the inline query. Required.
+	Results interface{}
+
+	// The maximum amount of time in seconds that the result of the inline query may be cached on
+	// the server. Defaults to 300.
+	CacheTime int
+
+	// Pass True if results may be cached on the server side only for the user that sent the
+	// query. By default, results may be returned to any user who sends the same query.
+	IsPersonal bool
+
+	// Pass the offset that a client should send in the next query with the same text to receive
+	// more results. Pass an empty string if there are no more results or if you don't support
+	// pagination. Offset length can't exceed 64 bytes.
+	NextOffset string
+
+	// A JSON-serialized object describing a button to be shown above inline query results
+	Button interface{}
+}
+
+func (r *AnswerInlineQueryRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	params["inline_query_id"] = r.InlineQueryID
+
+	if r.Results != nil {
+		if err := encodeParam(params, "results", r.Results); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if r.CacheTime != 0 {
+		params["cache_time"] = strconv.FormatInt(int64(r.CacheTime), 10)
+	}
+
+	if r.IsPersonal {
+		params["is_personal"] = "true"
+	}
+
+	if r.NextOffset != "" {
+		params["next_offset"] = r.NextOffset
+	}
+
+	if r.Button != nil {
+		if err := encodeParam(params, "button", r.Button); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return params, files, nil
+}
+
+// AnswerInlineQuery calls answerInlineQuery, see https://core.telegram.org/bots/api#answerinlinequery
+//
+// Use this method to send answers to an inline query. On success, True is returned. No more
+// than 50 results per query are allowed.
+func (api API) AnswerInlineQuery(ctx context.Context, r *AnswerInlineQueryRequest) error {
+	return api.call(ctx, "answerInlineQuery", r, nil)
+}
+
+// AnswerPreCheckoutQueryRequest is the request of answerPreCheckoutQuery, see API.AnswerPreCheckoutQuery.
+type AnswerPreCheckoutQueryRequest struct {
+	// Unique identifier for the query to be answered. Required.
+	PreCheckoutQueryID string
+
+	// Specify True if everything is alright (goods are available, etc.) and the bot is ready to
+	// proceed with the order. Use False if there are any problems. Required.
+	Ok bool
+
+	// Required if ok is False. Error message in human readable form that explains the reason for
+	// failure to proceed with the checkout (e.g. "Sorry, somebody just bought the last of our
+	// amazing black T-shirts while you were busy filling out your payment details. Please choose
+	// a different color or garment!"). Telegram will display this message to the user.
+	ErrorMessage string
+}
+
+func (r *AnswerPreCheckoutQueryRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	params["pre_checkout_query_id"] = r.PreCheckoutQueryID
+
+	params["ok"] = strconv.FormatBool(r.Ok)
+
+	if r.ErrorMessage != "" {
+		params["error_message"] = r.ErrorMessage
+	}
+
+	return params, files, nil
+}
+
+// AnswerPreCheckoutQuery calls answerPreCheckoutQuery, see https://core.telegram.org/bots/api#answerprecheckoutquery
+//
+// Once the user has confirmed their payment and shipping details, the Bot API sends the final
+// confirmation in the form of an Update with the field pre_checkout_query. Use this method to
+// respond to such pre-checkout queries. On success, True is returned. Note: The Bot API must
+// receive an answer within 10 seconds after the pre-checkout query was sent.
+func (api API) AnswerPreCheckoutQuery(ctx context.Context, r *AnswerPreCheckoutQueryRequest) error {
+	return api.call(ctx, "answerPreCheckoutQuery", r, nil)
+}
+
+// AnswerShippingQueryRequest is the request of answerShippingQuery, see API.AnswerShippingQuery.
+type AnswerShippingQueryRequest struct {
+	// Unique identifier for the query to be answered. Required.
+	ShippingQueryID string
+
+	// Pass True if delivery to the specified address is possible and False if there are any
+	// problems (for example, if delivery to the specified address is not possible). Required.
+	Ok bool
+
+	// Required if ok is True. A JSON-serialized array of available shipping options.
+	ShippingOptions []ShippingOption
+
+	// Required if ok is False. Error message in human readable form that explains why it is
+	// impossible to complete the order (e.g. "Sorry, delivery to your desired address is
+	// unavailable'). Telegram will display this message to the user.
+	ErrorMessage string
+}
+
+func (r *AnswerShippingQueryRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	params["shipping_query_id"] = r.ShippingQueryID
+
+	params["ok"] = strconv.FormatBool(r.Ok)
+
+	if r.ShippingOptions != nil {
+		if err := encodeParam(params, "shipping_options", r.ShippingOptions); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if r.ErrorMessage != "" {
+		params["error_message"] = r.ErrorMessage
+	}
+
+	return params, files, nil
+}
+
+// AnswerShippingQuery calls answerShippingQuery, see https://core.telegram.org/bots/api#answershippingquery
+//
+// If you sent an invoice requesting a shipping address and the parameter is_flexible was
+// specified, the Bot API will send an Update with a shipping_query field to the bot. Use this
+// method to reply to shipping queries. On success, True is returned.
+func (api API) AnswerShippingQuery(ctx context.Context, r *AnswerShippingQueryRequest) error {
+	return api.call(ctx, "answerShippingQuery", r, nil)
+}
+
+// AnswerWebAppQueryRequest is the request of answerWebAppQuery, see API.AnswerWebAppQuery.
+type AnswerWebAppQueryRequest struct {
+	// Unique identifier for the query to be answered. Required.
+	WebAppQueryID string
+
+	// A JSON-serialized object describing the message to be sent. Required.
+	Result interface{}
+}
+
+func (r *AnswerWebAppQueryRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	params["web_app_query_id"] = r.WebAppQueryID
+
+	if r.Result != nil {
+		if err := encodeParam(params, "result", r.Result); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return params, files, nil
+}
+
+// AnswerWebAppQuery calls answerWebAppQuery, see https://core.telegram.org/bots/api#answerwebappquery
+//
+// Use this method to set the result of an interaction with a Web App and send a corresponding
+// message on behalf of the user to the chat from which the query originated. On success, a
+// SentWebAppMessage object is returned.
+func (api API) AnswerWebAppQuery(ctx context.Context, r *AnswerWebAppQueryRequest) (*WebAppMessage, error) {
+	var result *WebAppMessage
+	err := api.call(ctx, "answerWebAppQuery", r, &result)
+	return result, err
+}
+
+// ApproveChatJoinRequestRequest is the request of approveChatJoinRequest, see API.ApproveChatJoinRequest.
+type ApproveChatJoinRequestRequest struct {
+	// Unique identifier for the target chat or username of the target channel (in the format
+	// @channelusername). Required.
+	ChatID Recipient
+
+	// Unique identifier of the target user. Required.
+	UserID int64
+}
+
+func (r *ApproveChatJoinRequestRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	if r.ChatID != nil {
+		params["chat_id"] = r.ChatID.Recipient()
+	}
+
+	params["user_id"] = strconv.FormatInt(int64(r.UserID), 10)
+
+	return params, files, nil
+}
+
+// ApproveChatJoinRequest calls approveChatJoinRequest, see https://core.telegram.org/bots/api#approvechatjoinrequest
+//
+// Use this method to approve a chat join request. The bot must be an administrator in the
+// chat for this to work and must have the can_invite_users administrator right. Returns True
+// on success.
+func (api API) ApproveChatJoinRequest(ctx context.Context, r *ApproveChatJoinRequestRequest) error {
+	return api.call(ctx, "approveChatJoinRequest", r, nil)
+}
+
+// BanChatMemberRequest is the request of banChatMember, see API.BanChatMember.
+type BanChatMemberRequest struct {
+	// Unique identifier for the target group or username of the target supergroup or channel (in
+	// the format @channelusername). Required.
+	ChatID Recipient
+
+	// Unique identifier of the target user. Required.
+	UserID int64
+
+	// Date when the user will be unbanned; Unix time.
+	UntilDate int
+
+	// Pass True to delete all messages from the chat for the user that is being removed.
+	RevokeMessages bool
+}
+
+func (r *BanChatMemberRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	if r.ChatID != nil {
+		params["chat_id"] = r.ChatID.Recipient()
+	}
+
+	params["user_id"] = strconv.FormatInt(int64(r.UserID), 10)
+
+	if r.UntilDate != 0 {
+		params["until_date"] = strconv.FormatInt(int64(r.UntilDate), 10)
+	}
+
+	if r.RevokeMessages {
+		params["revoke_messages"] = "true"
+	}
+
+	return params, files, nil
+}
+
+// BanChatMember calls banChatMember, see https://core.telegram.org/bots/api#banchatmember
+//
+// Use this method to ban a user in a group, a supergroup or a channel. Returns True on
+// success.
+func (api API) BanChatMember(ctx context.Context, r *BanChatMemberRequest) error {
+	return api.call(ctx, "banChatMember", r, nil)
+}
+
+// BanChatSenderChatRequest is the request of banChatSenderChat, see API.BanChatSenderChat.
+type BanChatSenderChatRequest struct {
+	// Unique identifier for the target chat or username of the target channel (in the format
+	// @channelusername). Required.
+	ChatID Recipient
+
+	// Unique identifier of the target sender chat. Required.
+	SenderChatID int64
+}
+
+func (r *BanChatSenderChatRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	if r.ChatID != nil {
+		params["chat_id"] = r.ChatID.Recipient()
+	}
+
+	params["sender_chat_id"] = strconv.FormatInt(int64(r.SenderChatID), 10)
+
+	return params, files, nil
+}
+
+// BanChatSenderChat calls banChatSenderChat, see https://core.telegram.org/bots/api#banchatsenderchat
+//
+// Use this method to ban a channel chat in a supergroup or a channel. Until the chat is
+// unbanned, the owner of the banned chat won't be able to send messages on behalf of any of
+// their channels. The bot must be an administrator in the supergroup or channel for this to
+// work and must have the appropriate administrator rights. Returns True on success.
+func (api API) BanChatSenderChat(ctx context.Context, r *BanChatSenderChatRequest) error {
+	return api.call(ctx, "banChatSenderChat", r, nil)
+}
+
+// Close calls close, see https://core.telegram.org/bots/api#close
+//
+// Use this method to close the bot instance before moving it from one local server to
+// another. Returns True on success.
+func (api API) Close(ctx context.Context) error {
+	return api.call(ctx, "close", nil, nil)
+}
+
+// CloseForumTopicRequest is the request of closeForumTopic, see API.CloseForumTopic.
+type CloseForumTopicRequest struct {
+	// Unique identifier for the target chat or username of the target supergroup (in the format
+	// @supergroupusername). Required.
+	ChatID Recipient
+
+	// Unique identifier for the target message thread of the forum topic. Required.
+	MessageThreadID int
+}
+
+func (r *CloseForumTopicRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	if r.ChatID != nil {
+		params["chat_id"] = r.ChatID.Recipient()
+	}
+
+	params["message_thread_id"] = strconv.FormatInt(int64(r.MessageThreadID), 10)
+
+	return params, files, nil
+}
+
+// CloseForumTopic calls closeForumTopic, see https://core.telegram.org/bots/api#closeforumtopic
+//
+// Use this method to close an open topic in a forum supergroup chat. The bot must be an
+// administrator in the chat for this to work and must have the can_manage_topics
+// administrator rights, unless it is the creator of the topic. Returns True on success.
+func (api API) CloseForumTopic(ctx context.Context, r *CloseForumTopicRequest) error {
+	return api.call(ctx, "closeForumTopic", r, nil)
+}
+
+// CloseGeneralForumTopicRequest is the request of closeGeneralForumTopic, see API.CloseGeneralForumTopic.
+type CloseGeneralForumTopicRequest struct {
+	// Unique identifier for the target chat or username of the target supergroup (in the format
+	// @supergroupusername). Required.
+	ChatID Recipient
+}
+
+func (r *CloseGeneralForumTopicRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	if r.ChatID != nil {
+		params["chat_id"] = r.ChatID.Recipient()
+	}
+
+	return params, files, nil
+}
+
+// CloseGeneralForumTopic calls closeGeneralForumTopic, see https://core.telegram.org/bots/api#closegeneralforumtopic
+//
+// Use this method to close an open 'General' topic in a forum supergroup chat. The bot must
+// be an administrator in the chat for this to work and must have the can_manage_topics
+// administrator rights. Returns True on success.
+func (api API) CloseGeneralForumTopic(ctx context.Context, r *CloseGeneralForumTopicRequest) error {
+	return api.call(ctx, "closeGeneralForumTopic", r, nil)
+}
+
+// CopyMessageRequest is the request of copyMessage, see API.CopyMessage.
+type CopyMessageRequest struct {
+	// Unique identifier for the target chat or username of the target channel (in the format
+	// @channelusername). Required.
+	ChatID Recipient
+
+	// Unique identifier for the target message thread (topic) of the forum; for forum supergroups
+	// only
+	MessageThreadID int
+
+	// Unique identifier for the chat where the original message was sent (or channel username in
+	// the format @channelusername). Required.
+	FromChatID Recipient
+
+	// Message identifier in the chat specified in from_chat_id. Required.
+	MessageID int
+
+	// Caption, 0-1024 characters after entities parsing
+	Caption string
+
+	// Mode for parsing entities in the caption. See formatting options for more details.
+	ParseMode string
+
+	// A JSON-serialized list of special entities that appear in the caption, which can be
+	// specified instead of parse_mode
+	CaptionEntities Entities
+
+	// Sends the message silently. Users will receive a notification with no sound.
+	DisableNotification bool
+
+	// Protects the contents of the sent message from forwarding and saving
+	ProtectContent bool
+
+	// Description of the message to reply to
+	ReplyParameters interface{}
+
+	// Additional interface options. A JSON-serialized object for an inline keyboard, custom reply
+	// keyboard, instructions to remove reply keyboard or to force a reply from the user.
+	ReplyMarkup *ReplyMarkup
+}
+
+func (r *CopyMessageRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	if r.ChatID != nil {
+		params["chat_id"] = r.ChatID.Recipient()
+	}
+
+	if r.MessageThreadID != 0 {
+		params["message_thread_id"] = strconv.FormatInt(int64(r.MessageThreadID), 10)
+	}
+
+	if r.FromChatID != nil {
+		params["from_chat_id"] = r.FromChatID.Recipient()
+	}
+
+	params["message_id"] = strconv.FormatInt(int64(r.MessageID), 10)
+
+	if r.Caption != "" {
+		params["caption"] = r.Caption
+	}
+
+	if r.ParseMode != "" {
+		params["parse_mode"] = r.ParseMode
+	}
+
+	if r.CaptionEntities != nil {
+		if err := encodeParam(params, "caption_entities", r.CaptionEntities); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if r.DisableNotification {
+		params["disable_notification"] = "true"
+	}
+
+	if r.ProtectContent {
+		params["protect_content"] = "true"
+	}
+
+	if r.ReplyParameters != nil {
+		if err := encodeParam(params, "reply_parameters", r.ReplyParameters); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if r.ReplyMarkup != nil {
+		if err := encodeParam(params, "reply_markup", r.ReplyMarkup); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return params, files, nil
+}
+
+// CopyMessage calls copyMessage, see https://core.telegram.org/bots/api#copymessage
+//
+// Use this method to copy messages of any kind. Returns the MessageId of the sent message on
+// success.
+func (api API) CopyMessage(ctx context.Context, r *CopyMessageRequest) (json.RawMessage, error) {
+	var result json.RawMessage
+	err := api.call(ctx, "copyMessage", r, &result)
+	return result, err
+}
+
+// CopyMessagesRequest is the request of copyMessages, see API.CopyMessages.
+type CopyMessagesRequest struct {
+	// Unique identifier for the target chat or username of the target channel (in the format
+	// @channelusername). Required.
+	ChatID Recipient
+
+	// Unique identifier for the target message thread (topic) of the forum; for forum supergroups
+	// only
+	MessageThreadID int
+
+	// Unique identifier for the chat where the original messages were sent (or channel username
+	// in the format @channelusername). Required.
+	FromChatID Recipient
+
+	// Identifiers of 1-100 messages in the chat from_chat_id to copy. The identifiers must be
+	// specified in a strictly increasing order. Required.
+	MessageIDs []int
+
+	// Sends the messages silently. Users will receive a notification with no sound.
+	DisableNotification bool
+
+	// Protects the contents of the sent messages from forwarding and saving
+	ProtectContent bool
+
+	// Pass True to copy the messages without their captions
+	RemoveCaption bool
+}
+
+func (r *CopyMessagesRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	if r.ChatID != nil {
+		params["chat_id"] = r.ChatID.Recipient()
+	}
+
+	if r.MessageThreadID != 0 {
+		params["message_thread_id"] = strconv.FormatInt(int64(r.MessageThreadID), 10)
+	}
+
+	if r.FromChatID != nil {
+		params["from_chat_id"] = r.FromChatID.Recipient()
+	}
+
+	if r.MessageIDs != nil {
+		if err := encodeParam(params, "message_ids", r.MessageIDs); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if r.DisableNotification {
+		params["disable_notification"] = "true"
+	}
+
+	if r.ProtectContent {
+		params["protect_content"] = "true"
+	}
+
+	if r.RemoveCaption {
+		params["remove_caption"] = "true"
+	}
+
+	return params, files, nil
+}
+
+// CopyMessages calls copyMessages, see https://core.telegram.org/bots/api#copymessages
+//
+// Use this method to copy messages of any kind. If some of the specified messages can't be
+// found or copied, they are skipped. Service messages, giveaway messages, giveaway winners
+// messages, and invoice messages can't be copied. On success, an array of MessageId of the
+// sent messages is returned.
+func (api API) CopyMessages(ctx context.Context, r *CopyMessagesRequest) (json.RawMessage, error) {
+	var result json.RawMessage
+	err := api.call(ctx, "copyMessages", r, &result)
+	return result, err
+}
+
+// CreateChatInviteLinkRequest is the request of createChatInviteLink, see API.CreateChatInviteLink.
+type CreateChatInviteLinkRequest struct {
+	// Unique identifier for the target chat or username of the target channel (in the format
+	// @channelusername). Required.
+	ChatID Recipient
+
+	// Invite link name; 0-32 characters
+	Name string
+
+	// Point in time (Unix timestamp) when the link will expire
+	ExpireDate int
+
+	// The maximum number of users that can be members of the chat simultaneously after joining
+	// the chat via this invite link; 1-99999
+	MemberLimit int
+
+	// True, if users joining the chat via the link need to be approved by chat administrators. If
+	// True, member_limit can't be specified
+	CreatesJoinRequest bool
+}
+
+func (r *CreateChatInviteLinkRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	if r.ChatID != nil {
+		params["chat_id"] = r.ChatID.Recipient()
+	}
+
+	if r.Name != "" {
+		params["name"] = r.Name
+	}
+
+	if r.ExpireDate != 0 {
+		params["expire_date"] = strconv.FormatInt(int64(r.ExpireDate), 10)
+	}
+
+	if r.MemberLimit != 0 {
+		params["member_limit"] = strconv.FormatInt(int64(r.MemberLimit), 10)
+	}
+
+	if r.CreatesJoinRequest {
+		params["creates_join_request"] = "true"
+	}
+
+	return params, files, nil
+}
+
+// CreateChatInviteLink calls createChatInviteLink, see https://core.telegram.org/bots/api#createchatinvitelink
+//
+// Use this method to create an additional invite link for a chat. The bot must be an
+// administrator in the chat for this to work and must have the appropriate administrator
+// rights. The link can be revoked using the method revokeChatInviteLink. Returns the new
+// invite link as ChatInviteLink object.
+func (api API) CreateChatInviteLink(ctx context.Context, r *CreateChatInviteLinkRequest) (*ChatInviteLink, error) {
+	var result *ChatInviteLink
+	err := api.call(ctx, "createChatInviteLink", r, &result)
+	return result, err
+}
+
+// CreateForumTopicRequest is the request of createForumTopic, see API.CreateForumTopic.
+type CreateForumTopicRequest struct {
+	// Unique identifier for the target chat or username of the target supergroup (in the format
+	// @supergroupusername). Required.
+	ChatID Recipient
+
+	// Topic name, 1-128 characters. Required.
+	Name string
+
+	// Color of the topic icon in RGB format. Currently, must be one of 7322096 (0x6FB9F0),
+	// 16766590 (0xFFD67E), 13338331 (0xCB86DB), 9367192 (0x8EEE98), 16749490 (0xFF93B2), or
+	// 16478047 (0xFB6F5F)
+	IconColor int
+
+	// Unique identifier of the custom emoji shown as the topic icon. Use
+	// getForumTopicIconStickers to get all allowed custom emoji identifiers.
+	IconCustomEmojiID string
+}
+
+func (r *CreateForumTopicRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	if r.ChatID != nil {
+		params["chat_id"] = r.ChatID.Recipient()
+	}
+
+	params["name"] = r.Name
+
+	if r.IconColor != 0 {
+		params["icon_color"] = strconv.FormatInt(int64(r.IconColor), 10)
+	}
+
+	if r.IconCustomEmojiID != "" {
+		params["icon_custom_emoji_id"] = r.IconCustomEmojiID
+	}
+
+	return params, files, nil
+}
+
+// CreateForumTopic calls createForumTopic, see https://core.telegram.org/bots/api#createforumtopic
+//
+// Use this method to create a topic in a forum supergroup chat. The bot must be an
+// administrator in the chat for this to work and must have the can_manage_topics
+// administrator rights. Returns information about the created topic as a ForumTopic object.
+func (api API) CreateForumTopic(ctx context.Context, r *CreateForumTopicRequest) (*Topic, error) {
+	var result *Topic
+	err := api.call(ctx, "createForumTopic", r, &result)
+	return result, err
+}
+
+// CreateInvoiceLinkRequest is the request of createInvoiceLink, see API.CreateInvoiceLink.
+type CreateInvoiceLinkRequest struct {
+	// Product name, 1-32 characters. Required.
+	Title string
+
+	// Product description, 1-255 characters. Required.
+	Description string
+
+	// Bot-defined invoice payload, 1-128 bytes. This will not be displayed to the user, use for
+	// your internal processes. Required.
+	Payload string
+
+	// Payment provider token, obtained via BotFather. Required.
+	ProviderToken string
+
+	// Three-letter ISO 4217 currency code, see more on currencies. Required.
+	Currency string
+
+	// Price breakdown, a JSON-serialized list of components (e.g. product price, tax, discount,
+	// delivery cost, delivery tax, bonus, etc.). Required.
+	Prices []Price
+
+	// The maximum accepted amount for tips in the smallest units of the currency (integer, not
+	// float/double). Defaults to 0
+	MaxTipAmount int
+
+	// A JSON-serialized array of suggested amounts of tips in the smallest units of the currency
+	// (integer, not float/double). At most 4 suggested tip amounts can be specified. The
+	// suggested tip amounts must be positive, passed in a strictly increased order and must not
+	// exceed max_tip_amount.
+	SuggestedTipAmounts []int
+
+	// JSON-serialized data about the invoice, which will be shared with the payment provider. A
+	// detailed description of required fields should be provided by the payment provider.
+	ProviderData string
+
+	// URL of the product photo for the invoice. Can be a photo of the goods or a marketing image
+	// for a service.
+	PhotoURL string
+
+	// Photo size in bytes
+	PhotoSize int
+
+	// Photo width
+	PhotoWidth int
+
+	// Photo height
+	PhotoHeight int
+
+	// Pass True if you require the user's full name to complete the order
+	NeedName bool
+
+	// Pass True if you require the user's phone number to complete the order
+	NeedPhoneNumber bool
+
+	// Pass True if you require the user's email address to complete the order
+	NeedEmail bool
+
+	// Pass True if you require the user's shipping address to complete the order
+	NeedShippingAddress bool
+
+	// Pass True if the user's phone number should be sent to the provider
+	SendPhoneNumberToProvider bool
+
+	// Pass True if the user's email address should be sent to the provider
+	SendEmailToProvider bool
+
+	// Pass True if the final price depends on the shipping method
+	IsFlexible bool
+}
+
+func (r *CreateInvoiceLinkRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	params["title"] = r.Title
+
+	params["description"] = r.Description
+
+	params["payload"] = r.Payload
+
+	params["provider_token"] = r.ProviderToken
+
+	params["currency"] = r.Currency
+
+	if r.Prices != nil {
+		if err := encodeParam(params, "prices", r.Prices); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if r.MaxTipAmount != 0 {
+		params["max_tip_amount"] = strconv.FormatInt(int64(r.MaxTipAmount), 10)
+	}
+
+	if r.SuggestedTipAmounts != nil {
+		if err := encodeParam(params, "suggested_tip_amounts", r.SuggestedTipAmounts); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if r.ProviderData != "" {
+		params["provider_data"] = r.ProviderData
+	}
+
+	if r.PhotoURL != "" {
+		params["photo_url"] = r.PhotoURL
+	}
+
+	if r.PhotoSize != 0 {
+		params["photo_size"] = strconv.FormatInt(int64(r.PhotoSize), 10)
+	}
+
+	if r.PhotoWidth != 0 {
+		params["photo_width"] = strconv.FormatInt(int64(r.PhotoWidth), 10)
+	}
+
+	if r.PhotoHeight != 0 {
+		params["photo_height"] = strconv.FormatInt(int64(r.PhotoHeight), 10)
+	}
+
+	if r.NeedName {
+		params["need_name"] = "true"
+	}
+
+	if r.NeedPhoneNumber {
+		params["need_phone_number"] = "true"
+	}
+
+	if r.NeedEmail {
+		params["need_email"] = "true"
+	}
+
+	if r.NeedShippingAddress {
+		params["need_shipping_address"] = "true"
+	}
+
+	if r.SendPhoneNumberToProvider {
+		params["send_phone_number_to_provider"] = "true"
+	}
+
+	if r.SendEmailToProvider {
+		params["send_email_to_provider"] = "true"
+	}
+
+	if r.IsFlexible {
+		params["is_flexible"] = "true"
+	}
+
+	return params, files, nil
+}
+
+// CreateInvoiceLink calls createInvoiceLink, see https://core.telegram.org/bots/api#createinvoicelink
+//
+// Use this method to create a link for an invoice. Returns the created invoice link as String
+// on success.
+func (api API) CreateInvoiceLink(ctx context.Context, r *CreateInvoiceLinkRequest) (string, error) {
+	var result string
+	err := api.call(ctx, "createInvoiceLink", r, &result)
+	return result, err
+}
+
+// CreateNewStickerSetRequest is the request of createNewStickerSet, see API.CreateNewStickerSet.
+type CreateNewStickerSetRequest struct {
+	// User identifier of created sticker set owner. Required.
+	UserID int64
+
+	// Short name of sticker set, to be used in t.me/addstickers/ URLs (e.g., animals). Can
+	// contain only English letters, digits and underscores. Must begin with a letter, can't
+	// contain consecutive underscores and must end in "_by_<bot_username>". <bot_username> is
+	// case insensitive. 1-64 characters. Required.
+	Name string
+
+	// Sticker set title, 1-64 characters. Required.
+	Title string
+
+	// A JSON-serialized list of 1-50 initial stickers to be added to the sticker set. Required.
+	Stickers interface{}
+
+	// Format of stickers in the set, must be one of “static”, “animated”, “video”.
+	// Required.
+	StickerFormat string
+
+	// Type of stickers in the set, pass “regular”, “mask”, or “custom_emoji”. By
+	// default, a regular sticker set is created.
+	StickerType string
+
+	// Pass True if stickers in the sticker set must be repainted to the color of text when used
+	// in messages, the accent color if used as emoji status, white on chat photos, or another
+	// appropriate color based on context; for custom emoji sticker sets only
+	NeedsRepainting bool
+}
+
+func (r *CreateNewStickerSetRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	params["user_id"] = strconv.FormatInt(int64(r.UserID), 10)
+
+	params["name"] = r.Name
+
+	params["title"] = r.Title
+
+	if r.Stickers != nil {
+		if err := encodeParam(params, "stickers", r.Stickers); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	params["sticker_format"] = r.StickerFormat
+
+	if r.StickerType != "" {
+		params["sticker_type"] = r.StickerType
+	}
+
+	if r.NeedsRepainting {
+		params["needs_repainting"] = "true"
+	}
+
+	return params, files, nil
+}
+
+// CreateNewStickerSet calls createNewStickerSet, see https://core.telegram.org/bots/api#createnewstickerset
+//
+// Use this method to create a new sticker set owned by a user. The bot will be able to edit
+// the sticker set thus created. Returns True on success.
+func (api API) CreateNewStickerSet(ctx context.Context, r *CreateNewStickerSetRequest) error {
+	return api.call(ctx, "createNewStickerSet", r, nil)
+}
+
+// DeclineChatJoinRequestRequest is the request of declineChatJoinRequest, see API.DeclineChatJoinRequest.
+type DeclineChatJoinRequestRequest struct {
+	// Unique identifier for the target chat or username of the target channel (in the format
+	// @channelusername). Required.
+	ChatID Recipient
+
+	// Unique identifier of the target user. Required.
+	UserID int64
+}
+
+func (r *DeclineChatJoinRequestRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	if r.ChatID != nil {
+		params["chat_id"] = r.ChatID.Recipient()
+	}
+
+	params["user_id"] = strconv.FormatInt(int64(r.UserID), 10)
+
+	return params, files, nil
+}
+
+// DeclineChatJoinRequest calls declineChatJoinRequest, see https://core.telegram.org/bots/api#declinechatjoinrequest
+//
+// Use this method to decline a chat join request. The bot must be an administrator in the
+// chat for this to work and must have the can_invite_users administrator right. Returns True
+// on success.
+func (api API) DeclineChatJoinRequest(ctx context.Context, r *DeclineChatJoinRequestRequest) error {
+	return api.call(ctx, "declineChatJoinRequest", r, nil)
+}
+
+// DeleteChatPhotoRequest is the request of deleteChatPhoto, see API.DeleteChatPhoto.
+type DeleteChatPhotoRequest struct {
+	// Unique identifier for the target chat or username of the target channel (in the format
+	// @channelusername). Required.
+	ChatID Recipient
+}
+
+func (r *DeleteChatPhotoRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	if r.ChatID != nil {
+		params["chat_id"] = r.ChatID.Recipient()
+	}
+
+	return params, files, nil
+}
+
+// DeleteChatPhoto calls deleteChatPhoto, see https://core.telegram.org/bots/api#deletechatphoto
+//
+// Use this method to delete a chat photo. Photos can't be changed for private chats. The bot
+// must be an administrator in the chat for this to work and must have the appropriate
+// administrator rights. Returns True on success.
+func (api API) DeleteChatPhoto(ctx context.Context, r *DeleteChatPhotoRequest) error {
+	return api.call(ctx, "deleteChatPhoto", r, nil)
+}
+
+// DeleteChatStickerSetRequest is the request of deleteChatStickerSet, see API.DeleteChatStickerSet.
+type DeleteChatStickerSetRequest struct {
+	// Unique identifier for the target chat or username of the target supergroup (in the format
+	// @supergroupusername). Required.
+	ChatID Recipient
+}
+
+func (r *DeleteChatStickerSetRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	if r.ChatID != nil {
+		params["chat_id"] = r.ChatID.Recipient()
+	}
+
+	return params, files, nil
+}
+
+// DeleteChatStickerSet calls deleteChatStickerSet, see https://core.telegram.org/bots/api#deletechatstickerset
+//
+// Use this method to delete a group sticker set from a supergroup. The bot must be an
+// administrator in the chat for this to work and must have the appropriate administrator
+// rights. Use the field can_set_sticker_set optionally returned in getChat requests to check
+// if the bot can use this method. Returns True on success.
+func (api API) DeleteChatStickerSet(ctx context.Context, r *DeleteChatStickerSetRequest) error {
+	return api.call(ctx, "deleteChatStickerSet", r, nil)
+}
+
+// DeleteForumTopicRequest is the request of deleteForumTopic, see API.DeleteForumTopic.
+type DeleteForumTopicRequest struct {
+	// Unique identifier for the target chat or username of the target supergroup (in the format
+	// @supergroupusername). Required.
+	ChatID Recipient
+
+	// Unique identifier for the target message thread of the forum topic. Required.
+	MessageThreadID int
+}
+
+func (r *DeleteForumTopicRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	if r.ChatID != nil {
+		params["chat_id"] = r.ChatID.Recipient()
+	}
+
+	params["message_thread_id"] = strconv.FormatInt(int64(r.MessageThreadID), 10)
+
+	return params, files, nil
+}
+
+// DeleteForumTopic calls deleteForumTopic, see https://core.telegram.org/bots/api#deleteforumtopic
+//
+// Use this method to delete a forum topic along with all its messages in a forum supergroup
+// chat. The bot must be an administrator in the chat for this to work and must have the
+// can_delete_messages administrator rights. Returns True on success.
+func (api API) DeleteForumTopic(ctx context.Context, r *DeleteForumTopicRequest) error {
+	return api.call(ctx, "deleteForumTopic", r, nil)
+}
+
+// DeleteMessageRequest is the request of deleteMessage, see API.DeleteMessage.
+type DeleteMessageRequest struct {
+	// Unique identifier for the target chat or username of the target channel (in the format
+	// @channelusername). Required.
+	ChatID Recipient
+
+	// Identifier of the message to delete. Required.
+	MessageID int
+}
+
+func (r *DeleteMessageRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	if r.ChatID != nil {
+		params["chat_id"] = r.ChatID.Recipient()
+	}
+
+	params["message_id"] = strconv.FormatInt(int64(r.MessageID), 10)
+
+	return params, files, nil
+}
+
+// DeleteMessage calls deleteMessage, see https://core.telegram.org/bots/api#deletemessage
+//
+// Use this method to delete a message, including service messages, with some limitations.
+// Returns True on success.
+func (api API) DeleteMessage(ctx context.Context, r *DeleteMessageRequest) error {
+	return api.call(ctx, "deleteMessage", r, nil)
+}
+
+// DeleteMessagesRequest is the request of deleteMessages, see API.DeleteMessages.
+type DeleteMessagesRequest struct {
+	// Unique identifier for the target chat or username of the target channel (in the format
+	// @channelusername). Required.
+	ChatID Recipient
+
+	// Identifiers of 1-100 messages to delete. See deleteMessage for limitations on which
+	// messages can be deleted. Required.
+	MessageIDs []int
+}
+
+func (r *DeleteMessagesRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	if r.ChatID != nil {
+		params["chat_id"] = r.ChatID.Recipient()
+	}
+
+	if r.MessageIDs != nil {
+		if err := encodeParam(params, "message_ids", r.MessageIDs); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return params, files, nil
+}
+
+// DeleteMessages calls deleteMessages, see https://core.telegram.org/bots/api#deletemessages
+//
+// Use this method to delete multiple messages simultaneously. If some of the specified
+// messages can't be found, they are skipped. Returns True on success.
+func (api API) DeleteMessages(ctx context.Context, r *DeleteMessagesRequest) error {
+	return api.call(ctx, "deleteMessages", r, nil)
+}
+
+// DeleteMyCommandsRequest is the request of deleteMyCommands, see API.DeleteMyCommands.
+type DeleteMyCommandsRequest struct {
+	// A JSON-serialized object, describing scope of users for which the commands are relevant.
+	// Defaults to BotCommandScopeDefault.
+	Scope *CommandScope
+
+	// A two-letter ISO 639-1 language code.
+	LanguageCode string
+}
+
+func (r *DeleteMyCommandsRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	if r.Scope != nil {
+		if err := encodeParam(params, "scope", r.Scope); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if r.LanguageCode != "" {
+		params["language_code"] = r.LanguageCode
+	}
+
+	return params, files, nil
+}
+
+// DeleteMyCommands calls deleteMyCommands, see https://core.telegram.org/bots/api#deletemycommands
+//
+// Use this method to delete the list of the bot's commands for the given scope and user
+// language. Returns True on success.
+func (api API) DeleteMyCommands(ctx context.Context, r *DeleteMyCommandsRequest) error {
+	return api.call(ctx, "deleteMyCommands", r, nil)
+}
+
+// DeleteStickerFromSetRequest is the request of deleteStickerFromSet, see API.DeleteStickerFromSet.
+type DeleteStickerFromSetRequest struct {
+	// File identifier of the sticker. Required.
+	Sticker string
+}
+
+func (r *DeleteStickerFromSetRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	params["sticker"] = r.Sticker
+
+	return params, files, nil
+}
+
+// DeleteStickerFromSet calls deleteStickerFromSet, see https://core.telegram.org/bots/api#deletestickerfromset
+//
+// Use this method to delete a sticker from a set created by the bot. Returns True on success.
+func (api API) DeleteStickerFromSet(ctx context.Context, r *DeleteStickerFromSetRequest) error {
+	return api.call(ctx, "deleteStickerFromSet", r, nil)
+}
+
+// DeleteStickerSetRequest is the request of deleteStickerSet, see API.DeleteStickerSet.
+type DeleteStickerSetRequest struct {
+	// Sticker set name. Required.
+	Name string
+}
+
+func (r *DeleteStickerSetRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	params["name"] = r.Name
+
+	return params, files, nil
+}
+
+// DeleteStickerSet calls deleteStickerSet, see https://core.telegram.org/bots/api#deletestickerset
+//
+// Use this method to delete a sticker set that was created by the bot. Returns True on
+// success.
+func (api API) DeleteStickerSet(ctx context.Context, r *DeleteStickerSetRequest) error {
+	return api.call(ctx, "deleteStickerSet", r, nil)
+}
+
+// DeleteWebhookRequest is the request of deleteWebhook, see API.DeleteWebhook.
+type DeleteWebhookRequest struct {
+	// Pass True to drop all pending updates
+	DropPendingUpdates bool
+}
+
+func (r *DeleteWebhookRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	if r.DropPendingUpdates {
+		params["drop_pending_updates"] = "true"
+	}
+
+	return params, files, nil
+}
+
+// DeleteWebhook calls deleteWebhook, see https://core.telegram.org/bots/api#deletewebhook
+//
+// Use this method to remove webhook integration if you decide to switch back to getUpdates.
+// Returns True on success.
+func (api API) DeleteWebhook(ctx context.Context, r *DeleteWebhookRequest) error {
+	return api.call(ctx, "deleteWebhook", r, nil)
+}
+
+// EditChatInviteLinkRequest is the request of editChatInviteLink, see API.EditChatInviteLink.
+type EditChatInviteLinkRequest struct {
+	// Unique identifier for the target chat or username of the target channel (in the format
+	// @channelusername). Required.
+	ChatID Recipient
+
+	// The invite link to edit. Required.
+	InviteLink string
+
+	// Invite link name; 0-32 characters
+	Name string
+
+	// Point in time (Unix timestamp) when the link will expire
+	ExpireDate int
+
+	// The maximum number of users that can be members of the chat simultaneously after joining
+	// the chat via this invite link; 1-99999
+	MemberLimit int
+
+	// True, if users joining the chat via the link need to be approved by chat administrators. If
+	// True, member_limit can't be specified
+	CreatesJoinRequest bool
+}
+
+func (r *EditChatInviteLinkRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	if r.ChatID != nil {
+		params["chat_id"] = r.ChatID.Recipient()
+	}
+
+	params["invite_link"] = r.InviteLink
+
+	if r.Name != "" {
+		params["name"] = r.Name
+	}
+
+	if r.ExpireDate != 0 {
+		params["expire_date"] = strconv.FormatInt(int64(r.ExpireDate), 10)
+	}
+
+	if r.MemberLimit != 0 {
+		params["member_limit"] = strconv.FormatInt(int64(r.MemberLimit), 10)
+	}
+
+	if r.CreatesJoinRequest {
+		params["creates_join_request"] = "true"
+	}
+
+	return params, files, nil
+}
+
+// EditChatInviteLink calls editChatInviteLink, see https://core.telegram.org/bots/api#editchatinvitelink
+//
+// Use this method to edit a non-primary invite link created by the bot. The bot must be an
+// administrator in the chat for this to work and must have the appropriate administrator
+// rights. Returns the edited invite link as a ChatInviteLink object.
+func (api API) EditChatInviteLink(ctx context.Context, r *EditChatInviteLinkRequest) (*ChatInviteLink, error) {
+	var result *ChatInviteLink
+	err := api.call(ctx, "editChatInviteLink", r, &result)
+	return result, err
+}
+
+// EditForumTopicRequest is the request of editForumTopic, see API.EditForumTopic.
+type EditForumTopicRequest struct {
+	// Unique identifier for the target chat or username of the target supergroup (in the format
+	// @supergroupusername). Required.
+	ChatID Recipient
+
+	// Unique identifier for the target message thread of the forum topic. Required.
+	MessageThreadID int
+
+	// New topic name, 0-128 characters. If not specified or empty, the current name of the topic
+	// will be kept
+	Name string
+
+	// New unique identifier of the custom emoji shown as the topic icon. Use
+	// getForumTopicIconStickers to get all allowed custom emoji identifiers. Pass an empty string
+	// to remove the icon. If not specified, the current icon will be kept
+	IconCustomEmojiID string
+}
+
+func (r *EditForumTopicRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	if r.ChatID != nil {
+		params["chat_id"] = r.ChatID.Recipient()
+	}
+
+	params["message_thread_id"] = strconv.FormatInt(int64(r.MessageThreadID), 10)
+
+	if r.Name != "" {
+		params["name"] = r.Name
+	}
+
+	if r.IconCustomEmojiID != "" {
+		params["icon_custom_emoji_id"] = r.IconCustomEmojiID
+	}
+
+	return params, files, nil
+}
+
+// EditForumTopic calls editForumTopic, see https://core.telegram.org/bots/api#editforumtopic
+//
+// Use this method to edit name and icon of a topic in a forum supergroup chat. The bot must
+// be an administrator in the chat for this to work and must have can_manage_topics
+// administrator rights, unless it is the creator of the topic. Returns True on success.
+func (api API) EditForumTopic(ctx context.Context, r *EditForumTopicRequest) error {
+	return api.call(ctx, "editForumTopic", r, nil)
+}
+
+// EditGeneralForumTopicRequest is the request of editGeneralForumTopic, see API.EditGeneralForumTopic.
+type EditGeneralForumTopicRequest struct {
+	// Unique identifier for the target chat or username of the target supergroup (in the format
+	// @supergroupusername). Required.
+	ChatID Recipient
+
+	// New topic name, 1-128 characters. Required.
+	Name string
+}
+
+func (r *EditGeneralForumTopicRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	if r.ChatID != nil {
+		params["chat_id"] = r.ChatID.Recipient()
+	}
+
+	params["name"] = r.Name
+
+	return params, files, nil
+}
+
+// EditGeneralForumTopic calls editGeneralForumTopic, see https://core.telegram.org/bots/api#editgeneralforumtopic
+//
+// Use this method to edit the name of the 'General' topic in a forum supergroup chat. The bot
+// must be an administrator in the chat for this to work and must have can_manage_topics
+// administrator rights. Returns True on success.
+func (api API) EditGeneralForumTopic(ctx context.Context, r *EditGeneralForumTopicRequest) error {
+	return api.call(ctx, "editGeneralForumTopic", r, nil)
+}
+
+// EditMessageCaptionRequest is the request of editMessageCaption, see API.EditMessageCaption.
+type EditMessageCaptionRequest struct {
+	// Required if inline_message_id is not specified. Unique identifier for the target chat or
+	// username of the target channel (in the format @channelusername)
+	ChatID Recipient
+
+	// Required if inline_message_id is not specified. Identifier of the message to edit
+	MessageID int
+
+	// Required if chat_id and message_id are not specified. Identifier of the inline message
+	InlineMessageID string
+
+	// Caption, 0-1024 characters after entities parsing
+	Caption string
+
+	// Mode for parsing entities in the caption. See formatting options for more details.
+	ParseMode string
+
+	// A JSON-serialized list of special entities that appear in the caption, which can be
+	// specified instead of parse_mode
+	CaptionEntities Entities
+
+	// A JSON-serialized object for an inline keyboard.
+	ReplyMarkup *ReplyMarkup
+}
+
+func (r *EditMessageCaptionRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	if r.ChatID != nil {
+		params["chat_id"] = r.ChatID.Recipient()
+	}
+
+	if r.MessageID != 0 {
+		params["message_id"] = strconv.FormatInt(int64(r.MessageID), 10)
+	}
+
+	if r.InlineMessageID != "" {
+		params["inline_message_id"] = r.InlineMessageID
+	}
+
+	if r.Caption != "" {
+		params["caption"] = r.Caption
+	}
+
+	if r.ParseMode != "" {
+		params["parse_mode"] = r.ParseMode
+	}
+
+	if r.CaptionEntities != nil {
+		if err := encodeParam(params, "caption_entities", r.CaptionEntities); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if r.ReplyMarkup != nil {
+		if err := encodeParam(params, "reply_markup", r.ReplyMarkup); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return params, files, nil
+}
+
+// EditMessageCaption calls editMessageCaption, see https://core.telegram.org/bots/api#editmessagecaption
+//
+// Use this method to edit captions of messages. On success, if the edited message is not an
+// inline message, the edited Message is returned, otherwise True is returned.
+func (api API) EditMessageCaption(ctx context.Context, r *EditMessageCaptionRequest) (*Message, error) {
+	var result *Message
+	err := api.call(ctx, "editMessageCaption", r, &result)
+	return result, err
+}
+
+// EditMessageLiveLocationRequest is the request of editMessageLiveLocation, see API.EditMessageLiveLocation.
+type EditMessageLiveLocationRequest struct {
+	// Required if inline_message_id is not specified. Unique identifier for the target chat or
+	// username of the target channel (in the format @channelusername)
+	ChatID Recipient
+
+	// Required if inline_message_id is not specified. Identifier of the message to edit
+	MessageID int
+
+	// Required if chat_id and message_id are not specified. Identifier of the inline message
+	InlineMessageID string
+
+	// Latitude of new location. Required.
+	Latitude float64
+
+	// Longitude of new location. Required.
+	Longitude float64
+
+	// The radius of uncertainty for the location, measured in meters; 0-1500
+	HorizontalAccuracy float64
+
+	// Direction in which the user is moving, in degrees. Must be between 1 and 360 if specified.
+	Heading int
+
+	// The maximum distance for proximity alerts about approaching another chat member, in meters.
+	// Must be between 1 and 100000 if specified.
+	ProximityAlertRadius int
+
+	// A JSON-serialized object for a new inline keyboard.
+	ReplyMarkup *ReplyMarkup
+}
+
+func (r *EditMessageLiveLocationRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	if r.ChatID != nil {
+		params["chat_id"] = r.ChatID.Recipient()
+	}
+
+	if r.MessageID != 0 {
+		params["message_id"] = strconv.FormatInt(int64(r.MessageID), 10)
+	}
+
+	if r.InlineMessageID != "" {
+		params["inline_message_id"] = r.InlineMessageID
+	}
+
+	params["latitude"] = strconv.FormatFloat(r.Latitude, 'f', -1, 64)
+
+	params["longitude"] = strconv.FormatFloat(r.Longitude, 'f', -1, 64)
+
+	if r.HorizontalAccuracy != 0 {
+		params["horizontal_accuracy"] = strconv.FormatFloat(r.HorizontalAccuracy, 'f', -1, 64)
+	}
+
+	if r.Heading != 0 {
+		params["heading"] = strconv.FormatInt(int64(r.Heading), 10)
+	}
+
+	if r.ProximityAlertRadius != 0 {
+		params["proximity_alert_radius"] = strconv.FormatInt(int64(r.ProximityAlertRadius), 10)
+	}
+
+	if r.ReplyMarkup != nil {
+		if err := encodeParam(params, "reply_markup", r.ReplyMarkup); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return params, files, nil
+}
+
+// EditMessageLiveLocation calls editMessageLiveLocation, see https://core.telegram.org/bots/api#editmessagelivelocation
+//
+// Use this method to edit live location messages. A location can be edited until its
+// live_period expires or editing is explicitly disabled by a call to stopMessageLiveLocation.
+// On success, if the edited message is not an inline message, the edited Message is returned,
+// otherwise True is returned.
+func (api API) EditMessageLiveLocation(ctx context.Context, r *EditMessageLiveLocationRequest) (*Message, error) {
+	var result *Message
+	err := api.call(ctx, "editMessageLiveLocation", r, &result)
+	return result, err
+}
+
+// EditMessageMediaRequest is the request of editMessageMedia, see API.EditMessageMedia.
+type EditMessageMediaRequest struct {
+	// Required if inline_message_id is not specified. Unique identifier for the target chat or
+	// username of the target channel (in the format @channelusername)
+	ChatID Recipient
+
+	// Required if inline_message_id is not specified. Identifier of the message to edit
+	MessageID int
+
+	// Required if chat_id and message_id are not specified. Identifier of the inline message
+	InlineMessageID string
+
+	// A JSON-serialized object for a new media content of the message. Required.
+	Media Inputtable
+
+	// A JSON-serialized object for a new inline keyboard.
+	ReplyMarkup *ReplyMarkup
+}
+
+func (r *EditMessageMediaRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	if r.ChatID != nil {
+		params["chat_id"] = r.ChatID.Recipient()
+	}
+
+	if r.MessageID != 0 {
+		params["message_id"] = strconv.FormatInt(int64(r.MessageID), 10)
+	}
+
+	if r.InlineMessageID != "" {
+		params["inline_message_id"] = r.InlineMessageID
+	}
+
+	if r.Media != nil {
+		if err := encodeMedia(params, files, "media", r.Media); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if r.ReplyMarkup != nil {
+		if err := encodeParam(params, "reply_markup", r.ReplyMarkup); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return params, files, nil
+}
+
+// EditMessageMedia calls editMessageMedia, see https://core.telegram.org/bots/api#editmessagemedia
+//
+// Use this method to edit animation, audio, document, photo, or video messages. If a message
+// is part of a message album, then it can be edited only to an audio for audio albums, only
+// to a document for document albums and to a photo or a video otherwise. When an inline
+// message is edited, a new file can't be uploaded; use a previously uploaded file via its
+// file_id or specify a URL. On success, if the edited message is not an inline message, the
+// edited Message is returned, otherwise True is returned.
+func (api API) EditMessageMedia(ctx context.Context, r *EditMessageMediaRequest) (*Message, error) {
+	var result *Message
+	err := api.call(ctx, "editMessageMedia", r, &result)
+	return result, err
+}
+
+// EditMessageReplyMarkupRequest is the request of editMessageReplyMarkup, see API.EditMessageReplyMarkup.
+type EditMessageReplyMarkupRequest struct {
+	// Required if inline_message_id is not specified. Unique identifier for the target chat or
+	// username of the target channel (in the format @channelusername)
+	ChatID Recipient
+
+	// Required if inline_message_id is not specified. Identifier of the message to edit
+	MessageID int
+
+	// Required if chat_id and message_id are not specified. Identifier of the inline message
+	InlineMessageID string
+
+	// A JSON-serialized object for an inline keyboard.
+	ReplyMarkup *ReplyMarkup
+}
+
+func (r *EditMessageReplyMarkupRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	if r.ChatID != nil {
+		params["chat_id"] = r.ChatID.Recipient()
+	}
+
+	if r.MessageID != 0 {
+		params["message_id"] = strconv.FormatInt(int64(r.MessageID), 10)
+	}
+
+	if r.InlineMessageID != "" {
+		params["inline_message_id"] = r.InlineMessageID
+	}
+
+	if r.ReplyMarkup != nil {
+		if err := encodeParam(params, "reply_markup", r.ReplyMarkup); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return params, files, nil
+}
+
+// EditMessageReplyMarkup calls editMessageReplyMarkup, see https://core.telegram.org/bots/api#editmessagereplymarkup
+//
+// Use this method to edit only the reply markup of messages. On success, if the edited
+// message is not an inline message, the edited Message is returned, otherwise True is
+// returned.
+func (api API) EditMessageReplyMarkup(ctx context.Context, r *EditMessageReplyMarkupRequest) (*Message, error) {
+	var result *Message
+	err := api.call(ctx, "editMessageReplyMarkup", r, &result)
+	return result, err
+}
+
+// EditMessageTextRequest is the request of editMessageText, see API.EditMessageText.
+type EditMessageTextRequest struct {
+	// Required if inline_message_id is not specified. Unique identifier for the target chat or
+	// username of the target channel (in the format @channelusername)
+	ChatID Recipient
+
+	// Required if inline_message_id is not specified. Identifier of the message to edit
+	MessageID int
+
+	// Required if chat_id and message_id are not specified. Identifier of the inline message
+	InlineMessageID string
+
+	// New text of the message, 1-4096 characters after entities parsing. Required.
+	Text string
+
+	// Mode for parsing entities in the message text. See formatting options for more details.
+	ParseMode string
+
+	// A JSON-serialized list of special entities that appear in message text, which can be
+	// specified instead of parse_mode
+	Entities Entities
+
+	// Link preview generation options for the message
+	LinkPreviewOptions interface{}
+
+	// A JSON-serialized object for an inline keyboard.
+	ReplyMarkup *ReplyMarkup
+}
+
+func (r *EditMessageTextRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	if r.ChatID != nil {
+		params["chat_id"] = r.ChatID.Recipient()
+	}
+
+	if r.MessageID != 0 {
+		params["message_id"] = strconv.FormatInt(int64(r.MessageID), 10)
+	}
+
+	if r.InlineMessageID != "" {
+		params["inline_message_id"] = r.InlineMessageID
+	}
+
+	params["text"] = r.Text
+
+	if r.ParseMode != "" {
+		params["parse_mode"] = r.ParseMode
+	}
+
+	if r.Entities != nil {
+		if err := encodeParam(params, "entities", r.Entities); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if r.LinkPreviewOptions != nil {
+		if err := encodeParam(params, "link_preview_options", r.LinkPreviewOptions); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if r.ReplyMarkup != nil {
+		if err := encodeParam(params, "reply_markup", r.ReplyMarkup); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return params, files, nil
+}
+
+// EditMessageText calls editMessageText, see https://core.telegram.org/bots/api#editmessagetext
+//
+// Use this method to edit text and game messages. On success, if the edited message is not an
+// inline message, the edited Message is returned, otherwise True is returned.
+func (api API) EditMessageText(ctx context.Context, r *EditMessageTextRequest) (*Message, error) {
+	var result *Message
+	err := api.call(ctx, "editMessageText", r, &result)
+	return result, err
+}
+
+// ExportChatInviteLinkRequest is the request of exportChatInviteLink, see API.ExportChatInviteLink.
+type ExportChatInviteLinkRequest struct {
+	// Unique identifier for the target chat or username of the target channel (in the format
+	// @channelusername). Required.
+	ChatID Recipient
+}
+
+func (r *ExportChatInviteLinkRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	if r.ChatID != nil {
+		params["chat_id"] = r.ChatID.Recipient()
+	}
+
+	return params, files, nil
+}
+
+// ExportChatInviteLink calls exportChatInviteLink, see https://core.telegram.org/bots/api#exportchatinvitelink
+//
+// Use this method to generate a new primary invite link for a chat; any previously generated
+// primary link is revoked. The bot must be an administrator in the chat for this to work and
+// must have the appropriate administrator rights. Returns the new invite link as String on
+// success.
+func (api API) ExportChatInviteLink(ctx context.Context, r *ExportChatInviteLinkRequest) (string, error) {
+	var result string
+	err := api.call(ctx, "exportChatInviteLink", r, &result)
+	return result, err
+}
+
+// ForwardMessageRequest is the request of forwardMessage, see API.ForwardMessage.
+type ForwardMessageRequest struct {
+	// Unique identifier for the target chat or username of the target channel (in the format
+	// @channelusername). Required.
+	ChatID Recipient
+
+	// Unique identifier for the target message thread (topic) of the forum; for forum supergroups
+	// only
+	MessageThreadID int
+
+	// Unique identifier for the chat where the original message was sent (or channel username in
+	// the format @channelusername). Required.
+	FromChatID Recipient
+
+	// Sends the message silently. Users will receive a notification with no sound.
+	DisableNotification bool
+
+	// Protects the contents of the forwarded message from forwarding and saving
+	ProtectContent bool
+
+	// Message identifier in the chat specified in from_chat_id. Required.
+	MessageID int
+}
+
+func (r *ForwardMessageRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	if r.ChatID != nil {
+		params["chat_id"] = r.ChatID.Recipient()
+	}
+
+	if r.MessageThreadID != 0 {
+		params["message_thread_id"] = strconv.FormatInt(int64(r.MessageThreadID), 10)
+	}
+
+	if r.FromChatID != nil {
+		params["from_chat_id"] = r.FromChatID.Recipient()
+	}
+
+	if r.DisableNotification {
+		params["disable_notification"] = "true"
+	}
+
+	if r.ProtectContent {
+		params["protect_content"] = "true"
+	}
+
+	params["message_id"] = strconv.FormatInt(int64(r.MessageID), 10)
+
+	return params, files, nil
+}
+
+// ForwardMessage calls forwardMessage, see https://core.telegram.org/bots/api#forwardmessage
+//
+// Use this method to forward messages of any kind. On success, the sent Message is returned.
+func (api API) ForwardMessage(ctx context.Context, r *ForwardMessageRequest) (*Message, error) {
+	var result *Message
+	err := api.call(ctx, "forwardMessage", r, &result)
+	return result, err
+}
+
+// ForwardMessagesRequest is the request of forwardMessages, see API.ForwardMessages.
+type ForwardMessagesRequest struct {
+	// Unique identifier for the target chat or username of the target channel (in the format
+	// @channelusername). Required.
+	ChatID Recipient
+
+	// Unique identifier for the target message thread (topic) of the forum; for forum supergroups
+	// only
+	MessageThreadID int
+
+	// Unique identifier for the chat where the original messages were sent (or channel username
+	// in the format @channelusername). Required.
+	FromChatID Recipient
+
+	// Identifiers of 1-100 messages in the chat from_chat_id to forward. The identifiers must be
+	// specified in a strictly increasing order. Required.
+	MessageIDs []int
+
+	// Sends the messages silently. Users will receive a notification with no sound.
+	DisableNotification bool
+
+	// Protects the contents of the forwarded messages from forwarding and saving
+	ProtectContent bool
+}
+
+func (r *ForwardMessagesRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	if r.ChatID != nil {
+		params["chat_id"] = r.ChatID.Recipient()
+	}
+
+	if r.MessageThreadID != 0 {
+		params["message_thread_id"] = strconv.FormatInt(int64(r.MessageThreadID), 10)
+	}
+
+	if r.FromChatID != nil {
+		params["from_chat_id"] = r.FromChatID.Recipient()
+	}
+
+	if r.MessageIDs != nil {
+		if err := encodeParam(params, "message_ids", r.MessageIDs); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if r.DisableNotification {
+		params["disable_notification"] = "true"
+	}
+
+	if r.ProtectContent {
+		params["protect_content"] = "true"
+	}
+
+	return params, files, nil
+}
+
+// ForwardMessages calls forwardMessages, see https://core.telegram.org/bots/api#forwardmessages
+//
+// Use this method to forward multiple messages of any kind. If some of the specified messages
+// can't be found or forwarded, they are skipped. Service messages and messages with protected
+// content can't be forwarded. Album grouping is kept for forwarded messages. On success, an
+// array of MessageId of the sent messages is returned.
+func (api API) ForwardMessages(ctx context.Context, r *ForwardMessagesRequest) (json.RawMessage, error) {
+	var result json.RawMessage
+	err := api.call(ctx, "forwardMessages", r, &result)
+	return result, err
+}
+
+// GetChatRequest is the request of getChat, see API.GetChat.
+type GetChatRequest struct {
+	// Unique identifier for the target chat or username of the target supergroup or channel (in
+	// the format @channelusername). Required.
+	ChatID Recipient
+}
+
+func (r *GetChatRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	if r.ChatID != nil {
+		params["chat_id"] = r.ChatID.Recipient()
+	}
+
+	return params, files, nil
+}
+
+// GetChat calls getChat, see https://core.telegram.org/bots/api#getchat
+//
+// Use this method to get up to date information about the chat. Returns a Chat object on
+// success.
+func (api API) GetChat(ctx context.Context, r *GetChatRequest) (*Chat, error) {
+	var result *Chat
+	err := api.call(ctx, "getChat", r, &result)
+	return result, err
+}
+
+// GetChatAdministratorsRequest is the request of getChatAdministrators, see API.GetChatAdministrators.
+type GetChatAdministratorsRequest struct {
+	// Unique identifier for the target chat or username of the target supergroup or channel (in
+	// the format @channelusername). Required.
+	ChatID Recipient
+}
+
+func (r *GetChatAdministratorsRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	if r.ChatID != nil {
+		params["chat_id"] = r.ChatID.Recipient()
+	}
+
+	return params, files, nil
+}
+
+// GetChatAdministrators calls getChatAdministrators, see https://core.telegram.org/bots/api#getchatadministrators
+//
+// Use this method to get a list of administrators in a chat, which aren't bots. Returns an
+// Array of ChatMember objects.
+func (api API) GetChatAdministrators(ctx context.Context, r *GetChatAdministratorsRequest) ([]ChatMember, error) {
+	var result []ChatMember
+	err := api.call(ctx, "getChatAdministrators", r, &result)
+	return result, err
+}
+
+// GetChatMemberRequest is the request of getChatMember, see API.GetChatMember.
+type GetChatMemberRequest struct {
+	// Unique identifier for the target chat or username of the target supergroup or channel (in
+	// the format @channelusername). Required.
+	ChatID Recipient
+
+	// Unique identifier of the target user. Required.
+	UserID int64
+}
+
+func (r *GetChatMemberRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	if r.ChatID != nil {
+		params["chat_id"] = r.ChatID.Recipient()
+	}
+
+	params["user_id"] = strconv.FormatInt(int64(r.UserID), 10)
+
+	return params, files, nil
+}
+
+// GetChatMember calls getChatMember, see https://core.telegram.org/bots/api#getchatmember
+//
+// Use this method to get information about a member of a chat. Returns a ChatMember object on
+// success.
+func (api API) GetChatMember(ctx context.Context, r *GetChatMemberRequest) (*ChatMember, error) {
+	var result *ChatMember
+	err := api.call(ctx, "getChatMember", r, &result)
+	return result, err
+}
+
+// GetChatMemberCountRequest is the request of getChatMemberCount, see API.GetChatMemberCount.
+type GetChatMemberCountRequest struct {
+	// Unique identifier for the target chat or username of the target supergroup or channel (in
+	// the format @channelusername). Required.
+	ChatID Recipient
+}
+
+func (r *GetChatMemberCountRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	if r.ChatID != nil {
+		params["chat_id"] = r.ChatID.Recipient()
+	}
+
+	return params, files, nil
+}
+
+// GetChatMemberCount calls getChatMemberCount, see https://core.telegram.org/bots/api#getchatmembercount
+//
+// Use this method to get the number of members in a chat. Returns Int on success.
+func (api API) GetChatMemberCount(ctx context.Context, r *GetChatMemberCountRequest) (int, error) {
+	var result int
+	err := api.call(ctx, "getChatMemberCount", r, &result)
+	return result, err
+}
+
+// GetChatMenuButtonRequest is the request of getChatMenuButton, see API.GetChatMenuButton.
+type GetChatMenuButtonRequest struct {
+	// Unique identifier for the target private chat. If not specified, default bot's menu button
+	// will be returned
+	ChatID int64
+}
+
+func (r *GetChatMenuButtonRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	if r.ChatID != 0 {
+		params["chat_id"] = strconv.FormatInt(int64(r.ChatID), 10)
+	}
+
+	return params, files, nil
+}
+
+// GetChatMenuButton calls getChatMenuButton, see https://core.telegram.org/bots/api#getchatmenubutton
+//
+// Use this method to get the current value of the bot's menu button in a private chat, or the
+// default menu button. Returns MenuButton on success.
+func (api API) GetChatMenuButton(ctx context.Context, r *GetChatMenuButtonRequest) (*MenuButton, error) {
+	var result *MenuButton
+	err := api.call(ctx, "getChatMenuButton", r, &result)
+	return result, err
+}
+
+// GetCustomEmojiStickersRequest is the request of getCustomEmojiStickers, see API.GetCustomEmojiStickers.
+type GetCustomEmojiStickersRequest struct {
+	// List of custom emoji identifiers. At most 200 custom emoji identifiers can be specified.
+	// Required.
+	CustomEmojiIDs []string
+}
+
+func (r *GetCustomEmojiStickersRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	if r.CustomEmojiIDs != nil {
+		if err := encodeParam(params, "custom_emoji_ids", r.CustomEmojiIDs); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return params, files, nil
+}
+
+// GetCustomEmojiStickers calls getCustomEmojiStickers, see https://core.telegram.org/bots/api#getcustomemojistickers
+//
+// Use this method to get information about custom emoji stickers by their identifiers.
+// Returns an Array of Sticker objects.
+func (api API) GetCustomEmojiStickers(ctx context.Context, r *GetCustomEmojiStickersRequest) ([]Sticker, error) {
+	var result []Sticker
+	err := api.call(ctx, "getCustomEmojiStickers", r, &result)
+	return result, err
+}
+
+// GetFileRequest is the request of getFile, see API.GetFile.
+type GetFileRequest struct {
+	// File identifier to get information about. Required.
+	FileID string
+}
+
+func (r *GetFileRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	params["file_id"] = r.FileID
+
+	return params, files, nil
+}
+
+// GetFile calls getFile, see https://core.telegram.org/bots/api#getfile
+//
+// Use this method to get basic information about a file and prepare it for downloading. On
+// success, a File object is returned.
+func (api API) GetFile(ctx context.Context, r *GetFileRequest) (*File, error) {
+	var result *File
+	err := api.call(ctx, "getFile", r, &result)
+	return result, err
+}
+
+// GetForumTopicIconStickers calls getForumTopicIconStickers, see https://core.telegram.org/bots/api#getforumtopiciconstickers
+//
+// Use this method to get custom emoji stickers, which can be used as a forum topic icon by
+// any user. Requires no parameters. Returns an Array of Sticker objects.
+func (api API) GetForumTopicIconStickers(ctx context.Context) ([]Sticker, error) {
+	var result []Sticker
+	err := api.call(ctx, "getForumTopicIconStickers", nil, &result)
+	return result, err
+}
+
+// GetGameHighScoresRequest is the request of getGameHighScores, see API.GetGameHighScores.
+type GetGameHighScoresRequest struct {
+	// Target user id. Required.
+	UserID int64
+
+	// Required if inline_message_id is not specified. Unique identifier for the target chat
+	ChatID int64
+
+	// Required if inline_message_id is not specified. Identifier of the sent message
+	MessageID int
+
+	// Required if chat_id and message_id are not specified. Identifier of the inline message
+	InlineMessageID string
+}
+
+func (r *GetGameHighScoresRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	params["user_id"] = strconv.FormatInt(int64(r.UserID), 10)
+
+	if r.ChatID != 0 {
+		params["chat_id"] = strconv.FormatInt(int64(r.ChatID), 10)
+	}
+
+	if r.MessageID != 0 {
+		params["message_id"] = strconv.FormatInt(int64(r.MessageID), 10)
+	}
+
+	if r.InlineMessageID != "" {
+		params["inline_message_id"] = r.InlineMessageID
+	}
+
+	return params, files, nil
+}
+
+// GetGameHighScores calls getGameHighScores, see https://core.telegram.org/bots/api#getgamehighscores
+//
+// Use this method to get data for high score tables. Will return the score of the specified
+// user and several of their neighbors in a game. Returns an Array of GameHighScore objects.
+func (api API) GetGameHighScores(ctx context.Context, r *GetGameHighScoresRequest) ([]GameHighScore, error) {
+	var result []GameHighScore
+	err := api.call(ctx, "getGameHighScores", r, &result)
+	return result, err
+}
+
+// GetMe calls getMe, see https://core.telegram.org/bots/api#getme
+//
+// A simple method for testing your bot's authentication token. Returns basic information
+// about the bot in form of a User object.
+func (api API) GetMe(ctx context.Context) (*User, error) {
+	var result *User
+	err := api.call(ctx, "getMe", nil, &result)
+	return result, err
+}
+
+// GetMyCommandsRequest is the request of getMyCommands, see API.GetMyCommands.
+type GetMyCommandsRequest struct {
+	// A JSON-serialized object, describing scope of users. Defaults to BotCommandScopeDefault.
+	Scope *CommandScope
+
+	// A two-letter ISO 639-1 language code or an empty string
+	LanguageCode string
+}
+
+func (r *GetMyCommandsRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	if r.Scope != nil {
+		if err := encodeParam(params, "scope", r.Scope); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if r.LanguageCode != "" {
+		params["language_code"] = r.LanguageCode
+	}
+
+	return params, files, nil
+}
+
+// GetMyCommands calls getMyCommands, see https://core.telegram.org/bots/api#getmycommands
+//
+// Use this method to get the current list of the bot's commands for the given scope and user
+// language. Returns an Array of BotCommand objects.
+func (api API) GetMyCommands(ctx context.Context, r *GetMyCommandsRequest) ([]Command, error) {
+	var result []Command
+	err := api.call(ctx, "getMyCommands", r, &result)
+	return result, err
+}
+
+// GetMyDefaultAdministratorRightsRequest is the request of getMyDefaultAdministratorRights, see API.GetMyDefaultAdministratorRights.
+type GetMyDefaultAdministratorRightsRequest struct {
+	// Pass True to get default administrator rights of the bot in channels. Otherwise, default
+	// administrator rights of the bot for groups and supergroups will be returned.
+	ForChannels bool
+}
+
+func (r *GetMyDefaultAdministratorRightsRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	if r.ForChannels {
+		params["for_channels"] = "true"
+	}
+
+	return params, files, nil
+}
+
+// GetMyDefaultAdministratorRights calls getMyDefaultAdministratorRights, see https://core.telegram.org/bots/api#getmydefaultadministratorrights
+//
+// Use this method to get the current default administrator rights of the bot. Returns
+// ChatAdministratorRights on success.
+func (api API) GetMyDefaultAdministratorRights(ctx context.Context, r *GetMyDefaultAdministratorRightsRequest) (*Rights, error) {
+	var result *Rights
+	err := api.call(ctx, "getMyDefaultAdministratorRights", r, &result)
+	return result, err
+}
+
+// GetMyDescriptionRequest is the request of getMyDescription, see API.GetMyDescription.
+type GetMyDescriptionRequest struct {
+	// A two-letter ISO 639-1 language code or an empty string
+	LanguageCode string
+}
+
+func (r *GetMyDescriptionRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	if r.LanguageCode != "" {
+		params["language_code"] = r.LanguageCode
+	}
+
+	return params, files, nil
+}
+
+// GetMyDescription calls getMyDescription, see https://core.telegram.org/bots/api#getmydescription
+//
+// Use this method to get the current bot description for the given user language. Returns
+// BotDescription on success.
+func (api API) GetMyDescription(ctx context.Context, r *GetMyDescriptionRequest) (json.RawMessage, error) {
+	var result json.RawMessage
+	err := api.call(ctx, "getMyDescription", r, &result)
+	return result, err
+}
+
+// GetMyNameRequest is the request of getMyName, see API.GetMyName.
+type GetMyNameRequest struct {
+	// A two-letter ISO 639-1 language code or an empty string
+	LanguageCode string
+}
+
+func (r *GetMyNameRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	if r.LanguageCode != "" {
+		params["language_code"] = r.LanguageCode
+	}
+
+	return params, files, nil
+}
+
+// GetMyName calls getMyName, see https://core.telegram.org/bots/api#getmyname
+//
+// Use this method to get the current bot name for the given user language. Returns BotName on
+// success.
+func (api API) GetMyName(ctx context.Context, r *GetMyNameRequest) (json.RawMessage, error) {
+	var result json.RawMessage
+	err := api.call(ctx, "getMyName", r, &result)
+	return result, err
+}
+
+// GetMyShortDescriptionRequest is the request of getMyShortDescription, see API.GetMyShortDescription.
+type GetMyShortDescriptionRequest struct {
+	// A two-letter ISO 639-1 language code or an empty string
+	LanguageCode string
+}
+
+func (r *GetMyShortDescriptionRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	if r.LanguageCode != "" {
+		params["language_code"] = r.LanguageCode
+	}
+
+	return params, files, nil
+}
+
+// GetMyShortDescription calls getMyShortDescription, see https://core.telegram.org/bots/api#getmyshortdescription
+//
+// Use this method to get the current bot short description for the given user language.
+// Returns BotShortDescription on success.
+func (api API) GetMyShortDescription(ctx context.Context, r *GetMyShortDescriptionRequest) (json.RawMessage, error) {
+	var result json.RawMessage
+	err := api.call(ctx, "getMyShortDescription", r, &result)
+	return result, err
+}
+
+// GetStickerSetRequest is the request of getStickerSet, see API.GetStickerSet.
+type GetStickerSetRequest struct {
+	// Sticker set name. Required.
+	Name string
+}
+
+func (r *GetStickerSetRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	params["name"] = r.Name
+
+	return params, files, nil
+}
+
+// GetStickerSet calls getStickerSet, see https://core.telegram.org/bots/api#getstickerset
+//
+// Use this method to get a sticker set. On success, a StickerSet object is returned.
+func (api API) GetStickerSet(ctx context.Context, r *GetStickerSetRequest) (*StickerSet, error) {
+	var result *StickerSet
+	err := api.call(ctx, "getStickerSet", r, &result)
+	return result, err
+}
+
+// GetUpdatesRequest is the request of getUpdates, see API.GetUpdates.
+type GetUpdatesRequest struct {
+	// Identifier of the first update to be returned.
+	Offset int
+
+	// Limits the number of updates to be retrieved. Values between 1-100 are accepted. Defaults
+	// to 100.
+	Limit int
+
+	// Timeout in seconds for long polling. Defaults to 0, i.e. usual short polling.
+	Timeout int
+
+	// A JSON-serialized list of the update types you want your bot to receive.
+	AllowedUpdates []string
+}
+
+func (r *GetUpdatesRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	if r.Offset != 0 {
+		params["offset"] = strconv.FormatInt(int64(r.Offset), 10)
+	}
+
+	if r.Limit != 0 {
+		params["limit"] = strconv.FormatInt(int64(r.Limit), 10)
+	}
+
+	if r.Timeout != 0 {
+		params["timeout"] = strconv.FormatInt(int64(r.Timeout), 10)
+	}
+
+	if r.AllowedUpdates != nil {
+		if err := encodeParam(params, "allowed_updates", r.AllowedUpdates); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return params, files, nil
+}
+
+// GetUpdates calls getUpdates, see https://core.telegram.org/bots/api#getupdates
+//
+// Use this method to receive incoming updates using long polling. Returns an Array of Update
+// objects.
+func (api API) GetUpdates(ctx context.Context, r *GetUpdatesRequest) ([]Update, error) {
+	var result []Update
+	err := api.call(ctx, "getUpdates", r, &result)
+	return result, err
+}
+
+// GetUserChatBoostsRequest is the request of getUserChatBoosts, see API.GetUserChatBoosts.
+type GetUserChatBoostsRequest struct {
+	// Unique identifier for the chat or username of the channel (in the format @channelusername).
+	// Required.
+	ChatID Recipient
+
+	// Unique identifier of the target user. Required.
+	UserID int64
+}
+
+func (r *GetUserChatBoostsRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	if r.ChatID != nil {
+		params["chat_id"] = r.ChatID.Recipient()
+	}
+
+	params["user_id"] = strconv.FormatInt(int64(r.UserID), 10)
+
+	return params, files, nil
+}
+
+// GetUserChatBoosts calls getUserChatBoosts, see https://core.telegram.org/bots/api#getuserchatboosts
+//
+// Use this method to get the list of boosts added to a chat by a user. Requires administrator
+// rights in the chat. Returns a UserChatBoosts object.
+func (api API) GetUserChatBoosts(ctx context.Context, r *GetUserChatBoostsRequest) (json.RawMessage, error) {
+	var result json.RawMessage
+	err := api.call(ctx, "getUserChatBoosts", r, &result)
+	return result, err
+}
+
+// GetUserProfilePhotosRequest is the request of getUserProfilePhotos, see API.GetUserProfilePhotos.
+type GetUserProfilePhotosRequest struct {
+	// Unique identifier of the target user. Required.
+	UserID int64
+
+	// Sequential number of the first photo to be returned. By default, all photos are returned.
+	Offset int
+
+	// Limits the number of photos to be retrieved. Values between 1-100 are accepted. Defaults to
+	// 100.
+	Limit int
+}
+
+func (r *GetUserProfilePhotosRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	params["user_id"] = strconv.FormatInt(int64(r.UserID), 10)
+
+	if r.Offset != 0 {
+		params["offset"] = strconv.FormatInt(int64(r.Offset), 10)
+	}
+
+	if r.Limit != 0 {
+		params["limit"] = strconv.FormatInt(int64(r.Limit), 10)
+	}
+
+	return params, files, nil
+}
+
+// GetUserProfilePhotos calls getUserProfilePhotos, see https://core.telegram.org/bots/api#getuserprofilephotos
+//
+// Use this method to get a list of profile pictures for a user. Returns a UserProfilePhotos
+// object.
+func (api API) GetUserProfilePhotos(ctx context.Context, r *GetUserProfilePhotosRequest) (json.RawMessage, error) {
+	var result json.RawMessage
+	err := api.call(ctx, "getUserProfilePhotos", r, &result)
+	return result, err
+}
+
+// GetWebhookInfo calls getWebhookInfo, see https://core.telegram.org/bots/api#getwebhookinfo
+//
+// Use this method to get current webhook status. On success, returns a WebhookInfo object.
+func (api API) GetWebhookInfo(ctx context.Context) (json.RawMessage, error) {
+	var result json.RawMessage
+	err := api.call(ctx, "getWebhookInfo", nil, &result)
+	return result, err
+}
+
+// HideGeneralForumTopicRequest is the request of hideGeneralForumTopic, see API.HideGeneralForumTopic.
+type HideGeneralForumTopicRequest struct {
+	// Unique identifier for the target chat or username of the target supergroup (in the format
+	// @supergroupusername). Required.
+	ChatID Recipient
+}
+
+func (r *HideGeneralForumTopicRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	if r.ChatID != nil {
+		params["chat_id"] = r.ChatID.Recipient()
+	}
+
+	return params, files, nil
+}
+
+// HideGeneralForumTopic calls hideGeneralForumTopic, see https://core.telegram.org/bots/api#hidegeneralforumtopic
+//
+// Use this method to hide the 'General' topic in a forum supergroup chat. The bot must be an
+// administrator in the chat for this to work and must have the can_manage_topics
+// administrator rights. The topic will be automatically closed if it was open. Returns True
+// on success.
+func (api API) HideGeneralForumTopic(ctx context.Context, r *HideGeneralForumTopicRequest) error {
+	return api.call(ctx, "hideGeneralForumTopic", r, nil)
+}
+
+// LeaveChatRequest is the request of leaveChat, see API.LeaveChat.
+type LeaveChatRequest struct {
+	// Unique identifier for the target chat or username of the target supergroup or channel (in
+	// the format @channelusername). Required.
+	ChatID Recipient
+}
+
+func (r *LeaveChatRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	if r.ChatID != nil {
+		params["chat_id"] = r.ChatID.Recipient()
+	}
+
+	return params, files, nil
+}
+
+// LeaveChat calls leaveChat, see https://core.telegram.org/bots/api#leavechat
+//
+// Use this method for your bot to leave a group, supergroup or channel. Returns True on
+// success.
+func (api API) LeaveChat(ctx context.Context, r *LeaveChatRequest) error {
+	return api.call(ctx, "leaveChat", r, nil)
+}
+
+// LogOut calls logOut, see https://core.telegram.org/bots/api#logout
+//
+// Use this method to log out from the cloud Bot API server before launching the bot locally.
+// Returns True on success.
+func (api API) LogOut(ctx context.Context) error {
+	return api.call(ctx, "logOut", nil, nil)
+}
+
+// PinChatMessageRequest is the request of pinChatMessage, see API.PinChatMessage.
+type PinChatMessageRequest struct {
+	// Unique identifier for the target chat or username of the target channel (in the format
+	// @channelusername). Required.
+	ChatID Recipient
+
+	// Identifier of a message to pin. Required.
+	MessageID int
+
+	// Pass True if it is not necessary to send a notification to all chat members about the new
+	// pinned message. Notifications are always disabled in channels and private chats.
+	DisableNotification bool
+}
+
+func (r *PinChatMessageRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	if r.ChatID != nil {
+		params["chat_id"] = r.ChatID.Recipient()
+	}
+
+	params["message_id"] = strconv.FormatInt(int64(r.MessageID), 10)
+
+	if r.DisableNotification {
+		params["disable_notification"] = "true"
+	}
+
+	return params, files, nil
+}
+
+// PinChatMessage calls pinChatMessage, see https://core.telegram.org/bots/api#pinchatmessage
+//
+// Use this method to add a message to the list of pinned messages in a chat. If the chat is
+// not a private chat, the bot must be an administrator in the chat for this to work and must
+// have the 'can_pin_messages' administrator right in a supergroup or 'can_edit_messages'
+// administrator right in a channel. Returns True on success.
+func (api API) PinChatMessage(ctx context.Context, r *PinChatMessageRequest) error {
+	return api.call(ctx, "pinChatMessage", r, nil)
+}
+
+// PromoteChatMemberRequest is the request of promoteChatMember, see API.PromoteChatMember.
+type PromoteChatMemberRequest struct {
+	// Unique identifier for the target chat or username of the target channel (in the format
+	// @channelusername). Required.
+	ChatID Recipient
+
+	// Unique identifier of the target user. Required.
+	UserID int64
+
+	// Pass True if the administrator's presence in the chat is hidden
+	IsAnonymous bool
+
+	// Pass True if the administrator can access the chat event log, boost list in channels, see
+	// channel members, report spam messages, see anonymous administrators in supergroups and
+	// ignore slow mode. Implied by any other administrator privilege
+	CanManageChat bool
+
+	// Pass True if the administrator can delete messages of other users
+	CanDeleteMessages bool
+
+	// Pass True if the administrator can manage video chats
+	CanManageVideoChats bool
+
+	// Pass True if the administrator can restrict, ban or unban chat members, or access
+	// supergroup statistics
+	CanRestrictMembers bool
+
+	// Pass True if the administrator can add new administrators with a subset of their own
+	// privileges or demote administrators that they have promoted, directly or indirectly
+	// (promoted by administrators that were appointed by him)
+	CanPromoteMembers bool
+
+	// Pass True if the administrator can change chat title, photo and other settings
+	CanChangeInfo bool
+
+	// Pass True if the administrator can invite new users to the chat
+	CanInviteUsers bool
+
+	// Pass True if the administrator can post messages in the channel, or access channel
+	// statistics; channels only
+	CanPostMessages bool
+
+	// Pass True if the administrator can edit messages of other users and can pin messages;
+	// channels only
+	CanEditMessages bool
+
+	// Pass True if the administrator can pin messages, supergroups only
+	CanPinMessages bool
+
+	// Pass True if the administrator can post stories in the channel; channels only
+	CanPostStories bool
+
+	// Pass True if the administrator can edit stories posted by other users; channels only
+	CanEditStories bool
+
+	// Pass True if the administrator can delete stories posted by other users; channels only
+	CanDeleteStories bool
+
+	// Pass True if the user is allowed to create, rename, close, and reopen forum topics,
+	// supergroups only
+	CanManageTopics bool
+}
+
+func (r *PromoteChatMemberRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	if r.ChatID != nil {
+		params["chat_id"] = r.ChatID.Recipient()
+	}
+
+	params["user_id"] = strconv.FormatInt(int64(r.UserID), 10)
+
+	if r.IsAnonymous {
+		params["is_anonymous"] = "true"
+	}
+
+	if r.CanManageChat {
+		params["can_manage_chat"] = "true"
+	}
+
+	if r.CanDeleteMessages {
+		params["can_delete_messages"] = "true"
+	}
+
+	if r.CanManageVideoChats {
+		params["can_manage_video_chats"] = "true"
+	}
+
+	if r.CanRestrictMembers {
+		params["can_restrict_members"] = "true"
+	}
+
+	if r.CanPromoteMembers {
+		params["can_promote_members"] = "true"
+	}
+
+	if r.CanChangeInfo {
+		params["can_change_info"] = "true"
+	}
+
+	if r.CanInviteUsers {
+		params["can_invite_users"] = "true"
+	}
+
+	if r.CanPostMessages {
+		params["can_post_messages"] = "true"
+	}
+
+	if r.CanEditMessages {
+		params["can_edit_messages"] = "true"
+	}
+
+	if r.CanPinMessages {
+		params["can_pin_messages"] = "true"
+	}
+
+	if r.CanPostStories {
+		params["can_post_stories"] = "true"
+	}
+
+	if r.CanEditStories {
+		params["can_edit_stories"] = "true"
+	}
+
+	if r.CanDeleteStories {
+		params["can_delete_stories"] = "true"
+	}
+
+	if r.CanManageTopics {
+		params["can_manage_topics"] = "true"
+	}
+
+	return params, files, nil
+}
+
+// PromoteChatMember calls promoteChatMember, see https://core.telegram.org/bots/api#promotechatmember
+//
+// Use this method to promote or demote a user in a supergroup or a channel. The bot must be
+// an administrator in the chat for this to work and must have the appropriate administrator
+// rights. Pass False for all boolean parameters to demote a user. Returns True on success.
+func (api API) PromoteChatMember(ctx context.Context, r *PromoteChatMemberRequest) error {
+	return api.call(ctx, "promoteChatMember", r, nil)
+}
+
+// ReopenForumTopicRequest is the request of reopenForumTopic, see API.ReopenForumTopic.
+type ReopenForumTopicRequest struct {
+	// Unique identifier for the target chat or username of the target supergroup (in the format
+	// @supergroupusername). Required.
+	ChatID Recipient
+
+	// Unique identifier for the target message thread of the forum topic. Required.
+	MessageThreadID int
+}
+
+func (r *ReopenForumTopicRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	if r.ChatID != nil {
+		params["chat_id"] = r.ChatID.Recipient()
+	}
+
+	params["message_thread_id"] = strconv.FormatInt(int64(r.MessageThreadID), 10)
+
+	return params, files, nil
+}
+
+// ReopenForumTopic calls reopenForumTopic, see https://core.telegram.org/bots/api#reopenforumtopic
+//
+// Use this method to reopen a closed topic in a forum supergroup chat. The bot must be an
+// administrator in the chat for this to work and must have the can_manage_topics
+// administrator rights, unless it is the creator of the topic. Returns True on success.
+func (api API) ReopenForumTopic(ctx context.Context, r *ReopenForumTopicRequest) error {
+	return api.call(ctx, "reopenForumTopic", r, nil)
+}
+
+// ReopenGeneralForumTopicRequest is the request of reopenGeneralForumTopic, see API.ReopenGeneralForumTopic.
+type ReopenGeneralForumTopicRequest struct {
+	// Unique identifier for the target chat or username of the target supergroup (in the format
+	// @supergroupusername). Required.
+	ChatID Recipient
+}
+
+func (r *ReopenGeneralForumTopicRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	if r.ChatID != nil {
+		params["chat_id"] = r.ChatID.Recipient()
+	}
+
+	return params, files, nil
+}
+
+// ReopenGeneralForumTopic calls reopenGeneralForumTopic, see https://core.telegram.org/bots/api#reopengeneralforumtopic
+//
+// Use this method to reopen a closed 'General' topic in a forum supergroup chat. The bot must
+// be an administrator in the chat for this to work and must have the can_manage_topics
+// administrator rights. The topic will be automatically unhidden if it was hidden. Returns
+// True on success.
+func (api API) ReopenGeneralForumTopic(ctx context.Context, r *ReopenGeneralForumTopicRequest) error {
+	return api.call(ctx, "reopenGeneralForumTopic", r, nil)
+}
+
+// RestrictChatMemberRequest is the request of restrictChatMember, see API.RestrictChatMember.
+type RestrictChatMemberRequest struct {
+	// Unique identifier for the target chat or username of the target supergroup (in the format
+	// @supergroupusername). Required.
+	ChatID Recipient
+
+	// Unique identifier of the target user. Required.
+	UserID int64
+
+	// A JSON-serialized object for new user permissions. Required.
+	Permissions *Rights
+
+	// Pass True if chat permissions are set independently. Otherwise, the can_send_other_messages
+	// and can_add_web_page_previews permissions will imply the can_send_messages,
+	// can_send_audios, can_send_documents, can_send_photos, can_send_videos,
+	// can_send_video_notes, and can_send_voice_notes permissions; the can_send_polls permission
+	// will imply the can_send_messages permission.
+	UseIndependentChatPermissions bool
+
+	// Date when restrictions will be lifted for the user; Unix time. If user is restricted for
+	// more than 366 days or less than 30 seconds from the current time, they are considered to be
+	// restricted forever
+	UntilDate int
+}
+
+func (r *RestrictChatMemberRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	if r.ChatID != nil {
+		params["chat_id"] = r.ChatID.Recipient()
+	}
+
+	params["user_id"] = strconv.FormatInt(int64(r.UserID), 10)
+
+	if r.Permissions != nil {
+		if err := encodeParam(params, "permissions", r.Permissions); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if r.UseIndependentChatPermissions {
+		params["use_independent_chat_permissions"] = "true"
+	}
+
+	if r.UntilDate != 0 {
+		params["until_date"] = strconv.FormatInt(int64(r.UntilDate), 10)
+	}
+
+	return params, files, nil
+}
+
+// RestrictChatMember calls restrictChatMember, see https://core.telegram.org/bots/api#restrictchatmember
+//
+// Use this method to restrict a user in a supergroup. The bot must be an administrator in the
+// supergroup for this to work and must have the appropriate administrator rights. Pass True
+// for all permissions to lift restrictions from a user. Returns True on success.
+func (api API) RestrictChatMember(ctx context.Context, r *RestrictChatMemberRequest) error {
+	return api.call(ctx, "restrictChatMember", r, nil)
+}
+
+// RevokeChatInviteLinkRequest is the request of revokeChatInviteLink, see API.RevokeChatInviteLink.
+type RevokeChatInviteLinkRequest struct {
+	// Unique identifier of the target chat or username of the target channel (in the format
+	// @channelusername). Required.
+	ChatID Recipient
+
+	// The invite link to revoke. Required.
+	InviteLink string
+}
+
+func (r *RevokeChatInviteLinkRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	if r.ChatID != nil {
+		params["chat_id"] = r.ChatID.Recipient()
+	}
+
+	params["invite_link"] = r.InviteLink
+
+	return params, files, nil
+}
+
+// RevokeChatInviteLink calls revokeChatInviteLink, see https://core.telegram.org/bots/api#revokechatinvitelink
+//
+// Use this method to revoke an invite link created by the bot. If the primary link is
+// revoked, a new link is automatically generated. The bot must be an administrator in the
+// chat for this to work and must have the appropriate administrator rights. Returns the
+// revoked invite link as ChatInviteLink object.
+func (api API) RevokeChatInviteLink(ctx context.Context, r *RevokeChatInviteLinkRequest) (*ChatInviteLink, error) {
+	var result *ChatInviteLink
+	err := api.call(ctx, "revokeChatInviteLink", r, &result)
+	return result, err
+}
+
+// SendAnimationRequest is the request of sendAnimation, see API.SendAnimation.
+type SendAnimationRequest struct {
+	// Unique identifier for the target chat or username of the target channel (in the format
+	// @channelusername). Required.
+	ChatID Recipient
+
+	// Unique identifier for the target message thread (topic) of the forum; for forum supergroups
+	// only
+	MessageThreadID int
+
+	// Animation to send. Pass a file_id as String to send a file that exists on the Telegram
+	// servers (recommended), pass an HTTP URL as a String for Telegram to get a file from the
+	// Internet, or upload a new one using multipart/form-data. Required.
+	Animation File
+
+	// Duration of sent animation in seconds
+	Duration int
+
+	// Animation width
+	Width int
+
+	// Animation height
+	Height int
+
+	// Thumbnail of the file sent; can be ignored if thumbnail generation for the file is
+	// supported server-side.
+	Thumbnail File
+
+	// Pass True if the animation needs to be covered with a spoiler animation
+	HasSpoiler bool
+
+	// Caption, 0-1024 characters after entities parsing
+	Caption string
+
+	// Mode for parsing entities in the caption. See formatting options for more details.
+	ParseMode string
+
+	// A JSON-serialized list of special entities that appear in the caption, which can be
+	// specified instead of parse_mode
+	CaptionEntities Entities
+
+	// Sends the message silently. Users will receive a notification with no sound.
+	DisableNotification bool
+
+	// Protects the contents of the sent message from forwarding and saving
+	ProtectContent bool
+
+	// Description of the message to reply to
+	ReplyParameters interface{}
+
+	// Additional interface options. A JSON-serialized object for an inline keyboard, custom reply
+	// keyboard, instructions to remove reply keyboard or to force a reply from the user.
+	ReplyMarkup *ReplyMarkup
+}
+
+func (r *SendAnimationRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	if r.ChatID != nil {
+		params["chat_id"] = r.ChatID.Recipient()
+	}
+
+	if r.MessageThreadID != 0 {
+		params["message_thread_id"] = strconv.FormatInt(int64(r.MessageThreadID), 10)
+	}
+
+	if !r.Animation.empty() {
+		files["animation"] = r.Animation
+	}
+
+	if r.Duration != 0 {
+		params["duration"] = strconv.FormatInt(int64(r.Duration), 10)
+	}
+
+	if r.Width != 0 {
+		params["width"] = strconv.FormatInt(int64(r.Width), 10)
+	}
+
+	if r.Height != 0 {
+		params["height"] = strconv.FormatInt(int64(r.Height), 10)
+	}
+
+	if !r.Thumbnail.empty() {
+		files["thumbnail"] = r.Thumbnail
+	}
+
+	if r.HasSpoiler {
+		params["has_spoiler"] = "true"
+	}
+
+	if r.Caption != "" {
+		params["caption"] = r.Caption
+	}
+
+	if r.ParseMode != "" {
+		params["parse_mode"] = r.ParseMode
+	}
+
+	if r.CaptionEntities != nil {
+		if err := encodeParam(params, "caption_entities", r.CaptionEntities); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if r.DisableNotification {
+		params["disable_notification"] = "true"
+	}
+
+	if r.ProtectContent {
+		params["protect_content"] = "true"
+	}
+
+	if r.ReplyParameters != nil {
+		if err := encodeParam(params, "reply_parameters", r.ReplyParameters); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if r.ReplyMarkup != nil {
+		if err := encodeParam(params, "reply_markup", r.ReplyMarkup); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return params, files, nil
+}
+
+// SendAnimation calls sendAnimation, see https://core.telegram.org/bots/api#sendanimation
+//
+// Use this method to send animation files (GIF or H.264/MPEG-4 AVC video without sound). On
+// success, the sent Message is returned.
+func (api API) SendAnimation(ctx context.Context, r *SendAnimationRequest) (*Message, error) {
+	var result *Message
+	err := api.call(ctx, "sendAnimation", r, &result)
+	return result, err
+}
+
+// SendAudioRequest is the request of sendAudio, see API.SendAudio.
+type SendAudioRequest struct {
+	// Unique identifier for the target chat or username of the target channel (in the format
+	// @channelusername). Required.
+	ChatID Recipient
+
+	// Unique identifier for the target message thread (topic) of the forum; for forum supergroups
+	// only
+	MessageThreadID int
+
+	// Audio file to send. Pass a file_id as String to send a file that exists on the Telegram
+	// servers (recommended), pass an HTTP URL as a String for Telegram to get a file from the
+	// Internet, or upload a new one using multipart/form-data. Required.
+	Audio File
+
+	// Duration of the audio in seconds
+	Duration int
+
+	// Performer
+	Performer string
+
+	// Track name
+	Title string
+
+	// Thumbnail of the file sent; can be ignored if thumbnail generation for the file is
+	// supported server-side.
+	Thumbnail File
+
+	// Caption, 0-1024 characters after entities parsing
+	Caption string
+
+	// Mode for parsing entities in the caption. See formatting options for more details.
+	ParseMode string
+
+	// A JSON-serialized list of special entities that appear in the caption, which can be
+	// specified instead of parse_mode
+	CaptionEntities Entities
+
+	// Sends the message silently. Users will receive a notification with no sound.
+	DisableNotification bool
+
+	// Protects the contents of the sent message from forwarding and saving
+	ProtectContent bool
+
+	// Description of the message to reply to
+	ReplyParameters interface{}
+
+	// Additional interface options. A JSON-serialized object for an inline keyboard, custom reply
+	// keyboard, instructions to remove reply keyboard or to force a reply from the user.
+	ReplyMarkup *ReplyMarkup
+}
+
+func (r *SendAudioRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	if r.ChatID != nil {
+		params["chat_id"] = r.ChatID.Recipient()
+	}
+
+	if r.MessageThreadID != 0 {
+		params["message_thread_id"] = strconv.FormatInt(int64(r.MessageThreadID), 10)
+	}
+
+	if !r.Audio.empty() {
+		files["audio"] = r.Audio
+	}
+
+	if r.Duration != 0 {
+		params["duration"] = strconv.FormatInt(int64(r.Duration), 10)
+	}
+
+	if r.Performer != "" {
+		params["performer"] = r.Performer
+	}
+
+	if r.Title != "" {
+		params["title"] = r.Title
+	}
+
+	if !r.Thumbnail.empty() {
+		files["thumbnail"] = r.Thumbnail
+	}
+
+	if r.Caption != "" {
+		params["caption"] = r.Caption
+	}
+
+	if r.ParseMode != "" {
+		params["parse_mode"] = r.ParseMode
+	}
+
+	if r.CaptionEntities != nil {
+		if err := encodeParam(params, "caption_entities", r.CaptionEntities); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if r.DisableNotification {
+		params["disable_notification"] = "true"
+	}
+
+	if r.ProtectContent {
+		params["protect_content"] = "true"
+	}
+
+	if r.ReplyParameters != nil {
+		if err := encodeParam(params, "reply_parameters", r.ReplyParameters); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if r.ReplyMarkup != nil {
+		if err := encodeParam(params, "reply_markup", r.ReplyMarkup); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return params, files, nil
+}
+
+// SendAudio calls sendAudio, see https://core.telegram.org/bots/api#sendaudio
+//
+// Use this method to send audio files, if you want Telegram clients to display them in the
+// music player. On success, the sent Message is returned.
+func (api API) SendAudio(ctx context.Context, r *SendAudioRequest) (*Message, error) {
+	var result *Message
+	err := api.call(ctx, "sendAudio", r, &result)
+	return result, err
+}
+
+// SendChatActionRequest is the request of sendChatAction, see API.SendChatAction.
+type SendChatActionRequest struct {
+	// Unique identifier for the target chat or username of the target channel (in the format
+	// @channelusername). Required.
+	ChatID Recipient
+
+	// Unique identifier for the target message thread (topic) of the forum; for forum supergroups
+	// only
+	MessageThreadID int
+
+	// Type of action to broadcast, e.g. typing or upload_photo. Required.
+	Action string
+}
+
+func (r *SendChatActionRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	if r.ChatID != nil {
+		params["chat_id"] = r.ChatID.Recipient()
+	}
+
+	if r.MessageThreadID != 0 {
+		params["message_thread_id"] = strconv.FormatInt(int64(r.MessageThreadID), 10)
+	}
+
+	params["action"] = r.Action
+
+	return params, files, nil
+}
+
+// SendChatAction calls sendChatAction, see https://core.telegram.org/bots/api#sendchataction
+//
+// Use this method when you need to tell the user that something is happening on the bot's
+// side. Returns True on success.
+func (api API) SendChatAction(ctx context.Context, r *SendChatActionRequest) error {
+	return api.call(ctx, "sendChatAction", r, nil)
+}
+
+// SendContactRequest is the request of sendContact, see API.SendContact.
+type SendContactRequest struct {
+	// Unique identifier for the target chat or username of the target channel (in the format
+	// @channelusername). Required.
+	ChatID Recipient
+
+	// Unique identifier for the target message thread (topic) of the forum; for forum supergroups
+	// only
+	MessageThreadID int
+
+	// Contact's phone number. Required.
+	PhoneNumber string
+
+	// Contact's first name. Required.
+	FirstName string
+
+	// Contact's last name
+	LastName string
+
+	// Additional data about the contact in the form of a vCard, 0-2048 bytes
+	Vcard string
+
+	// Sends the message silently. Users will receive a notification with no sound.
+	DisableNotification bool
+
+	// Protects the contents of the sent message from forwarding and saving
+	ProtectContent bool
+
+	// Description of the message to reply to
+	ReplyParameters interface{}
+
+	// Additional interface options. A JSON-serialized object for an inline keyboard, custom reply
+	// keyboard, instructions to remove reply keyboard or to force a reply from the user.
+	ReplyMarkup *ReplyMarkup
+}
+
+func (r *SendContactRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	if r.ChatID != nil {
+		params["chat_id"] = r.ChatID.Recipient()
+	}
+
+	if r.MessageThreadID != 0 {
+		params["message_thread_id"] = strconv.FormatInt(int64(r.MessageThreadID), 10)
+	}
+
+	params["phone_number"] = r.PhoneNumber
+
+	params["first_name"] = r.FirstName
+
+	if r.LastName != "" {
+		params["last_name"] = r.LastName
+	}
+
+	if r.Vcard != "" {
+		params["vcard"] = r.Vcard
+	}
+
+	if r.DisableNotification {
+		params["disable_notification"] = "true"
+	}
+
+	if r.ProtectContent {
+		params["protect_content"] = "true"
+	}
+
+	if r.ReplyParameters != nil {
+		if err := encodeParam(params, "reply_parameters", r.ReplyParameters); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if r.ReplyMarkup != nil {
+		if err := encodeParam(params, "reply_markup", r.ReplyMarkup); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return params, files, nil
+}
+
+// SendContact calls sendContact, see https://core.telegram.org/bots/api#sendcontact
+//
+// Use this method to send phone contacts. On success, the sent Message is returned.
+func (api API) SendContact(ctx context.Context, r *SendContactRequest) (*Message, error) {
+	var result *Message
+	err := api.call(ctx, "sendContact", r, &result)
+	return result, err
+}
+
+// SendDiceRequest is the request of sendDice, see API.SendDice.
+type SendDiceRequest struct {
+	// Unique identifier for the target chat or username of the target channel (in the format
+	// @channelusername). Required.
+	ChatID Recipient
+
+	// Unique identifier for the target message thread (topic) of the forum; for forum supergroups
+	// only
+	MessageThreadID int
+
+	// Emoji on which the dice throw animation is based. Defaults to “🎲”
+	Emoji string
+
+	// Sends the message silently. Users will receive a notification with no sound.
+	DisableNotification bool
+
+	// Protects the contents of the sent message from forwarding and saving
+	ProtectContent bool
+
+	// Description of the message to reply to
+	ReplyParameters interface{}
+
+	// Additional interface options. A JSON-serialized object for an inline keyboard, custom reply
+	// keyboard, instructions to remove reply keyboard or to force a reply from the user.
+	ReplyMarkup *ReplyMarkup
+}
+
+func (r *SendDiceRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	if r.ChatID != nil {
+		params["chat_id"] = r.ChatID.Recipient()
+	}
+
+	if r.MessageThreadID != 0 {
+		params["message_thread_id"] = strconv.FormatInt(int64(r.MessageThreadID), 10)
+	}
+
+	if r.Emoji != "" {
+		params["emoji"] = r.Emoji
+	}
+
+	if r.DisableNotification {
+		params["disable_notification"] = "true"
+	}
+
+	if r.ProtectContent {
+		params["protect_content"] = "true"
+	}
+
+	if r.ReplyParameters != nil {
+		if err := encodeParam(params, "reply_parameters", r.ReplyParameters); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if r.ReplyMarkup != nil {
+		if err := encodeParam(params, "reply_markup", r.ReplyMarkup); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return params, files, nil
+}
+
+// SendDice calls sendDice, see https://core.telegram.org/bots/api#senddice
+//
+// Use this method to send an animated emoji that will display a random value. On success, the
+// sent Message is returned.
+func (api API) SendDice(ctx context.Context, r *SendDiceRequest) (*Message, error) {
+	var result *Message
+	err := api.call(ctx, "sendDice", r, &result)
+	return result, err
+}
+
+// SendDocumentRequest is the request of sendDocument, see API.SendDocument.
+type SendDocumentRequest struct {
+	// Unique identifier for the target chat or username of the target channel (in the format
+	// @channelusername). Required.
+	ChatID Recipient
+
+	// Unique identifier for the target message thread (topic) of the forum; for forum supergroups
+	// only
+	MessageThreadID int
+
+	// File to send. Pass a file_id as String to send a file that exists on the Telegram servers
+	// (recommended), pass an HTTP URL as a String for Telegram to get a file from the Internet,
+	// or upload a new one using multipart/form-data. Required.
+	Document File
+
+	// Thumbnail of the file sent; can be ignored if thumbnail generation for the file is
+	// supported server-side.
+	Thumbnail File
+
+	// Disables automatic server-side content type detection for files uploaded using
+	// multipart/form-data
+	DisableContentTypeDetection bool
+
+	// Caption, 0-1024 characters after entities parsing
+	Caption string
+
+	// Mode for parsing entities in the caption. See formatting options for more details.
+	ParseMode string
+
+	// A JSON-serialized list of special entities that appear in the caption, which can be
+	// specified instead of parse_mode
+	CaptionEntities Entities
+
+	// Sends the message silently. Users will receive a notification with no sound.
+	DisableNotification bool
+
+	// Protects the contents of the sent message from forwarding and saving
+	ProtectContent bool
+
+	// Description of the message to reply to
+	ReplyParameters interface{}
+
+	// Additional interface options. A JSON-serialized object for an inline keyboard, custom reply
+	// keyboard, instructions to remove reply keyboard or to force a reply from the user.
+	ReplyMarkup *ReplyMarkup
+}
+
+func (r *SendDocumentRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	if r.ChatID != nil {
+		params["chat_id"] = r.ChatID.Recipient()
+	}
+
+	if r.MessageThreadID != 0 {
+		params["message_thread_id"] = strconv.FormatInt(int64(r.MessageThreadID), 10)
+	}
+
+	if !r.Document.empty() {
+		files["document"] = r.Document
+	}
+
+	if !r.Thumbnail.empty() {
+		files["thumbnail"] = r.Thumbnail
+	}
+
+	if r.DisableContentTypeDetection {
+		params["disable_content_type_detection"] = "true"
+	}
+
+	if r.Caption != "" {
+		params["caption"] = r.Caption
+	}
+
+	if r.ParseMode != "" {
+		params["parse_mode"] = r.ParseMode
+	}
+
+	if r.CaptionEntities != nil {
+		if err := encodeParam(params, "caption_entities", r.CaptionEntities); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if r.DisableNotification {
+		params["disable_notification"] = "true"
+	}
+
+	if r.ProtectContent {
+		params["protect_content"] = "true"
+	}
+
+	if r.ReplyParameters != nil {
+		if err := encodeParam(params, "reply_parameters", r.ReplyParameters); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if r.ReplyMarkup != nil {
+		if err := encodeParam(params, "reply_markup", r.ReplyMarkup); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return params, files, nil
+}
+
+// SendDocument calls sendDocument, see https://core.telegram.org/bots/api#senddocument
+//
+// Use this method to send general files. On success, the sent Message is returned.
+func (api API) SendDocument(ctx context.Context, r *SendDocumentRequest) (*Message, error) {
+	var result *Message
+	err := api.call(ctx, "sendDocument", r, &result)
+	return result, err
+}
+
+// SendGameRequest is the request of sendGame, see API.SendGame.
+type SendGameRequest struct {
+	// Unique identifier for the target chat. Required.
+	ChatID int64
+
+	// Unique identifier for the target message thread (topic) of the forum; for forum supergroups
+	// only
+	MessageThreadID int
+
+	// Short name of the game, serves as the unique identifier for the game. Set up your games via
+	// @BotFather. Required.
+	GameShortName string
+
+	// Sends the message silently. Users will receive a notification with no sound.
+	DisableNotification bool
+
+	// Protects the contents of the sent message from forwarding and saving
+	ProtectContent bool
+
+	// Description of the message to reply to
+	ReplyParameters interface{}
+
+	// A JSON-serialized object for an inline keyboard. If empty, one 'Play game_title' button
+	// will be shown. If not empty, the first button must launch the game.
+	ReplyMarkup *ReplyMarkup
+}
+
+func (r *SendGameRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	params["chat_id"] = strconv.FormatInt(int64(r.ChatID), 10)
+
+	if r.MessageThreadID != 0 {
+		params["message_thread_id"] = strconv.FormatInt(int64(r.MessageThreadID), 10)
+	}
+
+	params["game_short_name"] = r.GameShortName
+
+	if r.DisableNotification {
+		params["disable_notification"] = "true"
+	}
+
+	if r.ProtectContent {
+		params["protect_content"] = "true"
+	}
+
+	if r.ReplyParameters != nil {
+		if err := encodeParam(params, "reply_parameters", r.ReplyParameters); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if r.ReplyMarkup != nil {
+		if err := encodeParam(params, "reply_markup", r.ReplyMarkup); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return params, files, nil
+}
+
+// SendGame calls sendGame, see https://core.telegram.org/bots/api#sendgame
+//
+// Use this method to send a game. On success, the sent Message is returned.
+func (api API) SendGame(ctx context.Context, r *SendGameRequest) (*Message, error) {
+	var result *Message
+	err := api.call(ctx, "sendGame", r, &result)
+	return result, err
+}
+
+// SendInvoiceRequest is the request of sendInvoice, see API.SendInvoice.
+type SendInvoiceRequest struct {
+	// Unique identifier for the target chat or username of the target channel (in the format
+	// @channelusername). Required.
+	ChatID Recipient
+
+	// Unique identifier for the target message thread (topic) of the forum; for forum supergroups
+	// only
+	MessageThreadID int
+
+	// Product name, 1-32 characters. Required.
+	Title string
+
+	// Product description, 1-255 characters. Required.
+	Description string
+
+	// Bot-defined invoice payload, 1-128 bytes. This will not be displayed to the user, use for
+	// your internal processes. Required.
+	Payload string
+
+	// Payment provider token, obtained via @BotFather. Required.
+	ProviderToken string
+
+	// Three-letter ISO 4217 currency code, see more on currencies. Required.
+	Currency string
+
+	// Price breakdown, a JSON-serialized list of components (e.g. product price, tax, discount,
+	// delivery cost, delivery tax, bonus, etc.). Required.
+	Prices []Price
+
+	// The maximum accepted amount for tips in the smallest units of the currency (integer, not
+	// float/double). Defaults to 0
+	MaxTipAmount int
+
+	// A JSON-serialized array of suggested amounts of tips in the smallest units of the currency
+	// (integer, not float/double). At most 4 suggested tip amounts can be specified. The
+	// suggested tip amounts must be positive, passed in a strictly increased order and must not
+	// exceed max_tip_amount.
+	SuggestedTipAmounts []int
+
+	// Unique deep-linking parameter. If left empty, forwarded copies of the sent message will
+	// have a Pay button, allowing multiple users to pay directly from the forwarded message,
+	// using the same invoice. If non-empty, forwarded copies of the sent message will have a URL
+	// button with a deep link to the bot (instead of a Pay button), with the value used as the
+	// start parameter
+	StartParameter string
+
+	// JSON-serialized data about the invoice, which will be shared with the payment provider. A
+	// detailed description of required fields should be provided by the payment provider.
+	ProviderData string
+
+	// URL of the product photo for the invoice. Can be a photo of the goods or a marketing image
+	// for a service. People like it better when they see what they are paying for.
+	PhotoURL string
+
+	// Photo size in bytes
+	PhotoSize int
+
+	// Photo width
+	PhotoWidth int
+
+	// Photo height
+	PhotoHeight int
+
+	// Pass True if you require the user's full name to complete the order
+	NeedName bool
+
+	// Pass True if you require the user's phone number to complete the order
+	NeedPhoneNumber bool
+
+	// Pass True if you require the user's email address to complete the order
+	NeedEmail bool
+
+	// Pass True if you require the user's shipping address to complete the order
+	NeedShippingAddress bool
+
+	// Pass True if the user's phone number should be sent to provider
+	SendPhoneNumberToProvider bool
+
+	// Pass True if the user's email address should be sent to provider
+	SendEmailToProvider bool
+
+	// Pass True if the final price depends on the shipping method
+	IsFlexible bool
+
+	// Sends the message silently. Users will receive a notification with no sound.
+	DisableNotification bool
+
+	// Protects the contents of the sent message from forwarding and saving
+	ProtectContent bool
+
+	// Description of the message to reply to
+	ReplyParameters interface{}
+
+	// A JSON-serialized object for an inline keyboard. If empty, one 'Pay total price' button
+	// will be shown. If not empty, the first button must be a Pay button.
+	ReplyMarkup *ReplyMarkup
+}
+
+func (r *SendInvoiceRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	if r.ChatID != nil {
+		params["chat_id"] = r.ChatID.Recipient()
+	}
+
+	if r.MessageThreadID != 0 {
+		params["message_thread_id"] = strconv.FormatInt(int64(r.MessageThreadID), 10)
+	}
+
+	params["title"] = r.Title
+
+	params["description"] = r.Description
+
+	params["payload"] = r.Payload
+
+	params["provider_token"] = r.ProviderToken
+
+	params["currency"] = r.Currency
+
+	if r.Prices != nil {
+		if err := encodeParam(params, "prices", r.Prices); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if r.MaxTipAmount != 0 {
+		params["max_tip_amount"] = strconv.FormatInt(int64(r.MaxTipAmount), 10)
+	}
+
+	if r.SuggestedTipAmounts != nil {
+		if err := encodeParam(params, "suggested_tip_amounts", r.SuggestedTipAmounts); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if r.StartParameter != "" {
+		params["start_parameter"] = r.StartParameter
+	}
+
+	if r.ProviderData != "" {
+		params["provider_data"] = r.ProviderData
+	}
+
+	if r.PhotoURL != "" {
+		params["photo_url"] = r.PhotoURL
+	}
+
+	if r.PhotoSize != 0 {
+		params["photo_size"] = strconv.FormatInt(int64(r.PhotoSize), 10)
+	}
+
+	if r.PhotoWidth != 0 {
+		params["photo_width"] = strconv.FormatInt(int64(r.PhotoWidth), 10)
+	}
+
+	if r.PhotoHeight != 0 {
+		params["photo_height"] = strconv.FormatInt(int64(r.PhotoHeight), 10)
+	}
+
+	if r.NeedName {
+		params["need_name"] = "true"
+	}
+
+	if r.NeedPhoneNumber {
+		params["need_phone_number"] = "true"
+	}
+
+	if r.NeedEmail {
+		params["need_email"] = "true"
+	}
+
+	if r.NeedShippingAddress {
+		params["need_shipping_address"] = "true"
+	}
+
+	if r.SendPhoneNumberToProvider {
+		params["send_phone_number_to_provider"] = "true"
+	}
+
+	if r.SendEmailToProvider {
+		params["send_email_to_provider"] = "true"
+	}
+
+	if r.IsFlexible {
+		params["is_flexible"] = "true"
+	}
+
+	if r.DisableNotification {
+		params["disable_notification"] = "true"
+	}
+
+	if r.ProtectContent {
+		params["protect_content"] = "true"
+	}
+
+	if r.ReplyParameters != nil {
+		if err := encodeParam(params, "reply_parameters", r.ReplyParameters); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if r.ReplyMarkup != nil {
+		if err := encodeParam(params, "reply_markup", r.ReplyMarkup); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return params, files, nil
+}
+
+// SendInvoice calls sendInvoice, see https://core.telegram.org/bots/api#sendinvoice
+//
+// Use this method to send invoices. On success, the sent Message is returned.
+func (api API) SendInvoice(ctx context.Context, r *SendInvoiceRequest) (*Message, error) {
+	var result *Message
+	err := api.call(ctx, "sendInvoice", r, &result)
+	return result, err
+}
+
+// SendLocationRequest is the request of sendLocation, see API.SendLocation.
+type SendLocationRequest struct {
+	// Unique identifier for the target chat or username of the target channel (in the format
+	// @channelusername). Required.
+	ChatID Recipient
+
+	// Unique identifier for the target message thread (topic) of the forum; for forum supergroups
+	// only
+	MessageThreadID int
+
+	// Latitude of the location. Required.
+	Latitude float64
+
+	// Longitude of the location. Required.
+	Longitude float64
+
+	// The radius of uncertainty for the location, measured in meters; 0-1500
+	HorizontalAccuracy float64
+
+	// Period in seconds for which the location will be updated, should be between 60 and 86400.
+	LivePeriod int
+
+	// For live locations, a direction in which the user is moving, in degrees. Must be between 1
+	// and 360 if specified.
+	Heading int
+
+	// For live locations, a maximum distance for proximity alerts about approaching another chat
+	// member, in meters.
+	ProximityAlertRadius int
+
+	// Sends the message silently. Users will receive a notification with no sound.
+	DisableNotification bool
+
+	// Protects the contents of the sent message from forwarding and saving
+	ProtectContent bool
+
+	// Description of the message to reply to
+	ReplyParameters interface{}
+
+	// Additional interface options. A JSON-serialized object for an inline keyboard, custom reply
+	// keyboard, instructions to remove reply keyboard or to force a reply from the user.
+	ReplyMarkup *ReplyMarkup
+}
+
+func (r *SendLocationRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	if r.ChatID != nil {
+		params["chat_id"] = r.ChatID.Recipient()
+	}
+
+	if r.MessageThreadID != 0 {
+		params["message_thread_id"] = strconv.FormatInt(int64(r.MessageThreadID), 10)
+	}
+
+	params["latitude"] = strconv.FormatFloat(r.Latitude, 'f', -1, 64)
+
+	params["longitude"] = strconv.FormatFloat(r.Longitude, 'f', -1, 64)
+
+	if r.HorizontalAccuracy != 0 {
+		params["horizontal_accuracy"] = strconv.FormatFloat(r.HorizontalAccuracy, 'f', -1, 64)
+	}
+
+	if r.LivePeriod != 0 {
+		params["live_period"] = strconv.FormatInt(int64(r.LivePeriod), 10)
+	}
+
+	if r.Heading != 0 {
+		params["heading"] = strconv.FormatInt(int64(r.Heading), 10)
+	}
+
+	if r.ProximityAlertRadius != 0 {
+		params["proximity_alert_radius"] = strconv.FormatInt(int64(r.ProximityAlertRadius), 10)
+	}
+
+	if r.DisableNotification {
+		params["disable_notification"] = "true"
+	}
+
+	if r.ProtectContent {
+		params["protect_content"] = "true"
+	}
+
+	if r.ReplyParameters != nil {
+		if err := encodeParam(params, "reply_parameters", r.ReplyParameters); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if r.ReplyMarkup != nil {
+		if err := encodeParam(params, "reply_markup", r.ReplyMarkup); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return params, files, nil
+}
+
+// SendLocation calls sendLocation, see https://core.telegram.org/bots/api#sendlocation
+//
+// Use this method to send point on the map. On success, the sent Message is returned.
+func (api API) SendLocation(ctx context.Context, r *SendLocationRequest) (*Message, error) {
+	var result *Message
+	err := api.call(ctx, "sendLocation", r, &result)
+	return result, err
+}
+
+// SendMediaGroupRequest is the request of sendMediaGroup, see API.SendMediaGroup.
+type SendMediaGroupRequest struct {
+	// Unique identifier for the target chat or username of the target channel (in the format
+	// @channelusername). Required.
+	ChatID Recipient
+
+	// Unique identifier for the target message thread (topic) of the forum; for forum supergroups
+	// only
+	MessageThreadID int
+
+	// A JSON-serialized array describing messages to be sent, must include 2-10 items. Required.
+	Media Album
+
+	// Sends messages silently. Users will receive a notification with no sound.
+	DisableNotification bool
+
+	// Protects the contents of the sent messages from forwarding and saving
+	ProtectContent bool
+
+	// Description of the message to reply to
+	ReplyParameters interface{}
+}
+
+func (r *SendMediaGroupRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	if r.ChatID != nil {
+		params["chat_id"] = r.ChatID.Recipient()
+	}
+
+	if r.MessageThreadID != 0 {
+		params["message_thread_id"] = strconv.FormatInt(int64(r.MessageThreadID), 10)
+	}
+
+	if r.Media != nil {
+		if err := encodeMedia(params, files, "media", r.Media); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if r.DisableNotification {
+		params["disable_notification"] = "true"
+	}
+
+	if r.ProtectContent {
+		params["protect_content"] = "true"
+	}
+
+	if r.ReplyParameters != nil {
+		if err := encodeParam(params, "reply_parameters", r.ReplyParameters); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return params, files, nil
+}
+
+// SendMediaGroup calls sendMediaGroup, see https://core.telegram.org/bots/api#sendmediagroup
+//
+// Use this method to send a group of photos, videos, documents or audios as an album.
+// Documents and audio files can be only grouped in an album with messages of the same type.
+// On success, an array of Messages that were sent is returned.
+func (api API) SendMediaGroup(ctx context.Context, r *SendMediaGroupRequest) ([]Message, error) {
+	var result []Message
+	err := api.call(ctx, "sendMediaGroup", r, &result)
+	return result, err
+}
+
+// SendMessageRequest is the request of sendMessage, see API.SendMessage.
+type SendMessageRequest struct {
+	// Unique identifier for the target chat or username of the target channel (in the format
+	// @channelusername). Required.
+	ChatID Recipient
+
+	// Unique identifier for the target message thread (topic) of the forum; for forum supergroups
+	// only
+	MessageThreadID int
+
+	// Text of the message to be sent, 1-4096 characters after entities parsing. Required.
+	Text string
+
+	// Mode for parsing entities in the message text. See formatting options for more details.
+	ParseMode string
+
+	// A JSON-serialized list of special entities that appear in message text, which can be
+	// specified instead of parse_mode
+	Entities Entities
+
+	// Link preview generation options for the message
+	LinkPreviewOptions interface{}
+
+	// Sends the message silently. Users will receive a notification with no sound.
+	DisableNotification bool
+
+	// Protects the contents of the sent message from forwarding and saving
+	ProtectContent bool
+
+	// Description of the message to reply to
+	ReplyParameters interface{}
+
+	// Additional interface options. A JSON-serialized object for an inline keyboard, custom reply
+	// keyboard, instructions to remove reply keyboard or to force a reply from the user.
+	ReplyMarkup *ReplyMarkup
+}
+
+func (r *SendMessageRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	if r.ChatID != nil {
+		params["chat_id"] = r.ChatID.Recipient()
+	}
+
+	if r.MessageThreadID != 0 {
+		params["message_thread_id"] = strconv.FormatInt(int64(r.MessageThreadID), 10)
+	}
+
+	params["text"] = r.Text
+
+	if r.ParseMode != "" {
+		params["parse_mode"] = r.ParseMode
+	}
+
+	if r.Entities != nil {
+		if err := encodeParam(params, "entities", r.Entities); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if r.LinkPreviewOptions != nil {
+		if err := encodeParam(params, "link_preview_options", r.LinkPreviewOptions); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if r.DisableNotification {
+		params["disable_notification"] = "true"
+	}
+
+	if r.ProtectContent {
+		params["protect_content"] = "true"
+	}
+
+	if r.ReplyParameters != nil {
+		if err := encodeParam(params, "reply_parameters", r.ReplyParameters); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if r.ReplyMarkup != nil {
+		if err := encodeParam(params, "reply_markup", r.ReplyMarkup); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return params, files, nil
+}
+
+// SendMessage calls sendMessage, see https://core.telegram.org/bots/api#sendmessage
+//
+// Use this method to send text messages. On success, the sent Message is returned.
+func (api API) SendMessage(ctx context.Context, r *SendMessageRequest) (*Message, error) {
+	var result *Message
+	err := api.call(ctx, "sendMessage", r, &result)
+	return result, err
+}
+
+// SendPhotoRequest is the request of sendPhoto, see API.SendPhoto.
+type SendPhotoRequest struct {
+	// Unique identifier for the target chat or username of the target channel (in the format
+	// @channelusername). Required.
+	ChatID Recipient
+
+	// Unique identifier for the target message thread (topic) of the forum; for forum supergroups
+	// only
+	MessageThreadID int
+
+	// Photo to send. Pass a file_id as String to send a file that exists on the Telegram servers
+	// (recommended), pass an HTTP URL as a String for Telegram to get a file from the Internet,
+	// or upload a new one using multipart/form-data. Required.
+	Photo File
+
+	// Pass True if the photo needs to be covered with a spoiler animation
+	HasSpoiler bool
+
+	// Caption, 0-1024 characters after entities parsing
+	Caption string
+
+	// Mode for parsing entities in the caption. See formatting options for more details.
+	ParseMode string
+
+	// A JSON-serialized list of special entities that appear in the caption, which can be
+	// specified instead of parse_mode
+	CaptionEntities Entities
+
+	// Sends the message silently. Users will receive a notification with no sound.
+	DisableNotification bool
+
+	// Protects the contents of the sent message from forwarding and saving
+	ProtectContent bool
+
+	// Description of the message to reply to
+	ReplyParameters interface{}
+
+	// Additional interface options. A JSON-serialized object for an inline keyboard, custom reply
+	// keyboard, instructions to remove reply keyboard or to force a reply from the user.
+	ReplyMarkup *ReplyMarkup
+}
+
+func (r *SendPhotoRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	if r.ChatID != nil {
+		params["chat_id"] = r.ChatID.Recipient()
+	}
+
+	if r.MessageThreadID != 0 {
+		params["message_thread_id"] = strconv.FormatInt(int64(r.MessageThreadID), 10)
+	}
+
+	if !r.Photo.empty() {
+		files["photo"] = r.Photo
+	}
+
+	if r.HasSpoiler {
+		params["has_spoiler"] = "true"
+	}
+
+	if r.Caption != "" {
+		params["caption"] = r.Caption
+	}
+
+	if r.ParseMode != "" {
+		params["parse_mode"] = r.ParseMode
+	}
+
+	if r.CaptionEntities != nil {
+		if err := encodeParam(params, "caption_entities", r.CaptionEntities); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if r.DisableNotification {
+		params["disable_notification"] = "true"
+	}
+
+	if r.ProtectContent {
+		params["protect_content"] = "true"
+	}
+
+	if r.ReplyParameters != nil {
+		if err := encodeParam(params, "reply_parameters", r.ReplyParameters); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if r.ReplyMarkup != nil {
+		if err := encodeParam(params, "reply_markup", r.ReplyMarkup); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return params, files, nil
+}
+
+// SendPhoto calls sendPhoto, see https://core.telegram.org/bots/api#sendphoto
+//
+// Use this method to send photos. On success, the sent Message is returned.
+func (api API) SendPhoto(ctx context.Context, r *SendPhotoRequest) (*Message, error) {
+	var result *Message
+	err := api.call(ctx, "sendPhoto", r, &result)
+	return result, err
+}
+
+// SendPollRequest is the request of sendPoll, see API.SendPoll.
+type SendPollRequest struct {
+	// Unique identifier for the target chat or username of the target channel (in the format
+	// @channelusername). Required.
+	ChatID Recipient
+
+	// Unique identifier for the target message thread (topic) of the forum; for forum supergroups
+	// only
+	MessageThreadID int
+
+	// Poll question, 1-300 characters. Required.
+	Question string
+
+	// A JSON-serialized list of answer options, 2-10 strings 1-100 characters each. Required.
+	Options []string
+
+	// True, if the poll needs to be anonymous, defaults to True
+	IsAnonymous bool
+
+	// Poll type, “quiz” or “regular”, defaults to “regular”
+	Type string
+
+	// True, if the poll allows multiple answers, ignored for polls in quiz mode, defaults to
+	// False
+	AllowsMultipleAnswers bool
+
+	// 0-based identifier of the correct answer option, required for polls in quiz mode
+	CorrectOptionID int
+
+	// Text that is shown when a user chooses an incorrect answer or taps on the lamp icon in a
+	// quiz-style poll, 0-200 characters with at most 2 line feeds after entities parsing
+	Explanation string
+
+	// Mode for parsing entities in the explanation. See formatting options for more details.
+	ExplanationParseMode string
+
+	// A JSON-serialized list of special entities that appear in the poll explanation, which can
+	// be specified instead of parse_mode
+	ExplanationEntities Entities
+
+	// Amount of time in seconds the poll will be active after creation, 5-600. Can't be used
+	// together with close_date.
+	OpenPeriod int
+
+	// Point in time (Unix timestamp) when the poll will be automatically closed. Must be at least
+	// 5 and no more than 600 seconds in the future. Can't be used together with open_period.
+	CloseDate int
+
+	// Pass True if the poll needs to be immediately closed. This can be useful for poll preview.
+	IsClosed bool
+
+	// Sends the message silently. Users will receive a notification with no sound.
+	DisableNotification bool
+
+	// Protects the contents of the sent message from forwarding and saving
+	ProtectContent bool
+
+	// Description of the message to reply to
+	ReplyParameters interface{}
+
+	// Additional interface options. A JSON-serialized object for an inline keyboard, custom reply
+	// keyboard, instructions to remove reply keyboard or to force a reply from the user.
+	ReplyMarkup *ReplyMarkup
+}
+
+func (r *SendPollRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	if r.ChatID != nil {
+		params["chat_id"] = r.ChatID.Recipient()
+	}
+
+	if r.MessageThreadID != 0 {
+		params["message_thread_id"] = strconv.FormatInt(int64(r.MessageThreadID), 10)
+	}
+
+	params["question"] = r.Question
+
+	if r.Options != nil {
+		if err := encodeParam(params, "options", r.Options); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if r.IsAnonymous {
+		params["is_anonymous"] = "true"
+	}
+
+	if r.Type != "" {
+		params["type"] = r.Type
+	}
+
+	if r.AllowsMultipleAnswers {
+		params["allows_multiple_answers"] = "true"
+	}
+
+	if r.CorrectOptionID != 0 {
+		params["correct_option_id"] = strconv.FormatInt(int64(r.CorrectOptionID), 10)
+	}
+
+	if r.Explanation != "" {
+		params["explanation"] = r.Explanation
+	}
+
+	if r.ExplanationParseMode != "" {
+		params["explanation_parse_mode"] = r.ExplanationParseMode
+	}
+
+	if r.ExplanationEntities != nil {
+		if err := encodeParam(params, "explanation_entities", r.ExplanationEntities); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if r.OpenPeriod != 0 {
+		params["open_period"] = strconv.FormatInt(int64(r.OpenPeriod), 10)
+	}
+
+	if r.CloseDate != 0 {
+		params["close_date"] = strconv.FormatInt(int64(r.CloseDate), 10)
+	}
+
+	if r.IsClosed {
+		params["is_closed"] = "true"
+	}
+
+	if r.DisableNotification {
+		params["disable_notification"] = "true"
+	}
+
+	if r.ProtectContent {
+		params["protect_content"] = "true"
+	}
+
+	if r.ReplyParameters != nil {
+		if err := encodeParam(params, "reply_parameters", r.ReplyParameters); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if r.ReplyMarkup != nil {
+		if err := encodeParam(params, "reply_markup", r.ReplyMarkup); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return params, files, nil
+}
+
+// SendPoll calls sendPoll, see https://core.telegram.org/bots/api#sendpoll
+//
+// Use this method to send a native poll. On success, the sent Message is returned.
+func (api API) SendPoll(ctx context.Context, r *SendPollRequest) (*Message, error) {
+	var result *Message
+	err := api.call(ctx, "sendPoll", r, &result)
+	return result, err
+}
+
+// SendStickerRequest is the request of sendSticker, see API.SendSticker.
+type SendStickerRequest struct {
+	// Unique identifier for the target chat or username of the target channel (in the format
+	// @channelusername). Required.
+	ChatID Recipient
+
+	// Unique identifier for the target message thread (topic) of the forum; for forum supergroups
+	// only
+	MessageThreadID int
+
+	// Sticker to send. Pass a file_id as String to send a file that exists on the Telegram
+	// servers (recommended), pass an HTTP URL as a String for Telegram to get a .WEBP sticker
+	// from the Internet, or upload a new .WEBP or .TGS sticker using multipart/form-data. Video
+	// stickers can only be sent by a file_id. Animated stickers can't be sent via an HTTP URL.
+	// Required.
+	Sticker File
+
+	// Emoji associated with the sticker; only for just uploaded stickers
+	Emoji string
+
+	// Sends the message silently. Users will receive a notification with no sound.
+	DisableNotification bool
+
+	// Protects the contents of the sent message from forwarding and saving
+	ProtectContent bool
+
+	// Description of the message to reply to
+	ReplyParameters interface{}
+
+	// Additional interface options. A JSON-serialized object for an inline keyboard, custom reply
+	// keyboard, instructions to remove reply keyboard or to force a reply from the user.
+	ReplyMarkup *ReplyMarkup
+}
+
+func (r *SendStickerRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	if r.ChatID != nil {
+		params["chat_id"] = r.ChatID.Recipient()
+	}
+
+	if r.MessageThreadID != 0 {
+		params["message_thread_id"] = strconv.FormatInt(int64(r.MessageThreadID), 10)
+	}
+
+	if !r.Sticker.empty() {
+		files["sticker"] = r.Sticker
+	}
+
+	if r.Emoji != "" {
+		params["emoji"] = r.Emoji
+	}
+
+	if r.DisableNotification {
+		params["disable_notification"] = "true"
+	}
+
+	if r.ProtectContent {
+		params["protect_content"] = "true"
+	}
+
+	if r.ReplyParameters != nil {
+		if err := encodeParam(params, "reply_parameters", r.ReplyParameters); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if r.ReplyMarkup != nil {
+		if err := encodeParam(params, "reply_markup", r.ReplyMarkup); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return params, files, nil
+}
+
+// SendSticker calls sendSticker, see https://core.telegram.org/bots/api#sendsticker
+//
+// Use this method to send static .WEBP, animated .TGS, or video .WEBM stickers. On success,
+// the sent Message is returned.
+func (api API) SendSticker(ctx context.Context, r *SendStickerRequest) (*Message, error) {
+	var result *Message
+	err := api.call(ctx, "sendSticker", r, &result)
+	return result, err
+}
+
+// SendVenueRequest is the request of sendVenue, see API.SendVenue.
+type SendVenueRequest struct {
+	// Unique identifier for the target chat or username of the target channel (in the format
+	// @channelusername). Required.
+	ChatID Recipient
+
+	// Unique identifier for the target message thread (topic) of the forum; for forum supergroups
+	// only
+	MessageThreadID int
+
+	// Latitude of the venue. Required.
+	Latitude float64
+
+	// Longitude of the venue. Required.
+	Longitude float64
+
+	// Name of the venue. Required.
+	Title string
+
+	// Address of the venue. Required.
+	Address string
+
+	// Foursquare identifier of the venue
+	FoursquareID string
+
+	// Foursquare type of the venue, if known.
+	FoursquareType string
+
+	// Google Places identifier of the venue
+	GooglePlaceID string
+
+	// Google Places type of the venue.
+	GooglePlaceType string
+
+	// Sends the message silently. Users will receive a notification with no sound.
+	DisableNotification bool
+
+	// Protects the contents of the sent message from forwarding and saving
+	ProtectContent bool
+
+	// Description of the message to reply to
+	ReplyParameters interface{}
+
+	// Additional interface options. A JSON-serialized object for an inline keyboard, custom reply
+	// keyboard, instructions to remove reply keyboard or to force a reply from the user.
+	ReplyMarkup *ReplyMarkup
+}
+
+func (r *SendVenueRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	if r.ChatID != nil {
+		params["chat_id"] = r.ChatID.Recipient()
+	}
+
+	if r.MessageThreadID != 0 {
+		params["message_thread_id"] = strconv.FormatInt(int64(r.MessageThreadID), 10)
+	}
+
+	params["latitude"] = strconv.FormatFloat(r.Latitude, 'f', -1, 64)
+
+	params["longitude"] = strconv.FormatFloat(r.Longitude, 'f', -1, 64)
+
+	params["title"] = r.Title
+
+	params["address"] = r.Address
+
+	if r.FoursquareID != "" {
+		params["foursquare_id"] = r.FoursquareID
+	}
+
+	if r.FoursquareType != "" {
+		params["foursquare_type"] = r.FoursquareType
+	}
+
+	if r.GooglePlaceID != "" {
+		params["google_place_id"] = r.GooglePlaceID
+	}
+
+	if r.GooglePlaceType != "" {
+		params["google_place_type"] = r.GooglePlaceType
+	}
+
+	if r.DisableNotification {
+		params["disable_notification"] = "true"
+	}
+
+	if r.ProtectContent {
+		params["protect_content"] = "true"
+	}
+
+	if r.ReplyParameters != nil {
+		if err := encodeParam(params, "reply_parameters", r.ReplyParameters); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if r.ReplyMarkup != nil {
+		if err := encodeParam(params, "reply_markup", r.ReplyMarkup); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return params, files, nil
+}
+
+// SendVenue calls sendVenue, see https://core.telegram.org/bots/api#sendvenue
+//
+// Use this method to send information about a venue. On success, the sent Message is
+// returned.
+func (api API) SendVenue(ctx context.Context, r *SendVenueRequest) (*Message, error) {
+	var result *Message
+	err := api.call(ctx, "sendVenue", r, &result)
+	return result, err
+}
+
+// SendVideoRequest is the request of sendVideo, see API.SendVideo.
+type SendVideoRequest struct {
+	// Unique identifier for the target chat or username of the target channel (in the format
+	// @channelusername). Required.
+	ChatID Recipient
+
+	// Unique identifier for the target message thread (topic) of the forum; for forum supergroups
+	// only
+	MessageThreadID int
+
+	// Video to send. Pass a file_id as String to send a file that exists on the Telegram servers
+	// (recommended), pass an HTTP URL as a String for Telegram to get a file from the Internet,
+	// or upload a new one using multipart/form-data. Required.
+	Video File
+
+	// Duration of sent video in seconds
+	Duration int
+
+	// Video width
+	Width int
+
+	// Video height
+	Height int
+
+	// Thumbnail of the file sent; can be ignored if thumbnail generation for the file is
+	// supported server-side.
+	Thumbnail File
+
+	// Pass True if the video needs to be covered with a spoiler animation
+	HasSpoiler bool
+
+	// Pass True if the uploaded video is suitable for streaming
+	SupportsStreaming bool
+
+	// Caption, 0-1024 characters after entities parsing
+	Caption string
+
+	// Mode for parsing entities in the caption. See formatting options for more details.
+	ParseMode string
+
+	// A JSON-serialized list of special entities that appear in the caption, which can be
+	// specified instead of parse_mode
+	CaptionEntities Entities
+
+	// Sends the message silently. Users will receive a notification with no sound.
+	DisableNotification bool
+
+	// Protects the contents of the sent message from forwarding and saving
+	ProtectContent bool
+
+	// Description of the message to reply to
+	ReplyParameters interface{}
+
+	// Additional interface options. A JSON-serialized object for an inline keyboard, custom reply
+	// keyboard, instructions to remove reply keyboard or to force a reply from the user.
+	ReplyMarkup *ReplyMarkup
+}
+
+func (r *SendVideoRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	if r.ChatID != nil {
+		params["chat_id"] = r.ChatID.Recipient()
+	}
+
+	if r.MessageThreadID != 0 {
+		params["message_thread_id"] = strconv.FormatInt(int64(r.MessageThreadID), 10)
+	}
+
+	if !r.Video.empty() {
+		files["video"] = r.Video
+	}
+
+	if r.Duration != 0 {
+		params["duration"] = strconv.FormatInt(int64(r.Duration), 10)
+	}
+
+	if r.Width != 0 {
+		params["width"] = strconv.FormatInt(int64(r.Width), 10)
+	}
+
+	if r.Height != 0 {
+		params["height"] = strconv.FormatInt(int64(r.Height), 10)
+	}
+
+	if !r.Thumbnail.empty() {
+		files["thumbnail"] = r.Thumbnail
+	}
+
+	if r.HasSpoiler {
+		params["has_spoiler"] = "true"
+	}
+
+	if r.SupportsStreaming {
+		params["supports_streaming"] = "true"
+	}
+
+	if r.Caption != "" {
+		params["caption"] = r.Caption
+	}
+
+	if r.ParseMode != "" {
+		params["parse_mode"] = r.ParseMode
+	}
+
+	if r.CaptionEntities != nil {
+		if err := encodeParam(params, "caption_entities", r.CaptionEntities); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if r.DisableNotification {
+		params["disable_notification"] = "true"
+	}
+
+	if r.ProtectContent {
+		params["protect_content"] = "true"
+	}
+
+	if r.ReplyParameters != nil {
+		if err := encodeParam(params, "reply_parameters", r.ReplyParameters); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if r.ReplyMarkup != nil {
+		if err := encodeParam(params, "reply_markup", r.ReplyMarkup); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return params, files, nil
+}
+
+// SendVideo calls sendVideo, see https://core.telegram.org/bots/api#sendvideo
+//
+// Use this method to send video files, Telegram clients support MPEG4 videos. On success, the
+// sent Message is returned.
+func (api API) SendVideo(ctx context.Context, r *SendVideoRequest) (*Message, error) {
+	var result *Message
+	err := api.call(ctx, "sendVideo", r, &result)
+	return result, err
+}
+
+// SendVideoNoteRequest is the request of sendVideoNote, see API.SendVideoNote.
+type SendVideoNoteRequest struct {
+	// Unique identifier for the target chat or username of the target channel (in the format
+	// @channelusername). Required.
+	ChatID Recipient
+
+	// Unique identifier for the target message thread (topic) of the forum; for forum supergroups
+	// only
+	MessageThreadID int
+
+	// Video note to send. Pass a file_id as String to send a video note that exists on the
+	// Telegram servers (recommended) or upload a new video using multipart/form-data. Sending
+	// video notes by a URL is currently unsupported. Required.
+	VideoNote File
+
+	// Duration of sent video in seconds
+	Duration int
+
+	// Video width and height, i.e. diameter of the video message
+	Length int
+
+	// Thumbnail of the file sent; can be ignored if thumbnail generation for the file is
+	// supported server-side. The thumbnail should be in JPEG format and less than 200 kB in size.
+	// A thumbnail's width and height should not exceed 320.
+	Thumbnail File
+
+	// Sends the message silently. Users will receive a notification with no sound.
+	DisableNotification bool
+
+	// Protects the contents of the sent message from forwarding and saving
+	ProtectContent bool
+
+	// Description of the message to reply to
+	ReplyParameters interface{}
+
+	// Additional interface options. A JSON-serialized object for an inline keyboard, custom reply
+	// keyboard, instructions to remove reply keyboard or to force a reply from the user.
+	ReplyMarkup *ReplyMarkup
+}
+
+func (r *SendVideoNoteRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	if r.ChatID != nil {
+		params["chat_id"] = r.ChatID.Recipient()
+	}
+
+	if r.MessageThreadID != 0 {
+		params["message_thread_id"] = strconv.FormatInt(int64(r.MessageThreadID), 10)
+	}
+
+	if !r.VideoNote.empty() {
+		files["video_note"] = r.VideoNote
+	}
+
+	if r.Duration != 0 {
+		params["duration"] = strconv.FormatInt(int64(r.Duration), 10)
+	}
+
+	if r.Length != 0 {
+		params["length"] = strconv.FormatInt(int64(r.Length), 10)
+	}
+
+	if !r.Thumbnail.empty() {
+		files["thumbnail"] = r.Thumbnail
+	}
+
+	if r.DisableNotification {
+		params["disable_notification"] = "true"
+	}
+
+	if r.ProtectContent {
+		params["protect_content"] = "true"
+	}
+
+	if r.ReplyParameters != nil {
+		if err := encodeParam(params, "reply_parameters", r.ReplyParameters); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if r.ReplyMarkup != nil {
+		if err := encodeParam(params, "reply_markup", r.ReplyMarkup); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return params, files, nil
+}
+
+// SendVideoNote calls sendVideoNote, see https://core.telegram.org/bots/api#sendvideonote
+//
+// As of v.4.0, Telegram clients support rounded square MPEG4 videos of up to 1 minute long.
+// Use this method to send video messages. On success, the sent Message is returned.
+func (api API) SendVideoNote(ctx context.Context, r *SendVideoNoteRequest) (*Message, error) {
+	var result *Message
+	err := api.call(ctx, "sendVideoNote", r, &result)
+	return result, err
+}
+
+// SendVoiceRequest is the request of sendVoice, see API.SendVoice.
+type SendVoiceRequest struct {
+	// Unique identifier for the target chat or username of the target channel (in the format
+	// @channelusername). Required.
+	ChatID Recipient
+
+	// Unique identifier for the target message thread (topic) of the forum; for forum supergroups
+	// only
+	MessageThreadID int
+
+	// Audio file to send. Pass a file_id as String to send a file that exists on the Telegram
+	// servers (recommended), pass an HTTP URL as a String for Telegram to get a file from the
+	// Internet, or upload a new one using multipart/form-data. Required.
+	Voice File
+
+	// Duration of the voice message in seconds
+	Duration int
+
+	// Caption, 0-1024 characters after entities parsing
+	Caption string
+
+	// Mode for parsing entities in the caption. See formatting options for more details.
+	ParseMode string
+
+	// A JSON-serialized list of special entities that appear in the caption, which can be
+	// specified instead of parse_mode
+	CaptionEntities Entities
+
+	// Sends the message silently. Users will receive a notification with no sound.
+	DisableNotification bool
+
+	// Protects the contents of the sent message from forwarding and saving
+	ProtectContent bool
+
+	// Description of the message to reply to
+	ReplyParameters interface{}
+
+	// Additional interface options. A JSON-serialized object for an inline keyboard, custom reply
+	// keyboard, instructions to remove reply keyboard or to force a reply from the user.
+	ReplyMarkup *ReplyMarkup
+}
+
+func (r *SendVoiceRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	if r.ChatID != nil {
+		params["chat_id"] = r.ChatID.Recipient()
+	}
+
+	if r.MessageThreadID != 0 {
+		params["message_thread_id"] = strconv.FormatInt(int64(r.MessageThreadID), 10)
+	}
+
+	if !r.Voice.empty() {
+		files["voice"] = r.Voice
+	}
+
+	if r.Duration != 0 {
+		params["duration"] = strconv.FormatInt(int64(r.Duration), 10)
+	}
+
+	if r.Caption != "" {
+		params["caption"] = r.Caption
+	}
+
+	if r.ParseMode != "" {
+		params["parse_mode"] = r.ParseMode
+	}
+
+	if r.CaptionEntities != nil {
+		if err := encodeParam(params, "caption_entities", r.CaptionEntities); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if r.DisableNotification {
+		params["disable_notification"] = "true"
+	}
+
+	if r.ProtectContent {
+		params["protect_content"] = "true"
+	}
+
+	if r.ReplyParameters != nil {
+		if err := encodeParam(params, "reply_parameters", r.ReplyParameters); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if r.ReplyMarkup != nil {
+		if err := encodeParam(params, "reply_markup", r.ReplyMarkup); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return params, files, nil
+}
+
+// SendVoice calls sendVoice, see https://core.telegram.org/bots/api#sendvoice
+//
+// Use this method to send audio files, if you want Telegram clients to display the file as a
+// playable voice message. On success, the sent Message is returned.
+func (api API) SendVoice(ctx context.Context, r *SendVoiceRequest) (*Message, error) {
+	var result *Message
+	err := api.call(ctx, "sendVoice", r, &result)
+	return result, err
+}
+
+// SetChatAdministratorCustomTitleRequest is the request of setChatAdministratorCustomTitle, see API.SetChatAdministratorCustomTitle.
+type SetChatAdministratorCustomTitleRequest struct {
+	// Unique identifier for the target chat or username of the target supergroup (in the format
+	// @supergroupusername). Required.
+	ChatID Recipient
+
+	// Unique identifier of the target user. Required.
+	UserID int64
+
+	// New custom title for the administrator; 0-16 characters, emoji are not allowed. Required.
+	CustomTitle string
+}
+
+func (r *SetChatAdministratorCustomTitleRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	if r.ChatID != nil {
+		params["chat_id"] = r.ChatID.Recipient()
+	}
+
+	params["user_id"] = strconv.FormatInt(int64(r.UserID), 10)
+
+	params["custom_title"] = r.CustomTitle
+
+	return params, files, nil
+}
+
+// SetChatAdministratorCustomTitle calls setChatAdministratorCustomTitle, see https://core.telegram.org/bots/api#setchatadministratorcustomtitle
+//
+// Use this method to set a custom title for an administrator in a supergroup promoted by the
+// bot. Returns True on success.
+func (api API) SetChatAdministratorCustomTitle(ctx context.Context, r *SetChatAdministratorCustomTitleRequest) error {
+	return api.call(ctx, "setChatAdministratorCustomTitle", r, nil)
+}
+
+// SetChatDescriptionRequest is the request of setChatDescription, see API.SetChatDescription.
+type SetChatDescriptionRequest struct {
+	// Unique identifier for the target chat or username of the target channel (in the format
+	// @channelusername). Required.
+	ChatID Recipient
+
+	// New chat description, 0-255 characters
+	Description string
+}
+
+func (r *SetChatDescriptionRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	if r.ChatID != nil {
+		params["chat_id"] = r.ChatID.Recipient()
+	}
+
+	if r.Description != "" {
+		params["description"] = r.Description
+	}
+
+	return params, files, nil
+}
+
+// SetChatDescription calls setChatDescription, see https://core.telegram.org/bots/api#setchatdescription
+//
+// Use this method to change the description of a group, a supergroup or a channel. The bot
+// must be an administrator in the chat for this to work and must have the appropriate
+// administrator rights. Returns True on success.
+func (api API) SetChatDescription(ctx context.Context, r *SetChatDescriptionRequest) error {
+	return api.call(ctx, "setChatDescription", r, nil)
+}
+
+// SetChatMenuButtonRequest is the request of setChatMenuButton, see API.SetChatMenuButton.
+type SetChatMenuButtonRequest struct {
+	// Unique identifier for the target private chat. If not specified, default bot's menu button
+	// will be changed
+	ChatID int64
+
+	// A JSON-serialized object for the bot's new menu button. Defaults to MenuButtonDefault
+	MenuButton *MenuButton
+}
+
+func (r *SetChatMenuButtonRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	if r.ChatID != 0 {
+		params["chat_id"] = strconv.FormatInt(int64(r.ChatID), 10)
+	}
+
+	if r.MenuButton != nil {
+		if err := encodeParam(params, "menu_button", r.MenuButton); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return params, files, nil
+}
+
+// SetChatMenuButton calls setChatMenuButton, see https://core.telegram.org/bots/api#setchatmenubutton
+//
+// Use this method to change the bot's menu button in a private chat, or the default menu
+// button. Returns True on success.
+func (api API) SetChatMenuButton(ctx context.Context, r *SetChatMenuButtonRequest) error {
+	return api.call(ctx, "setChatMenuButton", r, nil)
+}
+
+// SetChatPermissionsRequest is the request of setChatPermissions, see API.SetChatPermissions.
+type SetChatPermissionsRequest struct {
+	// Unique identifier for the target chat or username of the target supergroup (in the format
+	// @supergroupusername). Required.
+	ChatID Recipient
+
+	// A JSON-serialized object for new default chat permissions. Required.
+	Permissions *Rights
+
+	// Pass True if chat permissions are set independently. Otherwise, the can_send_other_messages
+	// and can_add_web_page_previews permissions will imply the can_send_messages,
+	// can_send_audios, can_send_documents, can_send_photos, can_send_videos,
+	// can_send_video_notes, and can_send_voice_notes permissions; the can_send_polls permission
+	// will imply the can_send_messages permission.
+	UseIndependentChatPermissions bool
+}
+
+func (r *SetChatPermissionsRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	if r.ChatID != nil {
+		params["chat_id"] = r.ChatID.Recipient()
+	}
+
+	if r.Permissions != nil {
+		if err := encodeParam(params, "permissions", r.Permissions); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if r.UseIndependentChatPermissions {
+		params["use_independent_chat_permissions"] = "true"
+	}
+
+	return params, files, nil
+}
+
+// SetChatPermissions calls setChatPermissions, see https://core.telegram.org/bots/api#setchatpermissions
+//
+// Use this method to set default chat permissions for all members. The bot must be an
+// administrator in the group or a supergroup for this to work and must have the
+// can_restrict_members administrator rights. Returns True on success.
+func (api API) SetChatPermissions(ctx context.Context, r *SetChatPermissionsRequest) error {
+	return api.call(ctx, "setChatPermissions", r, nil)
+}
+
+// SetChatPhotoRequest is the request of setChatPhoto, see API.SetChatPhoto.
+type SetChatPhotoRequest struct {
+	// Unique identifier for the target chat or username of the target channel (in the format
+	// @channelusername). Required.
+	ChatID Recipient
+
+	// New chat photo, uploaded using multipart/form-data. Required.
+	Photo File
+}
+
+func (r *SetChatPhotoRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	if r.ChatID != nil {
+		params["chat_id"] = r.ChatID.Recipient()
+	}
+
+	if !r.Photo.empty() {
+		files["photo"] = r.Photo
+	}
+
+	return params, files, nil
+}
+
+// SetChatPhoto calls setChatPhoto, see https://core.telegram.org/bots/api#setchatphoto
+//
+// Use this method to set a new profile photo for the chat. Photos can't be changed for
+// private chats. The bot must be an administrator in the chat for this to work and must have
+// the appropriate administrator rights. Returns True on success.
+func (api API) SetChatPhoto(ctx context.Context, r *SetChatPhotoRequest) error {
+	return api.call(ctx, "setChatPhoto", r, nil)
+}
+
+// SetChatStickerSetRequest is the request of setChatStickerSet, see API.SetChatStickerSet.
+type SetChatStickerSetRequest struct {
+	// Unique identifier for the target chat or username of the target supergroup (in the format
+	// @supergroupusername). Required.
+	ChatID Recipient
+
+	// Name of the sticker set to be set as the group sticker set. Required.
+	StickerSetName string
+}
+
+func (r *SetChatStickerSetRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	if r.ChatID != nil {
+		params["chat_id"] = r.ChatID.Recipient()
+	}
+
+	params["sticker_set_name"] = r.StickerSetName
+
+	return params, files, nil
+}
+
+// SetChatStickerSet calls setChatStickerSet, see https://core.telegram.org/bots/api#setchatstickerset
+//
+// Use this method to set a new group sticker set for a supergroup. The bot must be an
+// administrator in the chat for this to work and must have the appropriate administrator
+// rights. Use the field can_set_sticker_set optionally returned in getChat requests to check
+// if the bot can use this method. Returns True on success.
+func (api API) SetChatStickerSet(ctx context.Context, r *SetChatStickerSetRequest) error {
+	return api.call(ctx, "setChatStickerSet", r, nil)
+}
+
+// SetChatTitleRequest is the request of setChatTitle, see API.SetChatTitle.
+type SetChatTitleRequest struct {
+	// Unique identifier for the target chat or username of the target channel (in the format
+	// @channelusername). Required.
+	ChatID Recipient
+
+	// New chat title, 1-128 characters. Required.
+	Title string
+}
+
+func (r *SetChatTitleRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	if r.ChatID != nil {
+		params["chat_id"] = r.ChatID.Recipient()
+	}
+
+	params["title"] = r.Title
+
+	return params, files, nil
+}
+
+// SetChatTitle calls setChatTitle, see https://core.telegram.org/bots/api#setchattitle
+//
+// Use this method to change the title of a chat. Titles can't be changed for private chats.
+// The bot must be an administrator in the chat for this to work and must have the appropriate
+// administrator rights. Returns True on success.
+func (api API) SetChatTitle(ctx context.Context, r *SetChatTitleRequest) error {
+	return api.call(ctx, "setChatTitle", r, nil)
+}
+
+// SetCustomEmojiStickerSetThumbnailRequest is the request of setCustomEmojiStickerSetThumbnail, see API.SetCustomEmojiStickerSetThumbnail.
+type SetCustomEmojiStickerSetThumbnailRequest struct {
+	// Sticker set name. Required.
+	Name string
+
+	// Custom emoji identifier of a sticker from the sticker set; pass an empty string to drop the
+	// thumbnail and use the first sticker as the thumbnail.
+	CustomEmojiID string
+}
+
+func (r *SetCustomEmojiStickerSetThumbnailRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	params["name"] = r.Name
+
+	if r.CustomEmojiID != "" {
+		params["custom_emoji_id"] = r.CustomEmojiID
+	}
+
+	return params, files, nil
+}
+
+// SetCustomEmojiStickerSetThumbnail calls setCustomEmojiStickerSetThumbnail, see https://core.telegram.org/bots/api#setcustomemojistickersetthumbnail
+//
+// Use this method to set the thumbnail of a custom emoji sticker set. Returns True on
+// success.
+func (api API) SetCustomEmojiStickerSetThumbnail(ctx context.Context, r *SetCustomEmojiStickerSetThumbnailRequest) error {
+	return api.call(ctx, "setCustomEmojiStickerSetThumbnail", r, nil)
+}
+
+// SetGameScoreRequest is the request of setGameScore, see API.SetGameScore.
+type SetGameScoreRequest struct {
+	// User identifier. Required.
+	UserID int64
+
+	// New score, must be non-negative. Required.
+	Score int
+
+	// Pass True if the high score is allowed to decrease. This can be useful when fixing mistakes
+	// or banning cheaters
+	Force bool
+
+	// Pass True if the game message should not be automatically edited to include the current
+	// scoreboard
+	DisableEditMessage bool
+
+	// Required if inline_message_id is not specified. Unique identifier for the target chat
+	ChatID int64
+
+	// Required if inline_message_id is not specified. Identifier of the sent message
+	MessageID int
+
+	// Required if chat_id and message_id are not specified. Identifier of the inline message
+	InlineMessageID string
+}
+
+func (r *SetGameScoreRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	params["user_id"] = strconv.FormatInt(int64(r.UserID), 10)
+
+	params["score"] = strconv.FormatInt(int64(r.Score), 10)
+
+	if r.Force {
+		params["force"] = "true"
+	}
+
+	if r.DisableEditMessage {
+		params["disable_edit_message"] = "true"
+	}
+
+	if r.ChatID != 0 {
+		params["chat_id"] = strconv.FormatInt(int64(r.ChatID), 10)
+	}
+
+	if r.MessageID != 0 {
+		params["message_id"] = strconv.FormatInt(int64(r.MessageID), 10)
+	}
+
+	if r.InlineMessageID != "" {
+		params["inline_message_id"] = r.InlineMessageID
+	}
+
+	return params, files, nil
+}
+
+// SetGameScore calls setGameScore, see https://core.telegram.org/bots/api#setgamescore
+//
+// Use this method to set the score of the specified user in a game message. On success, if
+// the message is not an inline message, the Message is returned, otherwise True is returned.
+// Returns an error, if the new score is not greater than the user's current score in the chat
+// and force is False.
+func (api API) SetGameScore(ctx context.Context, r *SetGameScoreRequest) (*Message, error) {
+	var result *Message
+	err := api.call(ctx, "setGameScore", r, &result)
+	return result, err
+}
+
+// SetMessageReactionRequest is the request of setMessageReaction, see API.SetMessageReaction.
+type SetMessageReactionRequest struct {
+	// Unique identifier for the target chat or username of the target channel (in the format
+	// @channelusername). Required.
+	ChatID Recipient
+
+	// Identifier of the target message. If the message belongs to a media group, the reaction is
+	// set to the first non-deleted message in the group instead. Required.
+	MessageID int
+
+	// New list of reaction types to set on the message. Currently, as non-premium users, bots can
+	// set up to one reaction per message. A custom emoji reaction can be used if it is either
+	// already present on the message or explicitly allowed by chat administrators.
+	Reaction interface{}
+
+	// Pass True to set the reaction with a big animation
+	IsBig bool
+}
+
+func (r *SetMessageReactionRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	if r.ChatID != nil {
+		params["chat_id"] = r.ChatID.Recipient()
+	}
+
+	params["message_id"] = strconv.FormatInt(int64(r.MessageID), 10)
+
+	if r.Reaction != nil {
+		if err := encodeParam(params, "reaction", r.Reaction); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if r.IsBig {
+		params["is_big"] = "true"
+	}
+
+	return params, files, nil
+}
+
+// SetMessageReaction calls setMessageReaction, see https://core.telegram.org/bots/api#setmessagereaction
+//
+// Use this method to change the chosen reactions on a message. Service messages can't be
+// reacted to. Automatically forwarded messages from a channel to its discussion group have
+// the same available reactions as messages in the channel. Returns True on success.
+func (api API) SetMessageReaction(ctx context.Context, r *SetMessageReactionRequest) error {
+	return api.call(ctx, "setMessageReaction", r, nil)
+}
+
+// SetMyCommandsRequest is the request of setMyCommands, see API.SetMyCommands.
+type SetMyCommandsRequest struct {
+	// A JSON-serialized list of bot commands to be set as the list of the bot's commands. At most
+	// 100 commands can be specified. Required.
+	Commands []Command
+
+	// A JSON-serialized object, describing scope of users for which the commands are relevant.
+	// Defaults to BotCommandScopeDefault.
+	Scope *CommandScope
+
+	// A two-letter ISO 639-1 language code.
+	LanguageCode string
+}
+
+func (r *SetMyCommandsRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	if r.Commands != nil {
+		if err := encodeParam(params, "commands", r.Commands); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if r.Scope != nil {
+		if err := encodeParam(params, "scope", r.Scope); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if r.LanguageCode != "" {
+		params["language_code"] = r.LanguageCode
+	}
+
+	return params, files, nil
+}
+
+// SetMyCommands calls setMyCommands, see https://core.telegram.org/bots/api#setmycommands
+//
+// Use this method to change the list of the bot's commands. Returns True on success.
+func (api API) SetMyCommands(ctx context.Context, r *SetMyCommandsRequest) error {
+	return api.call(ctx, "setMyCommands", r, nil)
+}
+
+// SetMyDefaultAdministratorRightsRequest is the request of setMyDefaultAdministratorRights, see API.SetMyDefaultAdministratorRights.
+type SetMyDefaultAdministratorRightsRequest struct {
+	// A JSON-serialized object describing new default administrator rights. If not specified, the
+	// default administrator rights will be cleared.
+	Rights *Rights
+
+	// Pass True to change the default administrator rights of the bot in channels. Otherwise, the
+	// default administrator rights of the bot for groups and supergroups will be changed.
+	ForChannels bool
+}
+
+func (r *SetMyDefaultAdministratorRightsRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	if r.Rights != nil {
+		if err := encodeParam(params, "rights", r.Rights); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if r.ForChannels {
+		params["for_channels"] = "true"
+	}
+
+	return params, files, nil
+}
+
+// SetMyDefaultAdministratorRights calls setMyDefaultAdministratorRights, see https://core.telegram.org/bots/api#setmydefaultadministratorrights
+//
+// Use this method to change the default administrator rights requested by the bot when it's
+// added as an administrator to groups or channels. These rights will be suggested to users,
+// but they are free to modify the list before adding the bot. Returns True on success.
+func (api API) SetMyDefaultAdministratorRights(ctx context.Context, r *SetMyDefaultAdministratorRightsRequest) error {
+	return api.call(ctx, "setMyDefaultAdministratorRights", r, nil)
+}
+
+// SetMyDescriptionRequest is the request of setMyDescription, see API.SetMyDescription.
+type SetMyDescriptionRequest struct {
+	// New bot description; 0-512 characters. Pass an empty string to remove the dedicated
+	// description for the given language.
+	Description string
+
+	// A two-letter ISO 639-1 language code. If empty, the description will be applied to all
+	// users for whose language there is no dedicated description.
+	LanguageCode string
+}
+
+func (r *SetMyDescriptionRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	if r.Description != "" {
+		params["description"] = r.Description
+	}
+
+	if r.LanguageCode != "" {
+		params["language_code"] = r.LanguageCode
+	}
+
+	return params, files, nil
+}
+
+// SetMyDescription calls setMyDescription, see https://core.telegram.org/bots/api#setmydescription
+//
+// Use this method to change the bot's description, which is shown in the chat with the bot if
+// the chat is empty. Returns True on success.
+func (api API) SetMyDescription(ctx context.Context, r *SetMyDescriptionRequest) error {
+	return api.call(ctx, "setMyDescription", r, nil)
+}
+
+// SetMyNameRequest is the request of setMyName, see API.SetMyName.
+type SetMyNameRequest struct {
+	// New bot name; 0-64 characters. Pass an empty string to remove the dedicated name for the
+	// given language.
+	Name string
+
+	// A two-letter ISO 639-1 language code. If empty, the name will be shown to all users for
+	// whose language there is no dedicated name.
+	LanguageCode string
+}
+
+func (r *SetMyNameRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	if r.Name != "" {
+		params["name"] = r.Name
+	}
+
+	if r.LanguageCode != "" {
+		params["language_code"] = r.LanguageCode
+	}
+
+	return params, files, nil
+}
+
+// SetMyName calls setMyName, see https://core.telegram.org/bots/api#setmyname
+//
+// Use this method to change the bot's name. Returns True on success.
+func (api API) SetMyName(ctx context.Context, r *SetMyNameRequest) error {
+	return api.call(ctx, "setMyName", r, nil)
+}
+
+// SetMyShortDescriptionRequest is the request of setMyShortDescription, see API.SetMyShortDescription.
+type SetMyShortDescriptionRequest struct {
+	// New short description for the bot; 0-120 characters. Pass an empty string to remove the
+	// dedicated short description for the given language.
+	ShortDescription string
+
+	// A two-letter ISO 639-1 language code. If empty, the short description will be applied to
+	// all users for whose language there is no dedicated short description.
+	LanguageCode string
+}
+
+func (r *SetMyShortDescriptionRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	if r.ShortDescription != "" {
+		params["short_description"] = r.ShortDescription
+	}
+
+	if r.LanguageCode != "" {
+		params["language_code"] = r.LanguageCode
+	}
+
+	return params, files, nil
+}
+
+// SetMyShortDescription calls setMyShortDescription, see https://core.telegram.org/bots/api#setmyshortdescription
+//
+// Use this method to change the bot's short description, which is shown on the bot's profile
+// page and is sent together with the link when users share the bot. Returns True on success.
+func (api API) SetMyShortDescription(ctx context.Context, r *SetMyShortDescriptionRequest) error {
+	return api.call(ctx, "setMyShortDescription", r, nil)
+}
+
+// SetPassportDataErrorsRequest is the request of setPassportDataErrors, see API.SetPassportDataErrors.
+type SetPassportDataErrorsRequest struct {
+	// User identifier. Required.
+	UserID int64
+
+	// A JSON-serialized array describing the errors. Required.
+	Errors interface{}
+}
+
+func (r *SetPassportDataErrorsRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	params["user_id"] = strconv.FormatInt(int64(r.UserID), 10)
+
+	if r.Errors != nil {
+		if err := encodeParam(params, "errors", r.Errors); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return params, files, nil
+}
+
+// SetPassportDataErrors calls setPassportDataErrors, see https://core.telegram.org/bots/api#setpassportdataerrors
+//
+// Informs a user that some of the Telegram Passport elements they provided contains errors.
+// The user will not be able to re-submit their Passport to you until the errors are fixed
+// (the contents of the field for which you returned the error must change). Returns True on
+// success.
+func (api API) SetPassportDataErrors(ctx context.Context, r *SetPassportDataErrorsRequest) error {
+	return api.call(ctx, "setPassportDataErrors", r, nil)
+}
+
+// SetStickerEmojiListRequest is the request of setStickerEmojiList, see API.SetStickerEmojiList.
+type SetStickerEmojiListRequest struct {
+	// File identifier of the sticker. Required.
+	Sticker string
+
+	// A JSON-serialized list of 1-20 emoji associated with the sticker. Required.
+	EmojiList []string
+}
+
+func (r *SetStickerEmojiListRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	params["sticker"] = r.Sticker
+
+	if r.EmojiList != nil {
+		if err := encodeParam(params, "emoji_list", r.EmojiList); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return params, files, nil
+}
+
+// SetStickerEmojiList calls setStickerEmojiList, see https://core.telegram.org/bots/api#setstickeremojilist
+//
+// Use this method to change the list of emoji assigned to a regular or custom emoji sticker.
+// The sticker must belong to a sticker set created by the bot. Returns True on success.
+func (api API) SetStickerEmojiList(ctx context.Context, r *SetStickerEmojiListRequest) error {
+	return api.call(ctx, "setStickerEmojiList", r, nil)
+}
+
+// SetStickerKeywordsRequest is the request of setStickerKeywords, see API.SetStickerKeywords.
+type SetStickerKeywordsRequest struct {
+	// File identifier of the sticker. Required.
+	Sticker string
+
+	// A JSON-serialized list of 0-20 search keywords for the sticker with total length of up to
+	// 64 characters
+	Keywords []string
+}
+
+func (r *SetStickerKeywordsRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	params["sticker"] = r.Sticker
+
+	if r.Keywords != nil {
+		if err := encodeParam(params, "keywords", r.Keywords); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return params, files, nil
+}
+
+// SetStickerKeywords calls setStickerKeywords, see https://core.telegram.org/bots/api#setstickerkeywords
+//
+// Use this method to change search keywords assigned to a regular or custom emoji sticker.
+// The sticker must belong to a sticker set created by the bot. Returns True on success.
+func (api API) SetStickerKeywords(ctx context.Context, r *SetStickerKeywordsRequest) error {
+	return api.call(ctx, "setStickerKeywords", r, nil)
+}
+
+// SetStickerMaskPositionRequest is the request of setStickerMaskPosition, see API.SetStickerMaskPosition.
+type SetStickerMaskPositionRequest struct {
+	// File identifier of the sticker. Required.
+	Sticker string
+
+	// A JSON-serialized object with the position where the mask should be placed on faces. Omit
+	// the parameter to remove the mask position.
+	MaskPosition *MaskPosition
+}
+
+func (r *SetStickerMaskPositionRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	params["sticker"] = r.Sticker
+
+	if r.MaskPosition != nil {
+		if err := encodeParam(params, "mask_position", r.MaskPosition); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return params, files, nil
+}
+
+// SetStickerMaskPosition calls setStickerMaskPosition, see https://core.telegram.org/bots/api#setstickermaskposition
+//
+// Use this method to change the mask position of a mask sticker. The sticker must belong to a
+// sticker set that was created by the bot. Returns True on success.
+func (api API) SetStickerMaskPosition(ctx context.Context, r *SetStickerMaskPositionRequest) error {
+	return api.call(ctx, "setStickerMaskPosition", r, nil)
+}
+
+// SetStickerPositionInSetRequest is the request of setStickerPositionInSet, see API.SetStickerPositionInSet.
+type SetStickerPositionInSetRequest struct {
+	// File identifier of the sticker. Required.
+	Sticker string
+
+	// New sticker position in the set, zero-based. Required.
+	Position int
+}
+
+func (r *SetStickerPositionInSetRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	params["sticker"] = r.Sticker
+
+	params["position"] = strconv.FormatInt(int64(r.Position), 10)
+
+	return params, files, nil
+}
+
+// SetStickerPositionInSet calls setStickerPositionInSet, see https://core.telegram.org/bots/api#setstickerpositioninset
+//
+// Use this method to move a sticker in a set created by the bot to a specific position.
+// Returns True on success.
+func (api API) SetStickerPositionInSet(ctx context.Context, r *SetStickerPositionInSetRequest) error {
+	return api.call(ctx, "setStickerPositionInSet", r, nil)
+}
+
+// SetStickerSetThumbnailRequest is the request of setStickerSetThumbnail, see API.SetStickerSetThumbnail.
+type SetStickerSetThumbnailRequest struct {
+	// Sticker set name. Required.
+	Name string
+
+	// User identifier of the sticker set owner. Required.
+	UserID int64
+
+	// A .WEBP or .PNG image with the thumbnail, must be up to 128 kilobytes in size and have a
+	// width and height of exactly 100px, or a .TGS animation with a thumbnail up to 32 kilobytes
+	// in size, or a WEBM video with the thumbnail up to 32 kilobytes in size. Pass a file_id as a
+	// String to send a file that already exists on the Telegram servers, pass an HTTP URL as a
+	// String for Telegram to get a file from the Internet, or upload a new one using
+	// multipart/form-data. Animated and video sticker set thumbnails can't be uploaded via HTTP
+	// URL. If omitted, then the thumbnail is dropped and the first sticker is used as the
+	// thumbnail.
+	Thumbnail File
+}
+
+func (r *SetStickerSetThumbnailRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	params["name"] = r.Name
+
+	params["user_id"] = strconv.FormatInt(int64(r.UserID), 10)
+
+	if !r.Thumbnail.empty() {
+		files["thumbnail"] = r.Thumbnail
+	}
+
+	return params, files, nil
+}
+
+// SetStickerSetThumbnail calls setStickerSetThumbnail, see https://core.telegram.org/bots/api#setstickersetthumbnail
+//
+// Use this method to set the thumbnail of a regular or mask sticker set. The format of the
+// thumbnail file must match the format of the stickers in the set. Returns True on success.
+func (api API) SetStickerSetThumbnail(ctx context.Context, r *SetStickerSetThumbnailRequest) error {
+	return api.call(ctx, "setStickerSetThumbnail", r, nil)
+}
+
+// SetStickerSetTitleRequest is the request of setStickerSetTitle, see API.SetStickerSetTitle.
+type SetStickerSetTitleRequest struct {
+	// Sticker set name. Required.
+	Name string
+
+	// Sticker set title, 1-64 characters. Required.
+	Title string
+}
+
+func (r *SetStickerSetTitleRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	params["name"] = r.Name
+
+	params["title"] = r.Title
+
+	return params, files, nil
+}
+
+// SetStickerSetTitle calls setStickerSetTitle, see https://core.telegram.org/bots/api#setstickersettitle
+//
+// Use this method to set the title of a created sticker set. Returns True on success.
+func (api API) SetStickerSetTitle(ctx context.Context, r *SetStickerSetTitleRequest) error {
+	return api.call(ctx, "setStickerSetTitle", r, nil)
+}
+
+// SetWebhookRequest is the request of setWebhook, see API.SetWebhook.
+type SetWebhookRequest struct {
+	// HTTPS URL to send updates to. Use an empty string to remove webhook integration. Required.
+	URL string
+
+	// Upload your public key certificate so that the root certificate in use can be checked.
+	Certificate File
+
+	// The fixed IP address which will be used to send webhook requests instead of the IP address
+	// resolved through DNS
+	IPAddress string
+
+	// The maximum allowed number of simultaneous HTTPS connections to the webhook for update
+	// delivery, 1-100. Defaults to 40.
+	MaxConnections int
+
+	// A JSON-serialized list of the update types you want your bot to receive.
+	AllowedUpdates []string
+
+	// Pass True to drop all pending updates
+	DropPendingUpdates bool
+
+	// A secret token to be sent in a header “X-Telegram-Bot-Api-Secret-Token” in every
+	// webhook request, 1-256 characters.
+	SecretToken string
+}
+
+func (r *SetWebhookRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	params["url"] = r.URL
+
+	if !r.Certificate.empty() {
+		files["certificate"] = r.Certificate
+	}
+
+	if r.IPAddress != "" {
+		params["ip_address"] = r.IPAddress
+	}
+
+	if r.MaxConnections != 0 {
+		params["max_connections"] = strconv.FormatInt(int64(r.MaxConnections), 10)
+	}
+
+	if r.AllowedUpdates != nil {
+		if err := encodeParam(params, "allowed_updates", r.AllowedUpdates); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if r.DropPendingUpdates {
+		params["drop_pending_updates"] = "true"
+	}
+
+	if r.SecretToken != "" {
+		params["secret_token"] = r.SecretToken
+	}
+
+	return params, files, nil
+}
+
+// SetWebhook calls setWebhook, see https://core.telegram.org/bots/api#setwebhook
+//
+// Use this method to specify a URL and receive incoming updates via an outgoing webhook.
+// Returns True on success.
+func (api API) SetWebhook(ctx context.Context, r *SetWebhookRequest) error {
+	return api.call(ctx, "setWebhook", r, nil)
+}
+
+// StopMessageLiveLocationRequest is the request of stopMessageLiveLocation, see API.StopMessageLiveLocation.
+type StopMessageLiveLocationRequest struct {
+	// Required if inline_message_id is not specified. Unique identifier for the target chat or
+	// username of the target channel (in the format @channelusername)
+	ChatID Recipient
+
+	// Required if inline_message_id is not specified. Identifier of the message with live
+	// location to stop
+	MessageID int
+
+	// Required if chat_id and message_id are not specified. Identifier of the inline message
+	InlineMessageID string
+
+	// A JSON-serialized object for a new inline keyboard.
+	ReplyMarkup *ReplyMarkup
+}
+
+func (r *StopMessageLiveLocationRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	if r.ChatID != nil {
+		params["chat_id"] = r.ChatID.Recipient()
+	}
+
+	if r.MessageID != 0 {
+		params["message_id"] = strconv.FormatInt(int64(r.MessageID), 10)
+	}
+
+	if r.InlineMessageID != "" {
+		params["inline_message_id"] = r.InlineMessageID
+	}
+
+	if r.ReplyMarkup != nil {
+		if err := encodeParam(params, "reply_markup", r.ReplyMarkup); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return params, files, nil
+}
+
+// StopMessageLiveLocation calls stopMessageLiveLocation, see https://core.telegram.org/bots/api#stopmessagelivelocation
+//
+// Use this method to stop updating a live location message before live_period expires. On
+// success, if the message is not an inline message, the edited Message is returned, otherwise
+// True is returned.
+func (api API) StopMessageLiveLocation(ctx context.Context, r *StopMessageLiveLocationRequest) (*Message, error) {
+	var result *Message
+	err := api.call(ctx, "stopMessageLiveLocation", r, &result)
+	return result, err
+}
+
+// StopPollRequest is the request of stopPoll, see API.StopPoll.
+type StopPollRequest struct {
+	// Unique identifier for the target chat or username of the target channel (in the format
+	// @channelusername). Required.
+	ChatID Recipient
+
+	// Identifier of the original message with the poll. Required.
+	MessageID int
+
+	// A JSON-serialized object for a new message inline keyboard.
+	ReplyMarkup *ReplyMarkup
+}
+
+func (r *StopPollRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	if r.ChatID != nil {
+		params["chat_id"] = r.ChatID.Recipient()
+	}
+
+	params["message_id"] = strconv.FormatInt(int64(r.MessageID), 10)
+
+	if r.ReplyMarkup != nil {
+		if err := encodeParam(params, "reply_markup", r.ReplyMarkup); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return params, files, nil
+}
+
+// StopPoll calls stopPoll, see https://core.telegram.org/bots/api#stoppoll
+//
+// Use this method to stop a poll which was sent by the bot. On success, the stopped Poll is
+// returned.
+func (api API) StopPoll(ctx context.Context, r *StopPollRequest) (*Poll, error) {
+	var result *Poll
+	err := api.call(ctx, "stopPoll", r, &result)
+	return result, err
+}
+
+// UnbanChatMemberRequest is the request of unbanChatMember, see API.UnbanChatMember.
+type UnbanChatMemberRequest struct {
+	// Unique identifier for the target group or username of the target supergroup or channel (in
+	// the format @channelusername). Required.
+	ChatID Recipient
+
+	// Unique identifier of the target user. Required.
+	UserID int64
+
+	// Do nothing if the user is not banned
+	OnlyIfBanned bool
+}
+
+func (r *UnbanChatMemberRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	if r.ChatID != nil {
+		params["chat_id"] = r.ChatID.Recipient()
+	}
+
+	params["user_id"] = strconv.FormatInt(int64(r.UserID), 10)
+
+	if r.OnlyIfBanned {
+		params["only_if_banned"] = "true"
+	}
+
+	return params, files, nil
+}
+
+// UnbanChatMember calls unbanChatMember, see https://core.telegram.org/bots/api#unbanchatmember
+//
+// Use this method to unban a previously banned user in a supergroup or channel. Returns True
+// on success.
+func (api API) UnbanChatMember(ctx context.Context, r *UnbanChatMemberRequest) error {
+	return api.call(ctx, "unbanChatMember", r, nil)
+}
+
+// UnbanChatSenderChatRequest is the request of unbanChatSenderChat, see API.UnbanChatSenderChat.
+type UnbanChatSenderChatRequest struct {
+	// Unique identifier for the target chat or username of the target channel (in the format
+	// @channelusername). Required.
+	ChatID Recipient
+
+	// Unique identifier of the target sender chat. Required.
+	SenderChatID int64
+}
+
+func (r *UnbanChatSenderChatRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	if r.ChatID != nil {
+		params["chat_id"] = r.ChatID.Recipient()
+	}
+
+	params["sender_chat_id"] = strconv.FormatInt(int64(r.SenderChatID), 10)
+
+	return params, files, nil
+}
+
+// UnbanChatSenderChat calls unbanChatSenderChat, see https://core.telegram.org/bots/api#unbanchatsenderchat
+//
+// Use this method to unban a previously banned channel chat in a supergroup or channel. The
+// bot must be an administrator for this to work and must have the appropriate administrator
+// rights. Returns True on success.
+func (api API) UnbanChatSenderChat(ctx context.Context, r *UnbanChatSenderChatRequest) error {
+	return api.call(ctx, "unbanChatSenderChat", r, nil)
+}
+
+// UnhideGeneralForumTopicRequest is the request of unhideGeneralForumTopic, see API.UnhideGeneralForumTopic.
+type UnhideGeneralForumTopicRequest struct {
+	// Unique identifier for the target chat or username of the target supergroup (in the format
+	// @supergroupusername). Required.
+	ChatID Recipient
+}
+
+func (r *UnhideGeneralForumTopicRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	if r.ChatID != nil {
+		params["chat_id"] = r.ChatID.Recipient()
+	}
+
+	return params, files, nil
+}
+
+// UnhideGeneralForumTopic calls unhideGeneralForumTopic, see https://core.telegram.org/bots/api#unhidegeneralforumtopic
+//
+// Use this method to unhide the 'General' topic in a forum supergroup chat. The bot must be
+// an administrator in the chat for this to work and must have the can_manage_topics
+// administrator rights. Returns True on success.
+func (api API) UnhideGeneralForumTopic(ctx context.Context, r *UnhideGeneralForumTopicRequest) error {
+	return api.call(ctx, "unhideGeneralForumTopic", r, nil)
+}
+
+// UnpinAllChatMessagesRequest is the request of unpinAllChatMessages, see API.UnpinAllChatMessages.
+type UnpinAllChatMessagesRequest struct {
+	// Unique identifier for the target chat or username of the target channel (in the format
+	// @channelusername). Required.
+	ChatID Recipient
+}
+
+func (r *UnpinAllChatMessagesRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	if r.ChatID != nil {
+		params["chat_id"] = r.ChatID.Recipient()
+	}
+
+	return params, files, nil
+}
+
+// UnpinAllChatMessages calls unpinAllChatMessages, see https://core.telegram.org/bots/api#unpinallchatmessages
+//
+// Use this method to clear the list of pinned messages in a chat. If the chat is not a
+// private chat, the bot must be an administrator in the chat for this to work and must have
+// the 'can_pin_messages' administrator right in a supergroup or 'can_edit_messages'
+// administrator right in a channel. Returns True on success.
+func (api API) UnpinAllChatMessages(ctx context.Context, r *UnpinAllChatMessagesRequest) error {
+	return api.call(ctx, "unpinAllChatMessages", r, nil)
+}
+
+// UnpinAllForumTopicMessagesRequest is the request of unpinAllForumTopicMessages, see API.UnpinAllForumTopicMessages.
+type UnpinAllForumTopicMessagesRequest struct {
+	// Unique identifier for the target chat or username of the target supergroup (in the format
+	// @supergroupusername). Required.
+	ChatID Recipient
+
+	// Unique identifier for the target message thread of the forum topic. Required.
+	MessageThreadID int
+}
+
+func (r *UnpinAllForumTopicMessagesRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	if r.ChatID != nil {
+		params["chat_id"] = r.ChatID.Recipient()
+	}
+
+	params["message_thread_id"] = strconv.FormatInt(int64(r.MessageThreadID), 10)
+
+	return params, files, nil
+}
+
+// UnpinAllForumTopicMessages calls unpinAllForumTopicMessages, see https://core.telegram.org/bots/api#unpinallforumtopicmessages
+//
+// Use this method to clear the list of pinned messages in a forum topic. The bot must be an
+// administrator in the chat for this to work and must have the can_pin_messages administrator
+// right in the supergroup. Returns True on success.
+func (api API) UnpinAllForumTopicMessages(ctx context.Context, r *UnpinAllForumTopicMessagesRequest) error {
+	return api.call(ctx, "unpinAllForumTopicMessages", r, nil)
+}
+
+// UnpinAllGeneralForumTopicMessagesRequest is the request of unpinAllGeneralForumTopicMessages, see API.UnpinAllGeneralForumTopicMessages.
+type UnpinAllGeneralForumTopicMessagesRequest struct {
+	// Unique identifier for the target chat or username of the target supergroup (in the format
+	// @supergroupusername). Required.
+	ChatID Recipient
+}
+
+func (r *UnpinAllGeneralForumTopicMessagesRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	if r.ChatID != nil {
+		params["chat_id"] = r.ChatID.Recipient()
+	}
+
+	return params, files, nil
+}
+
+// UnpinAllGeneralForumTopicMessages calls unpinAllGeneralForumTopicMessages, see https://core.telegram.org/bots/api#unpinallgeneralforumtopicmessages
+//
+// Use this method to clear the list of pinned messages in a General forum topic. The bot must
+// be an administrator in the chat for this to work and must have the can_pin_messages
+// administrator right in the supergroup. Returns True on success.
+func (api API) UnpinAllGeneralForumTopicMessages(ctx context.Context, r *UnpinAllGeneralForumTopicMessagesRequest) error {
+	return api.call(ctx, "unpinAllGeneralForumTopicMessages", r, nil)
+}
+
+// UnpinChatMessageRequest is the request of unpinChatMessage, see API.UnpinChatMessage.
+type UnpinChatMessageRequest struct {
+	// Unique identifier for the target chat or username of the target channel (in the format
+	// @channelusername). Required.
+	ChatID Recipient
+
+	// Identifier of a message to unpin. If not specified, the most recent pinned message (by
+	// sending date) will be unpinned.
+	MessageID int
+}
+
+func (r *UnpinChatMessageRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	if r.ChatID != nil {
+		params["chat_id"] = r.ChatID.Recipient()
+	}
+
+	if r.MessageID != 0 {
+		params["message_id"] = strconv.FormatInt(int64(r.MessageID), 10)
+	}
+
+	return params, files, nil
+}
+
+// UnpinChatMessage calls unpinChatMessage, see https://core.telegram.org/bots/api#unpinchatmessage
+//
+// Use this method to remove a message from the list of pinned messages in a chat. If the chat
+// is not a private chat, the bot must be an administrator in the chat for this to work and
+// must have the 'can_pin_messages' administrator right in a supergroup or 'can_edit_messages'
+// administrator right in a channel. Returns True on success.
+func (api API) UnpinChatMessage(ctx context.Context, r *UnpinChatMessageRequest) error {
+	return api.call(ctx, "unpinChatMessage", r, nil)
+}
+
+// UploadStickerFileRequest is the request of uploadStickerFile, see API.UploadStickerFile.
+type UploadStickerFileRequest struct {
+	// User identifier of sticker file owner. Required.
+	UserID int64
+
+	// A file with the sticker in .WEBP, .PNG, .TGS, or .WEBM format. Required.
+	Sticker File
+
+	// Format of the sticker, must be one of “static”, “animated”, “video”. Required.
+	StickerFormat string
+}
+
+func (r *UploadStickerFileRequest) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+
+	params["user_id"] = strconv.FormatInt(int64(r.UserID), 10)
+
+	if !r.Sticker.empty() {
+		files["sticker"] = r.Sticker
+	}
+
+	params["sticker_format"] = r.StickerFormat
+
+	return params, files, nil
+}
+
+// UploadStickerFile calls uploadStickerFile, see https://core.telegram.org/bots/api#uploadstickerfile
+//
+// Use this method to upload a file with a sticker for later use in the createNewStickerSet
+// and addStickerToSet methods (the file can be used multiple times). Returns the uploaded
+// File on success.
+func (api API) UploadStickerFile(ctx context.Context, r *UploadStickerFileRequest) (*File, error) {
+	var result *File
+	err := api.call(ctx, "uploadStickerFile", r, &result)
+	return result, err
+}
@@ -1,6 +1,7 @@
 package telebot
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"io"
@@ -8,6 +9,9 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/graphomania/tg/scheduler"
 
 	"github.com/stretchr/testify/require"
 
@@ -118,3 +122,96 @@ func TestExtractMessage(t *testing.T) {
 	_, err = extractMessage(data)
 	require.NoError(t, err)
 }
+
+// priorityScheduler records the priorities of scheduled requests.
+type priorityScheduler struct {
+	priorities []scheduler.Priority
+}
+
+func (sch *priorityScheduler) SyncFunc(count int, chat string, fn scheduler.RawFunc) ([]byte, error) {
+	return sch.SyncFuncContext(context.Background(), count, chat, fn)
+}
+
+func (sch *priorityScheduler) SyncFuncContext(ctx context.Context, count int, chat string, fn scheduler.RawFunc) ([]byte, error) {
+	sch.priorities = append(sch.priorities, scheduler.PriorityFrom(ctx))
+	return fn()
+}
+
+func TestRawPriority(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"ok":true,"result":{"message_id":1}}`))
+	}))
+	defer srv.Close()
+
+	sch := &priorityScheduler{}
+	b, err := NewBot(Settings{URL: srv.URL, Offline: true, Scheduler: sch})
+	require.NoError(t, err)
+
+	_, err = b.Send(&Chat{ID: 1}, "text")
+	require.NoError(t, err)
+	_, err = b.Send(&Chat{ID: 1}, "text", scheduler.Bulk)
+	require.NoError(t, err)
+	_, err = b.Send(&Chat{ID: 1}, &Location{}, &SendOptions{Priority: scheduler.Interactive})
+	require.NoError(t, err)
+	_, err = b.SendContext(scheduler.WithPriority(context.Background(), scheduler.Bulk), &Chat{ID: 1}, "text")
+	require.NoError(t, err)
+
+	assert.Equal(t, []scheduler.Priority{
+		scheduler.Normal, scheduler.Bulk, scheduler.Interactive, scheduler.Bulk,
+	}, sch.priorities)
+}
+
+func TestRawContext(t *testing.T) {
+	var sent int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sent++
+		w.Write([]byte(`{"ok":true,"result":{"message_id":1}}`))
+	}))
+	defer srv.Close()
+
+	b, err := NewBot(Settings{URL: srv.URL, Offline: true, Scheduler: scheduler.Bucket(1, 20)})
+	require.NoError(t, err)
+
+	_, err = b.Send(&Chat{ID: 1}, "takes the only slot")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err = b.SendContext(ctx, &Chat{ID: 1}, "stale")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	_, err = b.RawContext(ctx, "sendMessage", map[string]string{"chat_id": "1", "text": "stale"})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, 1, sent)
+}
+
+func TestRawFlood(t *testing.T) {
+	var sent int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if sent++; sent == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte(`{"ok":false,"error_code":429,"description":"Too Many Requests: retry after 1","parameters":{"retry_after":1}}`))
+			return
+		}
+		w.Write([]byte(`{"ok":true,"result":{"message_id":1}}`))
+	}))
+	defer srv.Close()
+
+	b, err := NewBot(Settings{URL: srv.URL, Offline: true, Scheduler: scheduler.DefaultBucket()})
+	require.NoError(t, err)
+
+	start := time.Now()
+	_, err = b.Send(&Chat{ID: -100}, "retried transparently")
+	require.NoError(t, err)
+	assert.Equal(t, 2, sent)
+	assert.GreaterOrEqual(t, time.Since(start), time.Second)
+
+	// the request is dropped, if ctx is done before the time asked passes
+	sent = 0
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	_, err = b.SendContext(ctx, &Chat{ID: -200}, "dropped")
+	assert.ErrorAs(t, err, &FloodError{})
+	assert.Equal(t, 1, sent)
+}
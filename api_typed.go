@@ -0,0 +1,136 @@
+package telebot
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+//go:generate go run ./cmd/tg-apigen -spec cmd/tg-apigen/api.json -out api_gen.go
+
+// API is the typed Bot API: a method with its request struct for every call,
+// generated from the spec vendored in cmd/tg-apigen. The requests are encoded the same way
+// for all the calls: the optional fields left zero aren't sent, the objects are sent as JSON.
+//
+// The methods of Bot, e.g. Send and Edit, are the convenience layer on top of it.
+// Like them, the calls are scheduled, retried and intercepted.
+//
+// Example:
+//
+//	msg, err := b.API().SendMessage(ctx, &tele.SendMessageRequest{
+//		ChatID:          tele.ChatID(chatID),
+//		MessageThreadID: 42,
+//		Text:            "hello",
+//	})
+type API struct {
+	b *Bot
+}
+
+// API returns the typed Bot API of the bot.
+func (b *Bot) API() API {
+	return API{b: b}
+}
+
+// apiRequest is a generated request, see API.
+type apiRequest interface {
+	params() (map[string]string, map[string]File, error)
+}
+
+// call sends the request, decoding its result into the result, if it's not nil.
+func (api API) call(ctx context.Context, method string, req apiRequest, result interface{}) error {
+	params, files := make(map[string]string), map[string]File(nil)
+	if req != nil {
+		var err error
+		if params, files, err = req.params(); err != nil {
+			return wrapError(err)
+		}
+	}
+
+	var (
+		data []byte
+		err  error
+	)
+	if len(files) > 0 {
+		data, err = api.b.sendFilesContext(ctx, method, files, params)
+	} else {
+		data, err = api.b.RawContext(ctx, method, params)
+	}
+	if err != nil || result == nil {
+		return err
+	}
+
+	var resp struct {
+		Result json.RawMessage `json:"result"`
+	}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return wrapError(err)
+	}
+	// the inline messages edited are true
+	if bytes.Equal(resp.Result, []byte("true")) {
+		return nil
+	}
+	if err := json.Unmarshal(resp.Result, result); err != nil {
+		return wrapError(err)
+	}
+	return nil
+}
+
+// encodeParam sets the param to the object encoded as JSON.
+func encodeParam(params map[string]string, name string, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	params[name] = string(data)
+	return nil
+}
+
+// encodeMedia sets the param to the album or the media encoded as InputMedia.
+// The files to be uploaded are attached to the request, named after the param.
+func encodeMedia(params map[string]string, files map[string]File, name string, media interface{}) error {
+	var v interface{}
+	switch media := media.(type) {
+	case Album:
+		album := make([]InputMedia, len(media))
+		for i, med := range media {
+			im, err := attachMedia(files, name+strconv.Itoa(i), med)
+			if err != nil {
+				return err
+			}
+			album[i] = im
+		}
+		v = album
+	case Inputtable:
+		im, err := attachMedia(files, name, media)
+		if err != nil {
+			return err
+		}
+		v = im
+	}
+	return encodeParam(params, name, v)
+}
+
+// attachMedia returns the media as InputMedia, attaching its files as field, if they're to be uploaded.
+func attachMedia(files map[string]File, field string, med Inputtable) (InputMedia, error) {
+	im := med.InputMedia()
+	switch file := med.MediaFile(); {
+	case file.InCloud():
+		im.Media = file.FileID
+	case file.FileURL != "":
+		im.Media = file.FileURL
+	case file.empty():
+		return im, fmt.Errorf("media %s does not exist", field)
+	default:
+		im.Media = "attach://" + field
+		files[field] = *file
+	}
+
+	// the thumbnails are always uploaded
+	if v, ok := med.(*Video); ok && v.Thumbnail != nil {
+		im.Thumbnail = "attach://" + field + "_thumbnail"
+		files[field+"_thumbnail"] = v.Thumbnail.File
+	}
+	return im, nil
+}
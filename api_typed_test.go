@@ -0,0 +1,121 @@
+package telebot
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPI(t *testing.T) {
+	var got []map[string]string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		params := make(map[string]string)
+		if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/") {
+			require.NoError(t, r.ParseMultipartForm(1<<20))
+			for k, v := range r.MultipartForm.Value {
+				params[k] = v[0]
+			}
+		} else {
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&params))
+		}
+		got = append(got, params)
+
+		if params["inline_message_id"] != "" {
+			w.Write([]byte(`{"ok":true,"result":true}`))
+			return
+		}
+		w.Write([]byte(`{"ok":true,"result":{"message_id":1,"chat":{"id":1},"text":"hi"}}`))
+	}))
+	defer srv.Close()
+
+	b, err := NewBot(Settings{URL: srv.URL, Offline: true})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	msg, err := b.API().SendMessage(ctx, &SendMessageRequest{
+		ChatID:          ChatID(-100),
+		MessageThreadID: 7,
+		Text:            "hi",
+		Entities:        Entities{{Type: EntityBold, Length: 2}},
+		ReplyMarkup:     &ReplyMarkup{InlineKeyboard: [][]InlineButton{{{Text: "ok", Data: "ok"}}}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "hi", msg.Text)
+
+	params := got[0]
+	assert.Equal(t, "-100", params["chat_id"])
+	assert.Equal(t, "7", params["message_thread_id"])
+	assert.Contains(t, params["entities"], `"type":"bold"`)
+	assert.Contains(t, params["reply_markup"], `"callback_data":"ok"`)
+	// the optional fields left zero aren't sent
+	assert.NotContains(t, params, "parse_mode")
+	assert.NotContains(t, params, "disable_notification")
+
+	msg, err = b.API().EditMessageText(ctx, &EditMessageTextRequest{InlineMessageID: "inline", Text: "hi"})
+	require.NoError(t, err)
+	assert.Nil(t, msg)
+
+	_, err = b.API().SendPhoto(ctx, &SendPhotoRequest{
+		ChatID:  ChatID(1),
+		Photo:   FromReader(strings.NewReader("jpeg")),
+		Caption: "pic",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "pic", got[2]["caption"])
+	assert.Equal(t, "jpeg", got[2]["photo"])
+
+	require.NoError(t, b.API().DeleteMessage(ctx, &DeleteMessageRequest{ChatID: ChatID(1), MessageID: 1}))
+	assert.Equal(t, map[string]string{"chat_id": "1", "message_id": "1"}, got[3])
+}
+
+func TestAPIMedia(t *testing.T) {
+	var (
+		params map[string]string
+		files  map[string]string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseMultipartForm(1<<20))
+		params, files = make(map[string]string), make(map[string]string)
+		for k, v := range r.MultipartForm.Value {
+			params[k] = v[0]
+		}
+		for k := range r.MultipartForm.File {
+			files[k] = k
+		}
+		w.Write([]byte(`{"ok":true,"result":[{"message_id":1,"chat":{"id":1}},{"message_id":2,"chat":{"id":1}}]}`))
+	}))
+	defer srv.Close()
+
+	b, err := NewBot(Settings{URL: srv.URL, Offline: true})
+	require.NoError(t, err)
+
+	msgs, err := b.API().SendMediaGroup(context.Background(), &SendMediaGroupRequest{
+		ChatID: ChatID(1),
+		Media: Album{
+			&Photo{File: File{FileID: "photo"}, Caption: "cloud"},
+			&Photo{File: FromReader(strings.NewReader("jpeg"))},
+		},
+	})
+	require.NoError(t, err)
+	assert.Len(t, msgs, 2)
+
+	var media []InputMedia
+	require.NoError(t, json.Unmarshal([]byte(params["media"]), &media))
+	assert.Equal(t, "photo", media[0].Media)
+	assert.Equal(t, "cloud", media[0].Caption)
+	// the files to be uploaded are attached
+	assert.Equal(t, "attach://media1", media[1].Media)
+	assert.Equal(t, "jpeg", params["media1"])
+
+	_, err = b.API().SendMediaGroup(context.Background(), &SendMediaGroupRequest{
+		ChatID: ChatID(1),
+		Media:  Album{&Photo{}},
+	})
+	assert.EqualError(t, err, "telebot: media media0 does not exist")
+}
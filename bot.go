@@ -1,11 +1,13 @@
 package telebot
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"github.com/graphomania/tg/clock"
 	"github.com/graphomania/tg/scheduler"
 	"io"
-	"log"
+	"log/slog"
 	"net/http"
 	"os"
 	"regexp"
@@ -21,23 +23,41 @@ func NewBot(pref Settings) (*Bot, error) {
 		pref.Updates = 100
 	}
 
-	client := pref.Client
-	if client == nil {
-		client = &http.Client{Timeout: time.Minute}
-	}
-
 	if pref.URL == "" {
 		pref.URL = DefaultApiURL
 	}
+	client := pref.Client
+	if client == nil {
+		client = &http.Client{Timeout: time.Minute, Transport: DefaultTransport(pref.URL)}
+	}
 	if pref.Poller == nil {
 		pref.Poller = &LongPoller{}
 	}
-	if pref.OnError == nil {
-		pref.OnError = defaultOnError
+	if pref.Logger == nil {
+		pref.Logger = slog.Default()
 	}
 	if pref.Scheduler == nil {
 		pref.Scheduler = scheduler.Nil()
 	}
+	downloadPolicy := pref.RetryPolicy
+	if pref.RetryPolicy == nil {
+		// the requests may have reached telegram before failing,
+		// so only the floods are sent again unless asked to
+		policy := &Backoff{Retries: 3, FloodsOnly: true}
+		// while the downloads are idempotent and resumed on any failure
+		downloadPolicy = DefaultRetryPolicy()
+		if pref.Retries > 0 {
+			policy = DefaultRetryPolicy()
+			policy.Retries = pref.Retries
+			downloadPolicy = policy
+		}
+		pref.RetryPolicy = policy
+	}
+	if pref.Clock == nil {
+		pref.Clock = clock.Real()
+	} else if sch, ok := pref.Scheduler.(scheduler.Clocked); ok {
+		sch.UseClock(pref.Clock)
+	}
 
 	bot := &Bot{
 		Token:   pref.Token,
@@ -49,14 +69,26 @@ func NewBot(pref Settings) (*Bot, error) {
 		handlers: make(map[string]HandlerFunc),
 		stop:     make(chan chan struct{}),
 
-		synchronous: pref.Synchronous,
-		verbose:     pref.Verbose,
-		parseMode:   pref.ParseMode,
-		client:      client,
-		local:       pref.Local,
-		scheduler:   pref.Scheduler,
-		retries:     pref.Retries,
+		synchronous:    pref.Synchronous,
+		verbose:        pref.Verbose,
+		parseMode:      pref.ParseMode,
+		client:         client,
+		local:          pref.Local,
+		scheduler:      pref.Scheduler,
+		retryPolicy:    pref.RetryPolicy,
+		downloadPolicy: downloadPolicy,
+		outbox:         pref.Outbox,
+		clock:          pref.Clock,
+		logger:         pref.Logger,
+	}
+	if bot.onError == nil {
+		bot.onError = bot.logError
+	}
+
+	if pref.Verbose {
+		bot.Intercept(verboseClocked(bot.logger, bot.clock))
 	}
+	bot.Intercept(pref.Interceptors...)
 
 	if pref.Offline {
 		bot.Me = &User{}
@@ -69,6 +101,9 @@ func NewBot(pref Settings) (*Bot, error) {
 	}
 
 	bot.group = bot.Group()
+	if bot.outbox != nil {
+		go bot.replayOutbox(bot.outbox.takeUnsent())
+	}
 	return bot, nil
 }
 
@@ -91,7 +126,14 @@ type Bot struct {
 	client      *http.Client
 	stopClient  chan struct{}
 	scheduler   scheduler.Scheduler
-	retries     int
+	retryPolicy RetryPolicy
+	// downloadPolicy resumes the downloads, see Settings.RetryPolicy
+	downloadPolicy RetryPolicy
+	interceptors   []Interceptor
+	invoker        Invoker
+	outbox         *Outbox
+	clock          clock.Clock
+	logger         *slog.Logger
 }
 
 // Settings represents a utility struct for passing certain
@@ -114,6 +156,10 @@ type Settings struct {
 	// Use for debugging purposes only.
 	Verbose bool
 
+	// Interceptors wrap the requests sent to the API, see Interceptor.
+	// With Verbose, the logging goes first.
+	Interceptors []Interceptor
+
 	// Local modifies bot some bot behaviours, mainly, File downloading.
 	Local Local
 
@@ -125,9 +171,15 @@ type Settings struct {
 	// OnError is a callback function that will get called on errors
 	// resulted from the handler. It is used as post-middleware function.
 	// Notice that context can be nil.
+	// By default, the errors are logged by Logger along with LogAttrs of the context.
 	OnError func(error, Context)
 
-	// HTTP Client used to make requests to telegram api
+	// Logger is the logger of the bot, defaulted to slog.Default().
+	// The requests sent to the API are logged at the debug level.
+	Logger *slog.Logger
+
+	// HTTP Client used to make requests to telegram api, defaulted to the one
+	// with a minute timeout and the transport keeping the connections, see DefaultTransport.
 	Client *http.Client
 
 	// Offline allows to create a bot without network for testing purposes.
@@ -137,19 +189,29 @@ type Settings struct {
 	// https://core.telegram.org/bots/faq#my-bot-is-hitting-limits-how-do-i-avoid-this
 	Scheduler scheduler.Scheduler
 
+	// RetryPolicy decides whether the failed requests are sent again. If nil, only the floods are,
+	// up to 3 times. Mind that a request, which has timed out, may have reached telegram,
+	// so retrying the non-idempotent ones, i.e. sendMessage, on any failure may duplicate them.
+	// It resumes the downloads as well, which follow DefaultRetryPolicy, if nil.
+	RetryPolicy RetryPolicy
+
+	// Retries makes the bot retry with DefaultRetryPolicy with the retries, if positive.
+	//
+	// Deprecated: use RetryPolicy.
 	Retries int
-}
 
-var defaultOnError = func(err error, c Context) {
-	if c != nil {
-		log.Println(c.Update().ID, err)
-	} else {
-		log.Println(err)
-	}
+	// Clock is the source of time of the bot and its scheduler, defaulted to the real one.
+	// Pass clock.Fake to test the timing offline.
+	Clock clock.Clock
+
+	// Outbox makes the sends, edits and deletes survive a crash, see OpenOutbox.
+	// The requests left unacknowledged by the previous run are delivered again in the background.
+	Outbox *Outbox
 }
 
+// OnError passes the error to Settings.OnError, having the token masked in it, see Redact.
 func (b *Bot) OnError(err error, c Context) {
-	b.onError(err, c)
+	b.onError(b.redact(err), c)
 }
 
 func (b *Bot) debug(err error) {
@@ -230,15 +292,27 @@ func (b *Bot) Start() {
 		close(stopConfirm)
 	}()
 
+	handle := func(upd Update) {
+		b.ProcessUpdate(upd)
+		if acker, ok := b.Poller.(Acker); ok {
+			acker.Ack(upd)
+		}
+	}
+
 	for {
 		select {
 		// handle incoming updates
 		case upd := <-b.Updates:
-			b.ProcessUpdate(upd)
+			handle(upd)
 			// call to stop polling
 		case confirm := <-b.stop:
 			close(stop)
 			<-stopConfirm
+			// the updates taken by the poller while it stopped are acknowledged already,
+			// e.g. the ones of the webhook requests drained
+			for len(b.Updates) > 0 {
+				handle(<-b.Updates)
+			}
 			close(confirm)
 			b.stopClient = nil
 			return
@@ -284,12 +358,20 @@ func (b *Bot) NewContext(u Update) Context {
 //   - *ReplyMarkup (a component of SendOptions)
 //   - Option (a shortcut flag for popular options)
 //   - ParseMode (HTML, Markdown, etc)
+//   - scheduler.Priority (Interactive, Normal or Bulk)
 func (b *Bot) Send(to Recipient, what interface{}, opts ...interface{}) (*Message, error) {
+	return b.SendContext(context.Background(), to, what, opts...)
+}
+
+// SendContext is Send bound to ctx. If ctx is done before the message is sent,
+// it is dropped and ctx.Err() is returned. See RawContext for details.
+func (b *Bot) SendContext(ctx context.Context, to Recipient, what interface{}, opts ...interface{}) (*Message, error) {
 	if to == nil {
 		return nil, ErrBadRecipient
 	}
 
 	sendOpts := extractOptions(opts)
+	sendOpts.ctx = ctx
 
 	switch object := what.(type) {
 	case string:
@@ -305,11 +387,17 @@ func (b *Bot) Send(to Recipient, what interface{}, opts ...interface{}) (*Messag
 // To include the caption, make sure the first Inputtable of an album has it.
 // From all existing options, it only supports tele.Silent.
 func (b *Bot) SendAlbum(to Recipient, album Album, opts ...interface{}) ([]Message, error) {
+	return b.SendAlbumContext(context.Background(), to, album, opts...)
+}
+
+// SendAlbumContext is SendAlbum bound to ctx, see SendContext.
+func (b *Bot) SendAlbumContext(ctx context.Context, to Recipient, album Album, opts ...interface{}) ([]Message, error) {
 	if to == nil {
 		return nil, ErrBadRecipient
 	}
 
 	sendOpts := extractOptions(opts)
+	sendOpts.ctx = ctx
 	inputMedias := make([]string, len(album))
 	files := make(map[string]File)
 
@@ -391,7 +479,7 @@ func (b *Bot) SendAlbum(to Recipient, album Album, opts ...interface{}) ([]Messa
 	}
 	b.embedSendOptions(params, sendOpts)
 
-	data, err := b.sendFiles("sendMediaGroup", files, params)
+	data, err := b.sendFilesContext(sendOpts.context(), "sendMediaGroup", files, params)
 	if err != nil {
 		return nil, err
 	}
@@ -454,7 +542,7 @@ func (b *Bot) Forward(to Recipient, msg Editable, opts ...interface{}) (*Message
 	sendOpts := extractOptions(opts)
 	b.embedSendOptions(params, sendOpts)
 
-	data, err := b.Raw("forwardMessage", params)
+	data, err := b.RawContext(sendOpts.context(), "forwardMessage", params)
 	if err != nil {
 		return nil, err
 	}
@@ -480,7 +568,7 @@ func (b *Bot) Copy(to Recipient, msg Editable, options ...interface{}) (*Message
 	sendOpts := extractOptions(options)
 	b.embedSendOptions(params, sendOpts)
 
-	data, err := b.Raw("copyMessage", params)
+	data, err := b.RawContext(sendOpts.context(), "copyMessage", params)
 	if err != nil {
 		return nil, err
 	}
@@ -547,7 +635,7 @@ func (b *Bot) Edit(msg Editable, what interface{}, opts ...interface{}) (*Messag
 	sendOpts := extractOptions(opts)
 	b.embedSendOptions(params, sendOpts)
 
-	data, err := b.Raw(method, params)
+	data, err := b.RawContext(sendOpts.context(), method, params)
 	if err != nil {
 		return nil, err
 	}
@@ -611,7 +699,7 @@ func (b *Bot) EditCaption(msg Editable, caption string, opts ...interface{}) (*M
 	sendOpts := extractOptions(opts)
 	b.embedSendOptions(params, sendOpts)
 
-	data, err := b.Raw("editMessageCaption", params)
+	data, err := b.RawContext(sendOpts.context(), "editMessageCaption", params)
 	if err != nil {
 		return nil, err
 	}
@@ -699,7 +787,7 @@ func (b *Bot) EditMedia(msg Editable, media Inputtable, opts ...interface{}) (*M
 		params["message_id"] = msgID
 	}
 
-	data, err := b.sendFiles("editMessageMedia", files, params)
+	data, err := b.sendFilesContext(sendOpts.context(), "editMessageMedia", files, params)
 	if err != nil {
 		return nil, err
 	}
@@ -898,6 +986,7 @@ func (b *Bot) FileByID(fileID string) (File, error) {
 // Download saves the file from Telegram servers locally.
 // Maximum file size to download is 20 MB.
 // Unless you use b.Local=true with your own API server (limit=2 GB).
+// See DownloadContext for the resumable and verified downloads.
 func (b *Bot) Download(file *File, localFilename string) error {
 	if b.local != nil {
 		return b.local.Download(b, file, localFilename)
@@ -950,16 +1039,16 @@ func (b *Bot) File(file *File) (io.ReadCloser, error) {
 
 	req, err := http.NewRequest(http.MethodGet, url, nil)
 	if err != nil {
-		return nil, wrapError(err)
+		return nil, wrapError(b.redact(err))
 	}
 
 	resp, err := b.client.Do(req)
 	if err != nil {
-		return nil, wrapError(err)
+		return nil, wrapError(b.redact(err))
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		resp.Body.Close()
+		drain(resp.Body)
 		return nil, fmt.Errorf("telebot: expected status 200 but got %s", resp.Status)
 	}
 
@@ -985,7 +1074,7 @@ func (b *Bot) StopLiveLocation(msg Editable, opts ...interface{}) (*Message, err
 	sendOpts := extractOptions(opts)
 	b.embedSendOptions(params, sendOpts)
 
-	data, err := b.Raw("stopMessageLiveLocation", params)
+	data, err := b.RawContext(sendOpts.context(), "stopMessageLiveLocation", params)
 	if err != nil {
 		return nil, err
 	}
@@ -1009,7 +1098,7 @@ func (b *Bot) StopPoll(msg Editable, opts ...interface{}) (*Poll, error) {
 	sendOpts := extractOptions(opts)
 	b.embedSendOptions(params, sendOpts)
 
-	data, err := b.Raw("stopPoll", params)
+	data, err := b.RawContext(sendOpts.context(), "stopPoll", params)
 	if err != nil {
 		return nil, err
 	}
@@ -1048,7 +1137,7 @@ func (b *Bot) Pin(msg Editable, opts ...interface{}) error {
 	sendOpts := extractOptions(opts)
 	b.embedSendOptions(params, sendOpts)
 
-	_, err := b.Raw("pinChatMessage", params)
+	_, err := b.RawContext(sendOpts.context(), "pinChatMessage", params)
 	return err
 }
 
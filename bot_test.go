@@ -2,10 +2,8 @@ package telebot
 
 import (
 	"errors"
-	"github.com/graphomania/tg/scheduler"
 	"io"
 	"io/ioutil"
-	"log"
 	"net/http"
 	"os"
 	"strconv"
@@ -36,26 +34,6 @@ func newTestBot() (*Bot, error) {
 	return NewBot(defaultSettings())
 }
 
-func TestFren(t *testing.T) {
-	bot, err := NewBot(Settings{
-		Token:   os.Getenv("TG_TEST_TOKEN"),
-		Verbose: true,
-		OnError: func(err error, context Context) {
-			log.Println(err)
-		},
-		Scheduler: scheduler.Default(),
-	})
-	require.NoError(t, err)
-
-	chat, err := strconv.ParseInt(os.Getenv("TG_TEST_CHAT"), 10, 64)
-	require.NoError(t, err)
-
-	for i := 0; i < 30; i++ {
-		_, err := bot.Send(&Chat{ID: chat}, strconv.Itoa(i))
-		require.NoError(t, err)
-	}
-}
-
 func TestNewBot(t *testing.T) {
 	var pref Settings
 	_, err := NewBot(pref)
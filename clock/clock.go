@@ -0,0 +1,58 @@
+// Package clock abstracts the time away, so that the timing of the bot,
+// i.e. the quota and the album grouping, can be tested deterministically.
+//
+// Example:
+//
+//	fake := clock.NewFake(time.Now())
+//	b, _ := tele.NewBot(tele.Settings{Clock: fake, Scheduler: scheduler.DefaultBucket(), ...})
+//	// ...
+//	fake.Advance(time.Minute)
+package clock
+
+import (
+	"time"
+)
+
+// Clock is the source of time.
+type Clock interface {
+	Now() time.Time
+
+	// AfterFunc calls f in its own goroutine after the duration elapses.
+	AfterFunc(d time.Duration, f func()) Timer
+
+	// After returns a channel receiving the time after the duration elapses.
+	After(d time.Duration) <-chan time.Time
+
+	// Sleep pauses the current goroutine for the duration.
+	Sleep(d time.Duration)
+}
+
+// Timer is a single event, see Clock.AfterFunc.
+// Its methods have the same semantics as the ones of time.Timer.
+type Timer interface {
+	Reset(d time.Duration) bool
+	Stop() bool
+}
+
+// Real returns the clock of the time package.
+func Real() Clock {
+	return realClock{}
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) AfterFunc(d time.Duration, f func()) Timer {
+	return time.AfterFunc(d, f)
+}
+
+func (realClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+func (realClock) Sleep(d time.Duration) {
+	time.Sleep(d)
+}
@@ -0,0 +1,150 @@
+package clock
+
+import (
+	"slices"
+	"sync"
+	"time"
+)
+
+var _ Clock = &Fake{}
+
+// Fake is a clock, which only moves on Advance.
+// The timers fire in order from the goroutine calling Advance, so once it returns,
+// everything due has been done, except for what the timers have started in the background.
+type Fake struct {
+	mu      sync.Mutex
+	changed *sync.Cond
+	now     time.Time
+	timers  []*fakeTimer
+}
+
+type fakeTimer struct {
+	clock  *Fake
+	at     time.Time
+	f      func()
+	active bool
+}
+
+// NewFake returns a fake clock showing the time.
+func NewFake(now time.Time) *Fake {
+	c := &Fake{now: now}
+	c.changed = sync.NewCond(&c.mu)
+	return c
+}
+
+func (c *Fake) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *Fake) AfterFunc(d time.Duration, f func()) Timer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	t := &fakeTimer{clock: c, at: c.now.Add(d), f: f, active: true}
+	c.timers = append(c.timers, t)
+	c.changed.Broadcast()
+	return t
+}
+
+func (c *Fake) After(d time.Duration) <-chan time.Time {
+	ch := make(chan time.Time, 1)
+	c.AfterFunc(d, func() { ch <- c.Now() })
+	return ch
+}
+
+func (c *Fake) Sleep(d time.Duration) {
+	<-c.After(d)
+}
+
+// Advance moves the time forward, firing the timers due in order.
+func (c *Fake) Advance(d time.Duration) {
+	c.mu.Lock()
+	end := c.now.Add(d)
+	for {
+		var next *fakeTimer
+		for _, t := range c.timers {
+			if t.active && !t.at.After(end) && (next == nil || t.at.Before(next.at)) {
+				next = t
+			}
+		}
+		if next == nil {
+			break
+		}
+
+		if next.at.After(c.now) {
+			c.now = next.at
+		}
+		next.active = false
+		c.prune()
+
+		c.mu.Unlock()
+		next.f()
+		c.mu.Lock()
+	}
+	c.now = end
+	c.mu.Unlock()
+}
+
+// Timers returns the number of the timers waiting to fire.
+func (c *Fake) Timers() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.active()
+}
+
+// BlockUntil blocks until at least n timers are waiting to fire,
+// i.e. until n goroutines are sleeping.
+func (c *Fake) BlockUntil(n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for c.active() < n {
+		c.changed.Wait()
+	}
+}
+
+func (c *Fake) active() (n int) {
+	for _, t := range c.timers {
+		if t.active {
+			n++
+		}
+	}
+	return n
+}
+
+// prune forgets the stopped timers. Must be called with mu locked.
+func (c *Fake) prune() {
+	active := c.timers[:0]
+	for _, t := range c.timers {
+		if t.active {
+			active = append(active, t)
+		}
+	}
+	clear(c.timers[len(active):])
+	c.timers = active
+}
+
+func (t *fakeTimer) Reset(d time.Duration) bool {
+	c := t.clock
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	active := t.active
+	t.at, t.active = c.now.Add(d), true
+	if !slices.Contains(c.timers, t) {
+		c.timers = append(c.timers, t)
+	}
+	c.changed.Broadcast()
+	return active
+}
+
+func (t *fakeTimer) Stop() bool {
+	c := t.clock
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	active := t.active
+	t.active = false
+	return active
+}
@@ -0,0 +1,50 @@
+package clock
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFake(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := NewFake(start)
+
+	var fired []int
+	c.AfterFunc(2*time.Second, func() {
+		assert.Equal(t, start.Add(2*time.Second), c.Now())
+		fired = append(fired, 2)
+	})
+	c.AfterFunc(time.Second, func() { fired = append(fired, 1) })
+	stopped := c.AfterFunc(time.Second, func() { fired = append(fired, 0) })
+	assert.True(t, stopped.Stop())
+	assert.Equal(t, 2, c.Timers())
+
+	c.Advance(1500 * time.Millisecond)
+	assert.Equal(t, []int{1}, fired)
+	assert.Equal(t, start.Add(1500*time.Millisecond), c.Now())
+
+	c.Advance(time.Second)
+	assert.Equal(t, []int{1, 2}, fired)
+	assert.Zero(t, c.Timers())
+
+	// the stopped timer can be reset
+	assert.False(t, stopped.Reset(time.Second))
+	c.Advance(time.Second)
+	assert.Equal(t, []int{1, 2, 0}, fired)
+}
+
+func TestFakeSleep(t *testing.T) {
+	c := NewFake(time.Now())
+
+	done := make(chan struct{})
+	go func() {
+		c.Sleep(time.Hour)
+		close(done)
+	}()
+
+	c.BlockUntil(1)
+	c.Advance(time.Hour)
+	<-done
+}
@@ -0,0 +1,382 @@
+// Command tg-apigen generates the typed requests of Bot API from its spec,
+// see telebot.API. It's run by go generate in the root of the module.
+//
+// The spec is the JSON of github.com/PaulSonOfLars/telegram-bot-api-spec, the methods of it,
+// so a new version of the API is adopted by replacing api.json and regenerating.
+//
+// Usage:
+//
+//	tg-apigen -spec cmd/tg-apigen/api.json -out api_gen.go
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+type (
+	spec struct {
+		Version string             `json:"version"`
+		Methods map[string]*method `json:"methods"`
+	}
+
+	method struct {
+		Name        string   `json:"name"`
+		Href        string   `json:"href"`
+		Description []string `json:"description"`
+		Returns     []string `json:"returns"`
+		Fields      []*field `json:"fields"`
+	}
+
+	field struct {
+		Name        string   `json:"name"`
+		Types       []string `json:"types"`
+		Required    bool     `json:"required"`
+		Description string   `json:"description"`
+	}
+)
+
+// The kinds of the fields tell how they're encoded.
+const (
+	kindString    = "string"
+	kindInt       = "int"
+	kindFloat     = "float"
+	kindBool      = "bool"
+	kindRecipient = "recipient"
+	kindFile      = "file"
+	kindMedia     = "media"
+	kindJSON      = "json"
+)
+
+// knownTypes are the types of the spec, which telebot has.
+var knownTypes = map[string]string{
+	"Array of MessageEntity":  "Entities",
+	"Array of String":         "[]string",
+	"Array of Integer":        "[]int",
+	"Array of BotCommand":     "[]Command",
+	"Array of Update":         "[]Update",
+	"Array of ChatMember":     "[]ChatMember",
+	"Array of Message":        "[]Message",
+	"Array of Sticker":        "[]Sticker",
+	"Array of GameHighScore":  "[]GameHighScore",
+	"Array of LabeledPrice":   "[]Price",
+	"Array of ShippingOption": "[]ShippingOption",
+	"InlineKeyboardMarkup":    "*ReplyMarkup",
+	"ChatPermissions":         "*Rights",
+	"ChatAdministratorRights": "*Rights",
+	"BotCommandScope":         "*CommandScope",
+	"MenuButton":              "*MenuButton",
+	"MaskPosition":            "*MaskPosition",
+	"Message":                 "*Message",
+	"User":                    "*User",
+	"Chat":                    "*Chat",
+	"ChatMember":              "*ChatMember",
+	"ChatInviteLink":          "*ChatInviteLink",
+	"File":                    "*File",
+	"Poll":                    "*Poll",
+	"StickerSet":              "*StickerSet",
+	"ForumTopic":              "*Topic",
+	"SentWebAppMessage":       "*WebAppMessage",
+	"Integer":                 "int",
+	"String":                  "string",
+}
+
+func main() {
+	var (
+		specPath = flag.String("spec", "cmd/tg-apigen/api.json", "path to the spec")
+		out      = flag.String("out", "api_gen.go", "path to the generated file")
+	)
+	flag.Parse()
+
+	data, err := os.ReadFile(*specPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+	var s spec
+	if err := json.Unmarshal(data, &s); err != nil {
+		log.Fatalf("tg-apigen: bad spec: %v", err)
+	}
+
+	code, err := generate(&s)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(*out, code, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func generate(s *spec) ([]byte, error) {
+	names := make([]string, 0, len(s.Methods))
+	for name := range s.Methods {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	methods := make([]*method, len(names))
+	for i, name := range names {
+		methods[i] = s.Methods[name]
+	}
+
+	var usesJSON, usesStrconv bool
+	for _, m := range methods {
+		usesJSON = usesJSON || m.Result() == "json.RawMessage"
+		for _, f := range m.Fields {
+			switch f.Kind() {
+			case kindInt, kindFloat:
+				usesStrconv = true
+			case kindBool:
+				usesStrconv = usesStrconv || f.Required
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, map[string]interface{}{
+		"Version":     s.Version,
+		"Methods":     methods,
+		"UsesJSON":    usesJSON,
+		"UsesStrconv": usesStrconv,
+	}); err != nil {
+		return nil, err
+	}
+
+	code, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("tg-apigen: generated code is bad: %w\n%s", err, buf.Bytes())
+	}
+	return code, nil
+}
+
+// GoName returns the name of the method exported, e.g. SendMessage.
+func (m *method) GoName() string {
+	return strings.ToUpper(m.Name[:1]) + m.Name[1:]
+}
+
+// Result returns the type of the result, empty if it's just true.
+func (m *method) Result() string {
+	for _, r := range m.Returns {
+		if r == "Boolean" || r == "True" {
+			continue
+		}
+		if t, ok := knownTypes[r]; ok {
+			return t
+		}
+		return "json.RawMessage"
+	}
+	return ""
+}
+
+// Doc returns the description of the method as a comment.
+func (m *method) Doc() string {
+	return comment(strings.Join(m.Description, " "))
+}
+
+// GoName returns the name of the field exported, e.g. ChatID.
+func (f *field) GoName() string {
+	parts := strings.Split(f.Name, "_")
+	for i, p := range parts {
+		switch p {
+		case "id", "url", "ip":
+			parts[i] = strings.ToUpper(p)
+		case "ids":
+			parts[i] = "IDs"
+		default:
+			parts[i] = strings.ToUpper(p[:1]) + p[1:]
+		}
+	}
+	return strings.Join(parts, "")
+}
+
+// Kind tells how the field is encoded.
+func (f *field) Kind() string {
+	switch t := strings.Join(f.Types, " or "); {
+	case t == "Integer or String":
+		return kindRecipient
+	case strings.Contains(t, "InputFile"):
+		return kindFile
+	case strings.Contains(t, "InputMedia"):
+		return kindMedia
+	case t == "String":
+		return kindString
+	case t == "Integer":
+		return kindInt
+	case t == "Float":
+		return kindFloat
+	case t == "Boolean":
+		return kindBool
+	default:
+		return kindJSON
+	}
+}
+
+// GoType returns the type of the field.
+func (f *field) GoType() string {
+	switch f.Kind() {
+	case kindRecipient:
+		return "Recipient"
+	case kindFile:
+		return "File"
+	case kindString:
+		return "string"
+	case kindInt:
+		// the identifiers of the users and the chats don't fit 32 bits
+		if strings.HasSuffix(f.Name, "user_id") || strings.HasSuffix(f.Name, "chat_id") {
+			return "int64"
+		}
+		return "int"
+	case kindFloat:
+		return "float64"
+	case kindBool:
+		return "bool"
+	case kindMedia:
+		if strings.HasPrefix(f.Types[0], "Array of") {
+			return "Album"
+		}
+		return "Inputtable"
+	}
+
+	if f.Name == "reply_markup" {
+		return "*ReplyMarkup"
+	}
+	if len(f.Types) == 1 {
+		if t, ok := knownTypes[f.Types[0]]; ok {
+			return t
+		}
+	}
+	return "interface{}"
+}
+
+// Doc returns the description of the field as a comment.
+func (f *field) Doc() string {
+	desc := f.Description
+	if f.Required {
+		desc = strings.TrimSuffix(desc, ".") + ". Required."
+	}
+	return comment(desc)
+}
+
+// comment wraps the text into the lines of a comment.
+func comment(text string) string {
+	var lines []string
+	var line string
+	for _, word := range strings.Fields(text) {
+		if line != "" && len(line)+len(word) > 90 {
+			lines = append(lines, line)
+			line = ""
+		}
+		if line != "" {
+			line += " "
+		}
+		line += word
+	}
+	if line != "" {
+		lines = append(lines, line)
+	}
+	return "// " + strings.Join(lines, "\n// ")
+}
+
+var tmpl = template.Must(template.New("api").Parse(`// Code generated by tg-apigen from {{.Version}}; DO NOT EDIT.
+
+package telebot
+
+import (
+	"context"
+{{- if .UsesJSON}}
+	"encoding/json"
+{{- end}}
+{{- if .UsesStrconv}}
+	"strconv"
+{{- end}}
+)
+{{range $m := .Methods}}{{if $m.Fields}}
+// {{$m.GoName}}Request is the request of {{$m.Name}}, see API.{{$m.GoName}}.
+type {{$m.GoName}}Request struct {
+{{- range $m.Fields}}
+	{{.Doc}}
+	{{.GoName}} {{.GoType}}
+{{end -}}
+}
+
+func (r *{{$m.GoName}}Request) params() (map[string]string, map[string]File, error) {
+	params := make(map[string]string)
+	files := make(map[string]File)
+{{range $m.Fields}}{{if eq .Kind "string"}}
+	{{- if .Required}}
+	params["{{.Name}}"] = r.{{.GoName}}
+	{{- else}}
+	if r.{{.GoName}} != "" {
+		params["{{.Name}}"] = r.{{.GoName}}
+	}
+	{{- end}}
+{{else if eq .Kind "int"}}
+	{{- if .Required}}
+	params["{{.Name}}"] = strconv.FormatInt(int64(r.{{.GoName}}), 10)
+	{{- else}}
+	if r.{{.GoName}} != 0 {
+		params["{{.Name}}"] = strconv.FormatInt(int64(r.{{.GoName}}), 10)
+	}
+	{{- end}}
+{{else if eq .Kind "float"}}
+	{{- if .Required}}
+	params["{{.Name}}"] = strconv.FormatFloat(r.{{.GoName}}, 'f', -1, 64)
+	{{- else}}
+	if r.{{.GoName}} != 0 {
+		params["{{.Name}}"] = strconv.FormatFloat(r.{{.GoName}}, 'f', -1, 64)
+	}
+	{{- end}}
+{{else if eq .Kind "bool"}}
+	{{- if .Required}}
+	params["{{.Name}}"] = strconv.FormatBool(r.{{.GoName}})
+	{{- else}}
+	if r.{{.GoName}} {
+		params["{{.Name}}"] = "true"
+	}
+	{{- end}}
+{{else if eq .Kind "recipient"}}
+	if r.{{.GoName}} != nil {
+		params["{{.Name}}"] = r.{{.GoName}}.Recipient()
+	}
+{{else if eq .Kind "file"}}
+	if !r.{{.GoName}}.empty() {
+		files["{{.Name}}"] = r.{{.GoName}}
+	}
+{{else if eq .Kind "media"}}
+	if r.{{.GoName}} != nil {
+		if err := encodeMedia(params, files, "{{.Name}}", r.{{.GoName}}); err != nil {
+			return nil, nil, err
+		}
+	}
+{{else}}
+	if r.{{.GoName}} != nil {
+		if err := encodeParam(params, "{{.Name}}", r.{{.GoName}}); err != nil {
+			return nil, nil, err
+		}
+	}
+{{end}}{{end}}
+	return params, files, nil
+}
+{{end}}
+// {{$m.GoName}} calls {{$m.Name}}, see {{$m.Href}}
+//
+{{$m.Doc}}
+{{- if $m.Result}}
+func (api API) {{$m.GoName}}(ctx context.Context{{if $m.Fields}}, r *{{$m.GoName}}Request{{end}}) ({{$m.Result}}, error) {
+	var result {{$m.Result}}
+	err := api.call(ctx, "{{$m.Name}}", {{if $m.Fields}}r{{else}}nil{{end}}, &result)
+	return result, err
+}
+{{- else}}
+func (api API) {{$m.GoName}}(ctx context.Context{{if $m.Fields}}, r *{{$m.GoName}}Request{{end}}) error {
+	return api.call(ctx, "{{$m.Name}}", {{if $m.Fields}}r{{else}}nil{{end}}, nil)
+}
+{{- end}}
+{{end}}`))
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestGenerated checks that the generated code is up to date with the spec and the generator.
+func TestGenerated(t *testing.T) {
+	data, err := os.ReadFile("api.json")
+	require.NoError(t, err)
+	var s spec
+	require.NoError(t, json.Unmarshal(data, &s))
+
+	code, err := generate(&s)
+	require.NoError(t, err)
+
+	generated, err := os.ReadFile("../../api_gen.go")
+	require.NoError(t, err)
+	assert.Equal(t, string(generated), string(code), "run go generate")
+}
+
+func TestFieldNames(t *testing.T) {
+	for name, want := range map[string]string{
+		"chat_id":              "ChatID",
+		"message_thread_id":    "MessageThreadID",
+		"url":                  "URL",
+		"ip_address":           "IPAddress",
+		"disable_notification": "DisableNotification",
+		"custom_emoji_ids":     "CustomEmojiIDs",
+	} {
+		assert.Equal(t, want, (&field{Name: name}).GoName())
+	}
+}
@@ -0,0 +1,27 @@
+// Command tg-coordinator serves a shared quota for the bot replicas using scheduler.RemoteStore.
+//
+// Usage:
+//
+//	tg-coordinator -listen :8030 -global 30 -per-chat 20
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+
+	"github.com/graphomania/tg/scheduler"
+)
+
+func main() {
+	var (
+		listen  = flag.String("listen", ":8030", "address to listen on")
+		global  = flag.Int("global", scheduler.ApiRequestQuota, "global quota per second")
+		perChat = flag.Int("per-chat", scheduler.ApiRequestQuotaPerChat, "per chat quota per minute")
+	)
+	flag.Parse()
+
+	store := scheduler.MemoryStore(*global, *perChat)
+	log.Printf("tg-coordinator: listening on %s", *listen)
+	log.Fatal(http.ListenAndServe(*listen, scheduler.Coordinator(store)))
+}
@@ -5,6 +5,8 @@ import (
 	"strings"
 	"sync"
 	"time"
+
+	"github.com/graphomania/tg/clock"
 )
 
 // HandlerFunc represents a handler function, which is
@@ -130,8 +132,13 @@ type Context interface {
 	// DeleteAfter waits for the duration to elapse and then removes the
 	// message. It handles an error automatically using b.OnError callback.
 	// It returns a Timer that can be used to cancel the call using its Stop method.
+	// With a clock of the bot other than the real one, the timer returned is
+	// a stopped one, which can't cancel the call, use DeleteIn then.
 	DeleteAfter(d time.Duration) *time.Timer
 
+	// DeleteIn is DeleteAfter following the clock of the bot (see Settings.Clock).
+	DeleteIn(d time.Duration) clock.Timer
+
 	// Notify updates the chat action for the current recipient.
 	// See Notify from bot.go.
 	Notify(action ChatAction) error
@@ -162,10 +169,11 @@ type Context interface {
 // nativeContext is a native implementation of the Context interface.
 // "context" is taken by context package, maybe there is a better name.
 type nativeContext struct {
-	b     *Bot
-	u     Update
-	lock  sync.RWMutex
-	store map[string]interface{}
+	b        *Bot
+	u        Update
+	lock     sync.RWMutex
+	store    map[string]interface{}
+	endpoint string
 }
 
 func (c *nativeContext) Bot() *Bot {
@@ -449,7 +457,16 @@ func (c *nativeContext) Delete() error {
 }
 
 func (c *nativeContext) DeleteAfter(d time.Duration) *time.Timer {
-	return time.AfterFunc(d, func() {
+	if t, ok := c.DeleteIn(d).(*time.Timer); ok {
+		return t
+	}
+	t := time.NewTimer(d)
+	t.Stop()
+	return t
+}
+
+func (c *nativeContext) DeleteIn(d time.Duration) clock.Timer {
+	return c.b.clock.AfterFunc(d, func() {
 		if err := c.Delete(); err != nil {
 			c.b.OnError(err, c)
 		}
@@ -1,9 +1,16 @@
 package telebot
 
 import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/graphomania/tg/clock"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 var _ Context = (*nativeContext)(nil)
@@ -16,3 +23,30 @@ func TestContext(t *testing.T) {
 		assert.Equal(t, "Jon Snow", c.Get("name"))
 	})
 }
+
+func TestContextDeleteAfter(t *testing.T) {
+	var deleted atomic.Int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/deleteMessage") {
+			deleted.Add(1)
+		}
+		w.Write([]byte(`{"ok":true,"result":true}`))
+	}))
+	defer srv.Close()
+
+	fake := clock.NewFake(time.Now())
+	b, err := NewBot(Settings{URL: srv.URL, Offline: true, Clock: fake})
+	require.NoError(t, err)
+
+	c := b.NewContext(Update{Message: &Message{ID: 1, Chat: &Chat{ID: 1}}})
+	c.DeleteAfter(time.Minute)
+	canceled := c.DeleteIn(time.Minute)
+
+	fake.Advance(time.Second)
+	assert.Zero(t, deleted.Load())
+	assert.True(t, canceled.Stop())
+
+	fake.Advance(time.Minute)
+	assert.Eventually(t, func() bool { return deleted.Load() == 1 }, time.Second, time.Millisecond)
+	assert.Never(t, func() bool { return deleted.Load() > 1 }, 20*time.Millisecond, time.Millisecond)
+}
@@ -0,0 +1,366 @@
+package telebot
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+)
+
+// PartSuffix is appended to the destination of the download to name the file
+// it's downloaded into, which is renamed to the destination once it's complete.
+const PartSuffix = ".part"
+
+// ErrSizeMismatch is returned, if the size of the file downloaded isn't File.FileSize.
+var ErrSizeMismatch = errors.New("telebot: size of the file downloaded doesn't match")
+
+// errNoRanges tells the server sent the whole file, when a range of it was asked.
+var errNoRanges = errors.New("telebot: server doesn't serve ranges")
+
+// DownloadOptions are the options of Bot.DownloadContext.
+type DownloadOptions struct {
+	// Resume continues the download left by the previous call in the partial
+	// file (see PartSuffix), instead of starting over.
+	Resume bool
+
+	// Chunks is how many ranges of the file are downloaded in parallel,
+	// which pays off with the large files of a local Bot API server.
+	// The file is downloaded in one go if it's 1 or less, or the size is unknown.
+	Chunks int
+
+	// SHA256 makes the download compute the SHA-256 digest of the file.
+	SHA256 bool
+}
+
+// Downloaded is the file downloaded by Bot.DownloadContext.
+type Downloaded struct {
+	Path string
+	Size int64
+
+	// SHA256 is the digest of the file, if asked by DownloadOptions.SHA256.
+	SHA256 []byte
+}
+
+// DownloadContext saves the file from Telegram servers to dst, or takes it
+// from the local Bot API server as Settings.Local does, if it's set.
+// LocalCopying copies the file with the options below, the other ones
+// (LocalMoving included) take it on their own, so it's only checked afterwards.
+//
+// Unlike Download, it survives the dropped connections: the download is resumed
+// from where it stopped as the retry policy allows (see Settings.RetryPolicy).
+// If it fails anyway, the partial file is left to be resumed with DownloadOptions.Resume.
+// The size of the file is checked against File.FileSize, ErrSizeMismatch is returned otherwise.
+func (b *Bot) DownloadContext(ctx context.Context, file *File, dst string, opts *DownloadOptions) (*Downloaded, error) {
+	if opts == nil {
+		opts = &DownloadOptions{}
+	}
+
+	if file.FilePath == "" || file.FileSize == 0 {
+		f, err := b.API().GetFile(ctx, &GetFileRequest{FileID: file.FileID})
+		if err != nil {
+			return nil, err
+		}
+		file.FilePath = f.FilePath
+		if file.FileSize == 0 {
+			file.FileSize = f.FileSize
+		}
+	}
+
+	var src fileSource = httpSource{b: b, url: b.URL + "/file/bot" + b.Token + "/" + file.FilePath}
+	switch b.local.(type) {
+	case nil:
+	case localCopy, *localCopy:
+		src = diskSource(file.FilePath)
+	default:
+		return b.downloadLocal(file, dst, opts)
+	}
+
+	part := dst + PartSuffix
+	flags := os.O_CREATE | os.O_WRONLY
+	if !opts.Resume {
+		flags |= os.O_TRUNC
+	}
+	out, err := os.OpenFile(part, flags, 0o644)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+
+	size, err := b.fetch(ctx, src, out, file.FileSize, opts.Chunks)
+	if cerr := out.Close(); err == nil && cerr != nil {
+		err = wrapError(cerr)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if file.FileSize > 0 && size != file.FileSize {
+		os.Remove(part)
+		return nil, fmt.Errorf("%w: got %d bytes, expected %d", ErrSizeMismatch, size, file.FileSize)
+	}
+
+	result := &Downloaded{Path: dst, Size: size}
+	if opts.SHA256 {
+		if result.SHA256, err = digest(part); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := os.Rename(part, dst); err != nil {
+		return nil, wrapError(err)
+	}
+	if b.local != nil {
+		// the original copy, as LocalCopying does
+		file.FileLocal = file.FilePath
+	} else {
+		file.FileLocal = dst
+	}
+	return result, nil
+}
+
+// downloadLocal takes the file with Settings.Local and checks it.
+// The file isn't removed on the size mismatch, since it may be the only one left.
+func (b *Bot) downloadLocal(file *File, dst string, opts *DownloadOptions) (*Downloaded, error) {
+	if err := b.local.Download(b, file, dst); err != nil {
+		return nil, err
+	}
+
+	info, err := os.Stat(dst)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+	if file.FileSize > 0 && info.Size() != file.FileSize {
+		return nil, fmt.Errorf("%w: got %d bytes, expected %d", ErrSizeMismatch, info.Size(), file.FileSize)
+	}
+
+	result := &Downloaded{Path: dst, Size: info.Size()}
+	if opts.SHA256 {
+		if result.SHA256, err = digest(dst); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// fetch downloads the file into out, continuing after the bytes it has already.
+// The size is 0 if it's unknown. It returns the size of the file downloaded.
+func (b *Bot) fetch(ctx context.Context, src fileSource, out *os.File, size int64, chunks int) (int64, error) {
+	info, err := out.Stat()
+	if err != nil {
+		return 0, wrapError(err)
+	}
+	offset := info.Size()
+	if size > 0 && offset > size {
+		// not the same file
+		offset = 0
+	}
+
+	if chunks > 1 && size > 0 && size-offset >= int64(chunks) {
+		done, err := b.fetchChunks(ctx, src, out, offset, size, chunks)
+		if !errors.Is(err, errNoRanges) {
+			if terr := out.Truncate(done); err == nil && terr != nil {
+				err = wrapError(terr)
+			}
+			return done, err
+		}
+		// to be downloaded in one go
+		offset = done
+	}
+
+	// not limited by the size, so that the size is checked by the caller
+	n, err := b.fetchRange(ctx, src, out, offset, -1, true)
+	if terr := out.Truncate(offset + n); err == nil && terr != nil {
+		err = wrapError(terr)
+	}
+	return offset + n, err
+}
+
+// fetchChunks downloads the bytes [offset, size) of the file as chunks in parallel.
+// It returns the end of the bytes downloaded from offset without a gap,
+// so that the partial file is resumed correctly.
+func (b *Bot) fetchChunks(ctx context.Context, src fileSource, out *os.File, offset, size int64, chunks int) (int64, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		step    = (size - offset + int64(chunks) - 1) / int64(chunks)
+		ranges  = make([][2]int64, 0, chunks)
+		written = make([]int64, chunks)
+		errs    = make([]error, chunks)
+		wg      sync.WaitGroup
+	)
+	for from := offset; from < size; from += step {
+		ranges = append(ranges, [2]int64{from, min(from+step, size)})
+	}
+
+	for i, r := range ranges {
+		wg.Add(1)
+		go func(i int, from, to int64) {
+			defer wg.Done()
+			written[i], errs[i] = b.fetchRange(ctx, src, out, from, to, false)
+			if errs[i] != nil {
+				cancel()
+			}
+		}(i, r[0], r[1])
+	}
+	wg.Wait()
+
+	done := offset
+	for i, r := range ranges {
+		done = r[0] + written[i]
+		if done < r[1] {
+			break
+		}
+	}
+
+	// the first failure is the cause, the rest are cancelled by it
+	for _, err := range errs {
+		if err != nil && !errors.Is(err, context.Canceled) {
+			return done, err
+		}
+	}
+	return done, errors.Join(errs...)
+}
+
+// fetchRange downloads the bytes [from, to) of the file into out, to the end of it if to is -1.
+// The download is resumed on failures, as the retry policy allows. It returns the bytes written.
+// See copyRange for skip.
+func (b *Bot) fetchRange(ctx context.Context, src fileSource, out *os.File, from, to int64, skip bool) (int64, error) {
+	var written int64
+	start := b.clock.Now()
+	for attempt := 1; ; attempt++ {
+		n, err := copyRange(ctx, src, out, from+written, to, skip)
+		written += n
+		if err == nil || ctx.Err() != nil {
+			return written, err
+		}
+
+		wait, ok := b.downloadPolicy.Retry("getFile", attempt, b.clock.Now().Sub(start), err)
+		if !ok {
+			return written, err
+		}
+		select {
+		case <-b.clock.After(wait):
+		case <-ctx.Done():
+			return written, err
+		}
+	}
+}
+
+// copyRange copies the bytes [from, to) of the file into out once.
+// If the source sends the whole file, the bytes before from are skipped, if skip is true,
+// errNoRanges is returned otherwise.
+func copyRange(ctx context.Context, src fileSource, out *os.File, from, to int64, skip bool) (int64, error) {
+	r, ranged, err := src.open(ctx, from, to)
+	if err != nil {
+		return 0, err
+	}
+	defer drain(r)
+
+	var body io.Reader = r
+	if !ranged && from > 0 {
+		if !skip {
+			return 0, errNoRanges
+		}
+		if _, err := io.CopyN(io.Discard, r, from); err != nil {
+			return 0, wrapError(err)
+		}
+	}
+	if to >= 0 {
+		body = io.LimitReader(body, to-from)
+	}
+
+	n, err := io.Copy(io.NewOffsetWriter(out, from), body)
+	if err != nil {
+		return n, wrapError(err)
+	}
+	if to >= 0 && n < to-from {
+		return n, wrapError(io.ErrUnexpectedEOF)
+	}
+	return n, nil
+}
+
+// fileSource is where the files are downloaded from.
+type fileSource interface {
+	// open returns the bytes [from, to) of the file, to the end of it if to is -1.
+	// The whole file is returned, if ranged is false.
+	open(ctx context.Context, from, to int64) (r io.ReadCloser, ranged bool, err error)
+}
+
+// httpSource downloads the file from the Bot API server.
+type httpSource struct {
+	b   *Bot
+	url string
+}
+
+func (s httpSource) open(ctx context.Context, from, to int64) (io.ReadCloser, bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url, nil)
+	if err != nil {
+		return nil, false, wrapError(s.b.redact(err))
+	}
+	if from > 0 || to >= 0 {
+		rng := "bytes=" + strconv.FormatInt(from, 10) + "-"
+		if to >= 0 {
+			rng += strconv.FormatInt(to-1, 10)
+		}
+		req.Header.Set("Range", rng)
+	}
+
+	resp, err := s.b.client.Do(req)
+	if err != nil {
+		return nil, false, wrapError(s.b.redact(err))
+	}
+
+	switch resp.StatusCode {
+	case http.StatusPartialContent:
+		return resp.Body, true, nil
+	case http.StatusRequestedRangeNotSatisfiable:
+		// nothing is left after from
+		drain(resp.Body)
+		return http.NoBody, true, nil
+	case http.StatusOK:
+		return resp.Body, false, nil
+	default:
+		drain(resp.Body)
+		return nil, false, NewError(resp.StatusCode, "telebot: unexpected status of the file: "+resp.Status)
+	}
+}
+
+// diskSource copies the file from the directory of the local Bot API server.
+type diskSource string
+
+func (s diskSource) open(ctx context.Context, from, to int64) (io.ReadCloser, bool, error) {
+	f, err := os.Open(string(s))
+	if err != nil {
+		return nil, false, wrapError(err)
+	}
+	if to < 0 {
+		if to, err = f.Seek(0, io.SeekEnd); err != nil {
+			f.Close()
+			return nil, false, wrapError(err)
+		}
+	}
+	return struct {
+		io.Reader
+		io.Closer
+	}{io.NewSectionReader(f, from, to-from), f}, true, nil
+}
+
+// digest returns the SHA-256 digest of the file.
+func digest(path string) ([]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return nil, wrapError(err)
+	}
+	return h.Sum(nil), nil
+}
@@ -0,0 +1,166 @@
+package telebot
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDownload(t *testing.T) {
+	content := bytes.Repeat([]byte("0123456789"), 10<<10)
+	sum := sha256.Sum256(content)
+
+	var (
+		mu     sync.Mutex
+		ranges []string
+		drop   bool // the next download is cut in the middle
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/getFile") {
+			fmt.Fprintf(w, `{"ok":true,"result":{"file_id":"id","file_path":"videos/a.mp4","file_size":%d}}`, len(content))
+			return
+		}
+
+		mu.Lock()
+		ranges = append(ranges, r.Header.Get("Range"))
+		cut := drop
+		drop = false
+		mu.Unlock()
+
+		if cut {
+			w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+			w.Write(content[:len(content)/2])
+			panic(http.ErrAbortHandler)
+		}
+		http.ServeContent(w, r, "", time.Time{}, bytes.NewReader(content))
+	}))
+	defer srv.Close()
+
+	b, err := NewBot(Settings{URL: srv.URL, Offline: true, RetryPolicy: &Backoff{Retries: 2}})
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	ctx := context.Background()
+	reset := func(cut bool) {
+		mu.Lock()
+		defer mu.Unlock()
+		ranges, drop = nil, cut
+	}
+
+	t.Run("resumed", func(t *testing.T) {
+		reset(true)
+		dst := filepath.Join(dir, "resumed.mp4")
+
+		file := &File{FileID: "id"}
+		got, err := b.DownloadContext(ctx, file, dst, &DownloadOptions{SHA256: true})
+		require.NoError(t, err)
+		assert.Equal(t, int64(len(content)), got.Size)
+		assert.Equal(t, sum[:], got.SHA256)
+		assert.Equal(t, dst, file.FileLocal)
+
+		data, _ := os.ReadFile(dst)
+		assert.Equal(t, content, data)
+		assert.Equal(t, []string{"", "bytes=" + strconv.Itoa(len(content)/2) + "-"}, ranges)
+		assert.NoFileExists(t, dst+PartSuffix)
+	})
+
+	t.Run("default policy", func(t *testing.T) {
+		reset(true)
+		dst := filepath.Join(dir, "default.mp4")
+
+		// unlike the other requests, the downloads are resumed by default
+		b, err := NewBot(Settings{URL: srv.URL, Offline: true})
+		require.NoError(t, err)
+
+		got, err := b.DownloadContext(ctx, &File{FileID: "id"}, dst, &DownloadOptions{SHA256: true})
+		require.NoError(t, err)
+		assert.Equal(t, sum[:], got.SHA256)
+		assert.Len(t, ranges, 2)
+	})
+
+	t.Run("partial file", func(t *testing.T) {
+		reset(false)
+		dst := filepath.Join(dir, "partial.mp4")
+		require.NoError(t, os.WriteFile(dst+PartSuffix, content[:1000], 0o644))
+
+		_, err := b.DownloadContext(ctx, &File{FileID: "id"}, dst, &DownloadOptions{Resume: true})
+		require.NoError(t, err)
+
+		data, _ := os.ReadFile(dst)
+		assert.Equal(t, content, data)
+		assert.Equal(t, []string{"bytes=1000-"}, ranges)
+	})
+
+	t.Run("chunks", func(t *testing.T) {
+		reset(false)
+		dst := filepath.Join(dir, "chunks.mp4")
+
+		got, err := b.DownloadContext(ctx, &File{FileID: "id"}, dst, &DownloadOptions{Chunks: 4, SHA256: true})
+		require.NoError(t, err)
+		assert.Equal(t, sum[:], got.SHA256)
+		assert.Len(t, ranges, 4)
+		assert.Contains(t, ranges, "bytes=0-25599")
+	})
+
+	t.Run("size mismatch", func(t *testing.T) {
+		reset(false)
+		dst := filepath.Join(dir, "mismatch.mp4")
+
+		file := &File{FileID: "id", FilePath: "videos/a.mp4", FileSize: int64(len(content)) + 1}
+		_, err := b.DownloadContext(ctx, file, dst, nil)
+		assert.ErrorIs(t, err, ErrSizeMismatch)
+		assert.NoFileExists(t, dst)
+		assert.NoFileExists(t, dst+PartSuffix)
+	})
+}
+
+func TestDownloadLocal(t *testing.T) {
+	content := bytes.Repeat([]byte("0123456789"), 1000)
+	dir := t.TempDir()
+	src := filepath.Join(dir, "server", "a.mp4")
+	require.NoError(t, os.MkdirAll(filepath.Dir(src), 0o755))
+	require.NoError(t, os.WriteFile(src, content, 0o644))
+
+	b, err := NewBot(Settings{Offline: true, Local: LocalCopying()})
+	require.NoError(t, err)
+
+	dst := filepath.Join(dir, "a.mp4")
+	file := &File{FilePath: src, FileSize: int64(len(content))}
+	_, err = b.DownloadContext(context.Background(), file, dst, &DownloadOptions{Chunks: 3})
+	require.NoError(t, err)
+
+	data, _ := os.ReadFile(dst)
+	assert.Equal(t, content, data)
+	// the file of the server is kept
+	assert.FileExists(t, src)
+	assert.Equal(t, src, file.FileLocal)
+
+	// or moved, as Settings.Local does
+	b, err = NewBot(Settings{Offline: true, Local: LocalMoving()})
+	require.NoError(t, err)
+
+	dst = filepath.Join(dir, "b.mp4")
+	file = &File{FilePath: src, FileSize: int64(len(content))}
+	got, err := b.DownloadContext(context.Background(), file, dst, &DownloadOptions{Chunks: 3, SHA256: true})
+	require.NoError(t, err)
+
+	sum := sha256.Sum256(content)
+	assert.Equal(t, sum[:], got.SHA256)
+	assert.Equal(t, int64(len(content)), got.Size)
+	assert.Equal(t, dst, file.FileLocal)
+	assert.NoFileExists(t, src)
+	assert.NoFileExists(t, dst+PartSuffix)
+}
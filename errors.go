@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log"
 	"strings"
+	"time"
 )
 
 type (
@@ -50,6 +51,11 @@ func (err FloodError) Error() string {
 	return err.err.Error()
 }
 
+// RetryIn returns RetryAfter as a duration, implementing scheduler.Flood.
+func (err FloodError) RetryIn() time.Duration {
+	return time.Duration(err.RetryAfter) * time.Second
+}
+
 // Error implements error interface.
 func (err GroupError) Error() string {
 	return err.err.Error()
@@ -136,6 +142,12 @@ var (
 	ErrUserIsDeactivated    = NewError(403, "Forbidden: user is deactivated")
 )
 
+// Conflict errors
+var (
+	ErrTerminatedByOther = NewError(409, "Conflict: terminated by other getUpdates request; make sure that only one bot instance is running")
+	ErrWebhookActive     = NewError(409, "Conflict: can't use getUpdates method while webhook is active; use deleteWebhook to delete the webhook first")
+)
+
 // Err returns Error instance by given description.
 func Err(s string) error {
 	switch s {
@@ -251,6 +263,10 @@ func Err(s string) error {
 		return ErrChannelsTooMuch
 	case ErrChannelsTooMuchUser.ʔ():
 		return ErrChannelsTooMuchUser
+	case ErrTerminatedByOther.ʔ():
+		return ErrTerminatedByOther
+	case ErrWebhookActive.ʔ():
+		return ErrWebhookActive
 	default:
 		return nil
 	}
@@ -273,6 +289,7 @@ func OnErrorLog(logger ...*log.Logger) func(err error, ctx Context) {
 	}
 
 	return func(err error, ctx Context) {
+		err = redactFor(err, ctx)
 		line := []string{fmt.Sprintf("ERROR: %v", err)}
 		if ctx != nil {
 			if chat := ctx.Chat(); chat != nil {
@@ -289,7 +306,7 @@ func OnErrorForward(chatIDs ...int64) func(err error, ctx Context) {
 			return
 		}
 
-		errorMsg := fmt.Sprintf("ERROR: %v", err)
+		errorMsg := fmt.Sprintf("ERROR: %v", redactFor(err, ctx))
 		bot := ctx.Bot()
 		failedMsg := ctx.Message()
 		for _, chatID := range chatIDs {
@@ -302,3 +319,12 @@ func OnErrorForward(chatIDs ...int64) func(err error, ctx Context) {
 		}
 	}
 }
+
+// redactFor masks the token of the bot of the context in err, see Redact.
+func redactFor(err error, ctx Context) error {
+	var token string
+	if ctx != nil && ctx.Bot() != nil {
+		token = ctx.Bot().Token
+	}
+	return Redact(err, token)
+}
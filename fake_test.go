@@ -0,0 +1,33 @@
+package telebot_test
+
+import (
+	"strconv"
+	"testing"
+
+	tele "github.com/graphomania/tg"
+	"github.com/graphomania/tg/scheduler"
+	"github.com/graphomania/tg/tgtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFren(t *testing.T) {
+	srv := tgtest.NewServer()
+	defer srv.Close()
+
+	pref := srv.Settings()
+	pref.Verbose = true
+	pref.Scheduler = scheduler.Default()
+	bot, err := tele.NewBot(pref)
+	require.NoError(t, err)
+
+	chat := &tele.Chat{ID: 1}
+	for i := 0; i < 30; i++ {
+		_, err := bot.Send(chat, strconv.Itoa(i))
+		require.NoError(t, err)
+	}
+
+	sent := srv.Sent()
+	require.Len(t, sent, 30)
+	assert.Equal(t, "29", sent[29].Text)
+}
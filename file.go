@@ -82,3 +82,13 @@ func (f *File) OnDisk() bool {
 	_, err := os.Stat(f.FileLocal)
 	return err == nil
 }
+
+// empty tells whether the file refers to nothing.
+func (f *File) empty() bool {
+	return f.FileID == "" && f.FileURL == "" && f.FileLocal == "" && f.FileReader == nil
+}
+
+// fromReader tells whether the file is going to be uploaded from its FileReader.
+func (f *File) fromReader() bool {
+	return f.FileReader != nil && !f.InCloud() && f.FileURL == "" && !f.OnDisk()
+}
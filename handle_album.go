@@ -43,7 +43,7 @@ func (g *Group) HandleAlbum(handler AlbumHandlerFunc, opts ...interface{}) {
 	if g.b.synchronous {
 		albumHandler = newSyncedManager(g.b, handler, delay)
 	} else {
-		albumHandler = newUnsyncedManager(delay, handler)
+		albumHandler = newUnsyncedManager(g.b, delay, handler)
 	}
 
 	for _, endpoint := range endpoints {
@@ -81,9 +81,7 @@ func newSyncedManager(bot *Bot, fn AlbumHandlerFunc, delay time.Duration) *synce
 }
 
 func (manager *syncedManager) delayHandling(id string) {
-	go func() {
-		time.Sleep(manager.delay)
-
+	manager.bot.clock.AfterFunc(manager.delay, func() {
 		manager.sync.Lock()
 		defer manager.sync.Unlock()
 
@@ -107,7 +105,7 @@ func (manager *syncedManager) delayHandling(id string) {
 
 		manager.current = ""
 		manager.ctx = nil
-	}()
+	})
 }
 
 func (manager *syncedManager) add(ctx Context) (err error) {
@@ -138,14 +136,16 @@ type handleSchedulerUnit struct {
 }
 
 type unsyncedManager struct {
+	bot             *Bot
 	handler         AlbumHandlerFunc
 	delay           time.Duration
 	unscheduled     map[string]handleSchedulerUnit
 	unscheduledSync *sync.Mutex
 }
 
-func newUnsyncedManager(timeout time.Duration, handler AlbumHandlerFunc) *unsyncedManager {
+func newUnsyncedManager(bot *Bot, timeout time.Duration, handler AlbumHandlerFunc) *unsyncedManager {
 	return &unsyncedManager{
+		bot:             bot,
 		handler:         handler,
 		delay:           timeout,
 		unscheduled:     map[string]handleSchedulerUnit{},
@@ -162,7 +162,7 @@ func (handleScheduler *unsyncedManager) add(ctx Context) error {
 		unit.ctx = append(unit.ctx, ctx)
 		unit.delays += 1
 		handleScheduler.unscheduled[id] = unit
-		go time.AfterFunc(handleScheduler.delay, func() { handleScheduler.handle(id) })
+		handleScheduler.bot.clock.AfterFunc(handleScheduler.delay, func() { handleScheduler.handle(id) })
 		return nil
 	}
 
@@ -170,7 +170,7 @@ func (handleScheduler *unsyncedManager) add(ctx Context) error {
 		delays: 1,
 		ctx:    []Context{ctx},
 	}
-	go time.AfterFunc(handleScheduler.delay, func() { handleScheduler.handle(id) })
+	handleScheduler.bot.clock.AfterFunc(handleScheduler.delay, func() { handleScheduler.handle(id) })
 
 	return nil
 }
@@ -0,0 +1,55 @@
+package telebot
+
+import (
+	"testing"
+	"time"
+
+	"github.com/graphomania/tg/clock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleAlbum(t *testing.T) {
+	for _, synchronous := range []bool{true, false} {
+		fake := clock.NewFake(time.Now())
+		b, err := NewBot(Settings{Synchronous: synchronous, Offline: true, Clock: fake})
+		require.NoError(t, err)
+
+		albums := make(chan []int, 2)
+		b.HandleAlbum(func(cs []Context) error {
+			var ids []int
+			for _, c := range cs {
+				ids = append(ids, c.Message().ID)
+			}
+			albums <- ids
+			return nil
+		})
+
+		chat := &Chat{ID: 1}
+		for _, msg := range []*Message{
+			{ID: 1, AlbumID: "a", Chat: chat, Photo: &Photo{}},
+			{ID: 2, AlbumID: "a", Chat: chat, Photo: &Photo{}},
+			{ID: 3, Chat: chat, Photo: &Photo{}},
+		} {
+			b.ProcessUpdate(Update{Message: msg})
+		}
+		if !synchronous {
+			// every message of the album delays its handling
+			fake.BlockUntil(3)
+		}
+
+		fake.Advance(time.Second / 4)
+		if synchronous {
+			// the next album has started, so the previous one is done
+			assert.Equal(t, []int{1, 2}, <-albums)
+		}
+		assert.Empty(t, albums)
+
+		fake.Advance(time.Second / 4)
+		if !synchronous {
+			assert.ElementsMatch(t, [][]int{{1, 2}, {3}}, [][]int{<-albums, <-albums})
+		} else {
+			assert.Equal(t, []int{3}, <-albums)
+		}
+	}
+}
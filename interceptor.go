@@ -0,0 +1,107 @@
+package telebot
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	"github.com/graphomania/tg/clock"
+)
+
+// Call is an outgoing request to Bot API, as the interceptors see it.
+type Call struct {
+	Method string
+
+	// Params is the payload of the request, map[string]string unless
+	// it's been passed to Raw otherwise. It's shared with the caller,
+	// so the interceptors changing it should make a copy.
+	Params interface{}
+
+	// Files are the files uploaded with the request, if any.
+	Files map[string]File
+}
+
+// Invoker sends the call, returning the raw response of the API.
+type Invoker func(ctx context.Context, call Call) ([]byte, error)
+
+// Interceptor represents a middleware of the outgoing requests,
+// which gets called before the call is sent, after it's been scheduled.
+// The retried requests go through the interceptors every time.
+//
+// Example:
+//
+//	func ThreadOf(id int) tele.Interceptor {
+//		return func(next tele.Invoker) tele.Invoker {
+//			return func(ctx context.Context, call tele.Call) ([]byte, error) {
+//				if params, ok := call.Params.(map[string]string); ok && params["message_thread_id"] == "" {
+//					params = maps.Clone(params)
+//					params["message_thread_id"] = strconv.Itoa(id)
+//					call.Params = params
+//				}
+//				return next(ctx, call)
+//			}
+//		}
+//	}
+type Interceptor func(Invoker) Invoker
+
+// Intercept adds the interceptors to the chain of the outgoing requests,
+// the first one being the outermost. It must not be called while the bot is sending.
+func (b *Bot) Intercept(interceptors ...Interceptor) {
+	b.interceptors = append(b.interceptors, interceptors...)
+
+	invoker := b.send
+	for i := len(b.interceptors) - 1; i >= 0; i-- {
+		invoker = b.interceptors[i](invoker)
+	}
+	b.invoker = invoker
+}
+
+func (b *Bot) invoke(ctx context.Context, call Call) ([]byte, error) {
+	return b.invoker(ctx, call)
+}
+
+// Verbose logs the requests sent and the responses got at the info level,
+// by the logger given or slog.Default(), see Settings.Verbose.
+// Anything looking like a token of a bot is masked in the logs.
+// The latencies are measured by the real clock, Settings.Verbose follows the one of the bot.
+func Verbose(logger ...*slog.Logger) Interceptor {
+	l := slog.Default()
+	if len(logger) > 0 {
+		l = logger[0]
+	}
+	return verboseClocked(l, clock.Real())
+}
+
+// verboseClocked is Verbose measuring the latencies by the clock.
+func verboseClocked(l *slog.Logger, clk clock.Clock) Interceptor {
+	return func(next Invoker) Invoker {
+		return func(ctx context.Context, call Call) ([]byte, error) {
+			start := clk.Now()
+			data, err := next(ctx, call)
+			verbose(ctx, l, call, clk.Now().Sub(start), data, err)
+			return data, err
+		}
+	}
+}
+
+func verbose(ctx context.Context, l *slog.Logger, call Call, latency time.Duration, data []byte, err error) {
+	body, _ := json.Marshal(call.Params)
+	body = bytes.ReplaceAll(body, []byte(`\"`), []byte(`"`))
+	body = bytes.ReplaceAll(body, []byte(`"{`), []byte(`{`))
+	body = bytes.ReplaceAll(body, []byte(`}"`), []byte(`}`))
+
+	attrs := []slog.Attr{
+		slog.String(LogMethod, call.Method),
+		slog.Duration(LogLatency, latency),
+		slog.String("params", redact(string(body), "")),
+	}
+	if data != nil {
+		attrs = append(attrs, slog.String("response", redact(string(data), "")))
+	}
+	if err != nil {
+		attrs = append(attrs, slog.Any(LogError, err))
+	}
+	l.LogAttrs(ctx, slog.LevelInfo, "telebot: sent request", attrs...)
+}
@@ -0,0 +1,61 @@
+package telebot
+
+import (
+	"context"
+	"maps"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntercept(t *testing.T) {
+	var (
+		order []string
+		calls []Call
+	)
+	trace := func(name string) Interceptor {
+		return func(next Invoker) Invoker {
+			return func(ctx context.Context, call Call) ([]byte, error) {
+				order = append(order, name)
+				return next(ctx, call)
+			}
+		}
+	}
+
+	b, err := NewBot(Settings{
+		Offline:      true,
+		Interceptors: []Interceptor{trace("first"), trace("second")},
+	})
+	require.NoError(t, err)
+
+	b.Intercept(func(next Invoker) Invoker {
+		return func(ctx context.Context, call Call) ([]byte, error) {
+			params := maps.Clone(call.Params.(map[string]string))
+			params["message_thread_id"] = "7"
+			call.Params = params
+			return next(ctx, call)
+		}
+	}, func(Invoker) Invoker {
+		// the test double answering instead of the API
+		return func(ctx context.Context, call Call) ([]byte, error) {
+			calls = append(calls, call)
+			return []byte(`{"ok":true,"result":{"message_id":42,"chat":{"id":1},"photo":[{"file_id":"photo"}]}}`), nil
+		}
+	})
+
+	msg, err := b.Send(&Chat{ID: 1}, "hi")
+	require.NoError(t, err)
+	assert.Equal(t, 42, msg.ID)
+
+	_, err = b.Send(&Chat{ID: 1}, &Photo{File: FromDisk(testPhoto(t))})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"first", "second", "first", "second"}, order)
+	require.Len(t, calls, 2)
+	assert.Equal(t, "sendMessage", calls[0].Method)
+	assert.Equal(t, "7", calls[0].Params.(map[string]string)["message_thread_id"])
+	assert.Empty(t, calls[0].Files)
+	assert.Equal(t, "sendPhoto", calls[1].Method)
+	assert.Contains(t, calls[1].Files, "photo")
+}
@@ -4,9 +4,10 @@ import (
 	"bytes"
 	"encoding/json"
 	"io/ioutil"
-	"log"
+	"log/slog"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"text/template"
 
 	"github.com/goccy/go-yaml"
@@ -22,6 +23,8 @@ type (
 		ctxs  map[tele.Context]string
 		funcs template.FuncMap
 
+		logger atomic.Pointer[slog.Logger]
+
 		commands map[string]string
 		buttons  map[string]Button
 		markups  map[string]Markup
@@ -119,6 +122,7 @@ var builtinFuncs = template.FuncMap{
 //		parse_mode: (default parse mode)
 //		long_poller: (long poller settings)
 //		webhook: (or webhook settings)
+//		scheduler: (quota profile, see scheduler.Profile)
 //
 // Usage:
 //
@@ -208,13 +212,13 @@ func (lt *Layout) CommandsLocale(locale string, args ...interface{}) (cmds []tel
 	for k, v := range lt.commands {
 		tmpl, err := lt.template(template.New(k).Funcs(lt.funcs), locale).Parse(v)
 		if err != nil {
-			log.Println("telebot/layout:", err)
+			lt.logError(err)
 			return nil
 		}
 
 		var buf bytes.Buffer
 		if err := tmpl.Execute(&buf, arg); err != nil {
-			log.Println("telebot/layout:", err)
+			lt.logError(err)
 			return nil
 		}
 
@@ -262,7 +266,7 @@ func (lt *Layout) TextLocale(locale, k string, args ...interface{}) string {
 
 	var buf bytes.Buffer
 	if err := lt.template(tmpl, locale).ExecuteTemplate(&buf, k, arg); err != nil {
-		log.Println("telebot/layout:", err)
+		lt.logError(err)
 	}
 
 	return buf.String()
@@ -331,24 +335,24 @@ func (lt *Layout) ButtonLocale(locale, k string, args ...interface{}) *tele.Btn
 
 	data, err := yaml.Marshal(btn)
 	if err != nil {
-		log.Println("telebot/layout:", err)
+		lt.logError(err)
 		return nil
 	}
 
 	tmpl, err := lt.template(template.New(k).Funcs(lt.funcs), locale).Parse(string(data))
 	if err != nil {
-		log.Println("telebot/layout:", err)
+		lt.logError(err)
 		return nil
 	}
 
 	var buf bytes.Buffer
 	if err := tmpl.Execute(&buf, arg); err != nil {
-		log.Println("telebot/layout:", err)
+		lt.logError(err)
 		return nil
 	}
 
 	if err := yaml.Unmarshal(buf.Bytes(), &btn); err != nil {
-		log.Println("telebot/layout:", err)
+		lt.logError(err)
 		return nil
 	}
 
@@ -396,13 +400,13 @@ func (lt *Layout) MarkupLocale(locale, k string, args ...interface{}) *tele.Repl
 
 	var buf bytes.Buffer
 	if err := lt.template(markup.keyboard, locale).Execute(&buf, arg); err != nil {
-		log.Println("telebot/layout:", err)
+		lt.logError(err)
 	}
 
 	r := &tele.ReplyMarkup{}
 	if *markup.inline {
 		if err := yaml.Unmarshal(buf.Bytes(), &r.InlineKeyboard); err != nil {
-			log.Println("telebot/layout:", err)
+			lt.logError(err)
 		}
 	} else {
 		r.ResizeKeyboard = markup.ResizeKeyboard == nil || *markup.ResizeKeyboard
@@ -412,7 +416,7 @@ func (lt *Layout) MarkupLocale(locale, k string, args ...interface{}) *tele.Repl
 		r.Selective = markup.Selective
 
 		if err := yaml.Unmarshal(buf.Bytes(), &r.ReplyKeyboard); err != nil {
-			log.Println("telebot/layout:", err)
+			lt.logError(err)
 		}
 	}
 
@@ -467,7 +471,7 @@ func (lt *Layout) ResultLocale(locale, k string, args ...interface{}) tele.Resul
 
 	var buf bytes.Buffer
 	if err := lt.template(result.result, locale).Execute(&buf, arg); err != nil {
-		log.Println("telebot/layout:", err)
+		lt.logError(err)
 	}
 
 	var (
@@ -477,72 +481,72 @@ func (lt *Layout) ResultLocale(locale, k string, args ...interface{}) tele.Resul
 	)
 
 	if err := yaml.Unmarshal(data, &base); err != nil {
-		log.Println("telebot/layout:", err)
+		lt.logError(err)
 	}
 
 	switch base.Type {
 	case "article":
 		r = &tele.ArticleResult{ResultBase: base.ResultBase}
 		if err := yaml.Unmarshal(data, r); err != nil {
-			log.Println("telebot/layout:", err)
+			lt.logError(err)
 		}
 	case "audio":
 		r = &tele.AudioResult{ResultBase: base.ResultBase}
 		if err := yaml.Unmarshal(data, r); err != nil {
-			log.Println("telebot/layout:", err)
+			lt.logError(err)
 		}
 	case "contact":
 		r = &tele.ContactResult{ResultBase: base.ResultBase}
 		if err := yaml.Unmarshal(data, r); err != nil {
-			log.Println("telebot/layout:", err)
+			lt.logError(err)
 		}
 	case "document":
 		r = &tele.DocumentResult{ResultBase: base.ResultBase}
 		if err := yaml.Unmarshal(data, r); err != nil {
-			log.Println("telebot/layout:", err)
+			lt.logError(err)
 		}
 	case "gif":
 		r = &tele.GifResult{ResultBase: base.ResultBase}
 		if err := yaml.Unmarshal(data, r); err != nil {
-			log.Println("telebot/layout:", err)
+			lt.logError(err)
 		}
 	case "location":
 		r = &tele.LocationResult{ResultBase: base.ResultBase}
 		if err := json.Unmarshal(data, &r); err != nil {
-			log.Println("telebot/layout:", err)
+			lt.logError(err)
 		}
 	case "mpeg4_gif":
 		r = &tele.Mpeg4GifResult{ResultBase: base.ResultBase}
 		if err := yaml.Unmarshal(data, r); err != nil {
-			log.Println("telebot/layout:", err)
+			lt.logError(err)
 		}
 	case "photo":
 		r = &tele.PhotoResult{ResultBase: base.ResultBase}
 		if err := yaml.Unmarshal(data, r); err != nil {
-			log.Println("telebot/layout:", err)
+			lt.logError(err)
 		}
 	case "venue":
 		r = &tele.VenueResult{ResultBase: base.ResultBase}
 		if err := yaml.Unmarshal(data, r); err != nil {
-			log.Println("telebot/layout:", err)
+			lt.logError(err)
 		}
 	case "video":
 		r = &tele.VideoResult{ResultBase: base.ResultBase}
 		if err := yaml.Unmarshal(data, r); err != nil {
-			log.Println("telebot/layout:", err)
+			lt.logError(err)
 		}
 	case "voice":
 		r = &tele.VoiceResult{ResultBase: base.ResultBase}
 		if err := yaml.Unmarshal(data, r); err != nil {
-			log.Println("telebot/layout:", err)
+			lt.logError(err)
 		}
 	case "sticker":
 		r = &tele.StickerResult{ResultBase: base.ResultBase}
 		if err := yaml.Unmarshal(data, r); err != nil {
-			log.Println("telebot/layout:", err)
+			lt.logError(err)
 		}
 	default:
-		log.Println("telebot/layout: unsupported inline result type")
+		lt.log().Error("telebot/layout: unsupported inline result type", "type", base.Type)
 		return nil
 	}
 
@@ -553,7 +557,7 @@ func (lt *Layout) ResultLocale(locale, k string, args ...interface{}) tele.Resul
 	if result.Markup != "" {
 		markup := lt.MarkupLocale(locale, result.Markup, args...)
 		if markup == nil {
-			log.Printf("telebot/layout: markup with name %s was not found\n", result.Markup)
+			lt.log().Error("telebot/layout: markup is not found", "markup", result.Markup)
 		} else {
 			r.SetReplyMarkup(markup)
 		}
@@ -562,6 +566,23 @@ func (lt *Layout) ResultLocale(locale, k string, args ...interface{}) tele.Resul
 	return r
 }
 
+// SetLogger sets the logger of the errors of the layout. If it's not set, the logger
+// of the bot using the Middleware is, and slog.Default until the middleware runs.
+func (lt *Layout) SetLogger(logger *slog.Logger) {
+	lt.logger.Store(logger)
+}
+
+func (lt *Layout) log() *slog.Logger {
+	if logger := lt.logger.Load(); logger != nil {
+		return logger
+	}
+	return slog.Default()
+}
+
+func (lt *Layout) logError(err error) {
+	lt.log().Error("telebot/layout: error", tele.LogError, err)
+}
+
 func (lt *Layout) template(tmpl *template.Template, locale string) *template.Template {
 	funcs := make(template.FuncMap)
 
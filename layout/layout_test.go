@@ -1,14 +1,45 @@
 package layout
 
 import (
+	"bytes"
+	"log/slog"
 	"os"
 	"testing"
 	"time"
 
+	"github.com/goccy/go-yaml"
 	tele "github.com/graphomania/tg"
+	"github.com/graphomania/tg/scheduler"
 	"github.com/stretchr/testify/assert"
 )
 
+func TestSchedulerProfile(t *testing.T) {
+	var pref Settings
+	err := yaml.Unmarshal([]byte(`
+scheduler:
+  global: { count: 30, period: 1s }
+  rules:
+    - { chats: [ group, channel ], count: 20, period: 1m }
+    - { methods: [ send ], paid: true, shared: true, count: 1000, period: 1s }
+`), &pref)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, &scheduler.Profile{
+		Global: scheduler.Limit{Count: 30, Period: time.Second},
+		Rules: []scheduler.Rule{{
+			Limit: scheduler.Limit{Count: 20, Period: time.Minute},
+			Chats: []scheduler.ChatKind{scheduler.Group, scheduler.Channel},
+		}, {
+			Limit:   scheduler.Limit{Count: 1000, Period: time.Second},
+			Methods: []string{"send"},
+			Paid:    true,
+			Shared:  true,
+		}},
+	}, pref.Scheduler)
+}
+
 func TestLayout(t *testing.T) {
 	os.Setenv("TOKEN", "TEST")
 
@@ -21,6 +52,7 @@ func TestLayout(t *testing.T) {
 	assert.Equal(t, "TEST", pref.Token)
 	assert.Equal(t, "html", pref.ParseMode)
 	assert.Equal(t, &tele.LongPoller{}, pref.Poller)
+	assert.NotNil(t, pref.Scheduler)
 
 	assert.ElementsMatch(t, []tele.Command{{
 		Text:        "start",
@@ -123,3 +155,20 @@ func TestLayout(t *testing.T) {
 		PreviewURL:  "https://preview.picture",
 	}))
 }
+
+func TestLayoutLogger(t *testing.T) {
+	os.Setenv("TOKEN", "TEST")
+
+	lt, err := New("example.yml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	lt.SetLogger(slog.New(slog.NewTextHandler(&buf, nil)))
+
+	assert.Empty(t, lt.TextLocale("en", "missing"))
+	assert.Contains(t, buf.String(), "level=ERROR")
+	assert.Contains(t, buf.String(), `msg="telebot/layout: error"`)
+	assert.Contains(t, buf.String(), "missing")
+}
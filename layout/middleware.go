@@ -32,6 +32,7 @@ func (lt *Layout) Middleware(defaultLocale string, localeFunc ...LocaleFunc) tel
 			}
 
 			lt.SetLocale(c, locale)
+			lt.logger.CompareAndSwap(nil, c.Bot().Logger())
 
 			defer func() {
 				lt.mu.Lock()
@@ -10,6 +10,7 @@ import (
 
 	"github.com/goccy/go-yaml"
 	tele "github.com/graphomania/tg"
+	"github.com/graphomania/tg/scheduler"
 	"github.com/spf13/viper"
 )
 
@@ -24,6 +25,8 @@ type Settings struct {
 
 	Webhook    *tele.Webhook    `yaml:"webhook"`
 	LongPoller *tele.LongPoller `yaml:"long_poller"`
+
+	Scheduler *scheduler.Profile `yaml:"scheduler"`
 }
 
 func (lt *Layout) UnmarshalYAML(data []byte) error {
@@ -65,6 +68,10 @@ func (lt *Layout) UnmarshalYAML(data []byte) error {
 		} else if pref.LongPoller != nil {
 			lt.pref.Poller = pref.LongPoller
 		}
+
+		if pref.Scheduler != nil {
+			lt.pref.Scheduler = scheduler.Profiled(*pref.Scheduler)
+		}
 	}
 
 	lt.buttons = make(map[string]Button, len(aux.Buttons))
@@ -0,0 +1,75 @@
+package telebot
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// The keys of the attributes the bot logs with.
+const (
+	LogUpdateID = "update_id"
+	LogChatID   = "chat_id"
+	LogUserID   = "user_id"
+	LogEndpoint = "endpoint"
+	LogMethod   = "method"
+	LogLatency  = "latency"
+	LogError    = "error"
+)
+
+// LogAttrs returns the attributes describing the update of the context:
+// its ID, the chat and the user of it, and the endpoint handling it, if any.
+func LogAttrs(c Context) []slog.Attr {
+	if c == nil {
+		return nil
+	}
+
+	attrs := []slog.Attr{slog.Int(LogUpdateID, c.Update().ID)}
+	if chat := c.Chat(); chat != nil {
+		attrs = append(attrs, slog.Int64(LogChatID, chat.ID))
+	}
+	if user := c.Sender(); user != nil {
+		attrs = append(attrs, slog.Int64(LogUserID, user.ID))
+	}
+	if end := Endpoint(c); end != "" {
+		attrs = append(attrs, slog.String(LogEndpoint, end))
+	}
+	return attrs
+}
+
+// Endpoint returns the endpoint the handler of the context is registered for,
+// e.g. "/start" or OnText, empty if it's not known.
+func Endpoint(c Context) string {
+	if nc, ok := c.(*nativeContext); ok {
+		nc.lock.RLock()
+		defer nc.lock.RUnlock()
+		return nc.endpoint
+	}
+	return ""
+}
+
+// Logger returns the logger of the bot, see Settings.Logger.
+func (b *Bot) Logger() *slog.Logger {
+	return b.logger
+}
+
+// logError is the default OnError, logging the error with the update it's got on.
+func (b *Bot) logError(err error, c Context) {
+	attrs := append(LogAttrs(c), slog.Any(LogError, err))
+	b.logger.LogAttrs(context.Background(), slog.LevelError, "telebot: error", attrs...)
+}
+
+// logCall logs the request sent to the API at the debug level.
+func (b *Bot) logCall(ctx context.Context, method string, start time.Time, err error) {
+	if !b.logger.Enabled(ctx, slog.LevelDebug) {
+		return
+	}
+	attrs := []slog.Attr{
+		slog.String(LogMethod, method),
+		slog.Duration(LogLatency, b.clock.Now().Sub(start)),
+	}
+	if err != nil {
+		attrs = append(attrs, slog.Any(LogError, err))
+	}
+	b.logger.LogAttrs(ctx, slog.LevelDebug, "telebot: request", attrs...)
+}
@@ -0,0 +1,126 @@
+package telebot
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/graphomania/tg/clock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// records decodes the lines logged by slog.JSONHandler.
+func records(t *testing.T, buf *bytes.Buffer) (recs []map[string]interface{}) {
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var rec map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(line), &rec))
+		recs = append(recs, rec)
+	}
+	return recs
+}
+
+func TestLogger(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"ok":true,"result":{"message_id":1,"chat":{"id":1}}}`))
+	}))
+	defer srv.Close()
+
+	var buf bytes.Buffer
+	b, err := NewBot(Settings{
+		URL:         srv.URL,
+		Offline:     true,
+		Synchronous: true,
+		Logger:      slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})),
+	})
+	require.NoError(t, err)
+
+	b.Handle("/start", func(c Context) error {
+		if _, err := c.Bot().Send(c.Chat(), "hi"); err != nil {
+			return err
+		}
+		return errors.New("failed")
+	})
+	b.ProcessUpdate(Update{ID: 7, Message: &Message{
+		Text:   "/start",
+		Chat:   &Chat{ID: 10},
+		Sender: &User{ID: 20},
+	}})
+
+	recs := records(t, &buf)
+	require.Len(t, recs, 2)
+
+	assert.Equal(t, "DEBUG", recs[0]["level"])
+	assert.Equal(t, "sendMessage", recs[0][LogMethod])
+	assert.Contains(t, recs[0], LogLatency)
+	assert.NotContains(t, recs[0], LogError)
+
+	assert.Equal(t, "ERROR", recs[1]["level"])
+	assert.Equal(t, float64(7), recs[1][LogUpdateID])
+	assert.Equal(t, float64(10), recs[1][LogChatID])
+	assert.Equal(t, float64(20), recs[1][LogUserID])
+	assert.Equal(t, "/start", recs[1][LogEndpoint])
+	assert.Equal(t, "failed", recs[1][LogError])
+}
+
+func TestVerboseLogger(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"ok":true,"result":true}`))
+	}))
+	defer srv.Close()
+
+	var buf bytes.Buffer
+	b, err := NewBot(Settings{
+		URL:     srv.URL,
+		Offline: true,
+		Verbose: true,
+		Logger:  slog.New(slog.NewJSONHandler(&buf, nil)),
+	})
+	require.NoError(t, err)
+
+	// a webhook of the form often having the token in it
+	_, err = b.Raw("setWebhook", map[string]string{"url": "https://example.com/110201543:AAHdqTcvCH1vGWJxfSeofSAs0K5PALDsaw"})
+	require.NoError(t, err)
+
+	recs := records(t, &buf)
+	require.Len(t, recs, 1)
+	assert.Equal(t, "setWebhook", recs[0][LogMethod])
+	assert.Equal(t, `{"url":"https://example.com/<token>"}`, recs[0]["params"])
+	assert.Equal(t, `{"ok":true,"result":true}`, recs[0]["response"])
+}
+
+func TestVerboseClock(t *testing.T) {
+	fake := clock.NewFake(time.Now())
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fake.Advance(2 * time.Second)
+		w.Write([]byte(`{"ok":true,"result":true}`))
+	}))
+	defer srv.Close()
+
+	var buf bytes.Buffer
+	b, err := NewBot(Settings{
+		URL:     srv.URL,
+		Offline: true,
+		Verbose: true,
+		Clock:   fake,
+		Logger:  slog.New(slog.NewJSONHandler(&buf, nil)),
+	})
+	require.NoError(t, err)
+
+	_, err = b.Raw("getMe", nil)
+	require.NoError(t, err)
+
+	// the latency follows the clock of the bot
+	recs := records(t, &buf)
+	require.Len(t, recs, 1)
+	assert.Equal(t, float64(2*time.Second), recs[0][LogLatency])
+}
@@ -9,6 +9,8 @@ import (
 
 // Logger returns a middleware that logs incoming updates.
 // If no custom logger provided, log.Default() will be used.
+//
+// Deprecated: use Slog, which logs the updates structured.
 func Logger(logger ...*log.Logger) tele.MiddlewareFunc {
 	var l *log.Logger
 	if len(logger) > 0 {
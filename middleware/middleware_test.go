@@ -1,6 +1,11 @@
 package middleware
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -28,3 +33,45 @@ func TestRecover(t *testing.T) {
 		Recover(onError)(h)(nil)
 	})
 }
+
+func TestSlog(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	h := Slog(SlogOptions{Logger: logger, Level: slog.LevelDebug, Sample: 5})(func(c tele.Context) error {
+		if c.Update().ID == 3 {
+			return errors.New("failed")
+		}
+		return nil
+	})
+	for id := 1; id <= 10; id++ {
+		h(b.NewContext(tele.Update{ID: id, Message: &tele.Message{Chat: &tele.Chat{ID: 1}}}))
+	}
+
+	var logged []int
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var rec struct {
+			Level    string
+			UpdateID int `json:"update_id"`
+			ChatID   int `json:"chat_id"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(line), &rec))
+		assert.Equal(t, 1, rec.ChatID)
+		if rec.UpdateID == 3 {
+			assert.Equal(t, "ERROR", rec.Level)
+		} else {
+			assert.Equal(t, "DEBUG", rec.Level)
+		}
+		logged = append(logged, rec.UpdateID)
+	}
+	// one of every 5 updates handled, the failed one anyway
+	assert.Equal(t, []int{1, 3, 7}, logged)
+
+	// the error level may be any, info included
+	buf.Reset()
+	h = Slog(SlogOptions{Logger: logger, ErrorLevel: slog.LevelInfo})(func(tele.Context) error {
+		return errors.New("failed")
+	})
+	h(b.NewContext(tele.Update{ID: 1, Message: &tele.Message{Chat: &tele.Chat{ID: 1}}}))
+	assert.Contains(t, buf.String(), `"level":"INFO"`)
+}
@@ -0,0 +1,71 @@
+package middleware
+
+import (
+	"context"
+	"log/slog"
+	"sync/atomic"
+	"time"
+
+	tele "github.com/graphomania/tg"
+)
+
+// SlogOptions are the options of Slog.
+type SlogOptions struct {
+	// Logger is the logger of the updates, the logger of the bot if nil.
+	Logger *slog.Logger
+
+	// Level is the level of the updates handled, slog.LevelInfo by default.
+	Level slog.Level
+
+	// ErrorLevel is the level of the updates the handlers failed on, slog.LevelError if nil.
+	ErrorLevel slog.Leveler
+
+	// Sample logs one of every Sample updates handled, all of them if it's 1 or less.
+	// The failed ones are logged anyway.
+	Sample int
+}
+
+// Slog returns a middleware that logs the updates handled with tele.LogAttrs,
+// the latency of the handler and the error it returned, if any.
+//
+// Example:
+//
+//	b.Use(middleware.Slog(middleware.SlogOptions{
+//		Level:  slog.LevelDebug,
+//		Sample: 100,
+//	}))
+func Slog(opts SlogOptions) tele.MiddlewareFunc {
+	if opts.ErrorLevel == nil {
+		opts.ErrorLevel = slog.LevelError
+	}
+	var handled atomic.Uint64
+
+	return func(next tele.HandlerFunc) tele.HandlerFunc {
+		return func(c tele.Context) error {
+			start := time.Now()
+			err := next(c)
+
+			level := opts.Level
+			if err != nil {
+				level = opts.ErrorLevel.Level()
+			} else if opts.Sample > 1 && handled.Add(1)%uint64(opts.Sample) != 1 {
+				return nil
+			}
+
+			logger := opts.Logger
+			if logger == nil {
+				logger = c.Bot().Logger()
+			}
+			if !logger.Enabled(context.Background(), level) {
+				return err
+			}
+
+			attrs := append(tele.LogAttrs(c), slog.Duration(tele.LogLatency, time.Since(start)))
+			if err != nil {
+				attrs = append(attrs, slog.Any(tele.LogError, err))
+			}
+			logger.LogAttrs(context.Background(), level, "telebot: update", attrs...)
+			return err
+		}
+	}
+}
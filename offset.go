@@ -0,0 +1,110 @@
+package telebot
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// OffsetStore keeps the ID of the last update processed across the restarts, see LongPoller.Store.
+type OffsetStore interface {
+	// Load returns the ID saved, 0 if there's none.
+	Load() (int, error)
+
+	// Save saves the ID of the last update processed.
+	Save(id int) error
+}
+
+// Acker is the poller told about the updates processed by Bot.Start.
+// The pollers wrapping the others should pass the acks on.
+type Acker interface {
+	// Ack is called once Bot.ProcessUpdate has returned for the update.
+	Ack(u Update)
+}
+
+var (
+	_ OffsetStore = (*MemoryOffsetStore)(nil)
+	_ OffsetStore = (*FileOffsetStore)(nil)
+
+	_ Acker = (*LongPoller)(nil)
+	_ Acker = (*MiddlewarePoller)(nil)
+)
+
+// MemoryOffsetStore keeps the offset in memory, e.g. for the tests
+// or for a bot sharing it between the pollers of its runs in a process.
+type MemoryOffsetStore struct {
+	mu sync.Mutex
+	id int
+}
+
+func (s *MemoryOffsetStore) Load() (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.id, nil
+}
+
+func (s *MemoryOffsetStore) Save(id int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.id = id
+	return nil
+}
+
+// FileOffsetStore keeps the offset in the file at Path. The file is replaced
+// atomically on every save, so a crash never leaves it half-written.
+type FileOffsetStore struct {
+	Path string
+}
+
+func (s *FileOffsetStore) Load() (int, error) {
+	data, err := os.ReadFile(s.Path)
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, wrapError(err)
+	}
+
+	id, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil {
+		return 0, fmt.Errorf("telebot: bad offset in %s: %w", s.Path, err)
+	}
+	return id, nil
+}
+
+func (s *FileOffsetStore) Save(id int) error {
+	return writeFileAtomic(s.Path, []byte(strconv.Itoa(id)+"\n"), 0o600)
+}
+
+// writeFileAtomic replaces the file at path with data, so that
+// a crash leaves either the old file or the new one.
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return wrapError(err)
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return wrapError(err)
+	}
+	if err := f.Chmod(perm); err != nil {
+		f.Close()
+		return wrapError(err)
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return wrapError(err)
+	}
+	if err := f.Close(); err != nil {
+		return wrapError(err)
+	}
+	if err := os.Rename(f.Name(), path); err != nil {
+		return wrapError(err)
+	}
+	return nil
+}
@@ -0,0 +1,195 @@
+package telebot
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileOffsetStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "offset")
+	store := &FileOffsetStore{Path: path}
+
+	id, err := store.Load()
+	require.NoError(t, err)
+	assert.Zero(t, id)
+
+	require.NoError(t, store.Save(42))
+	id, err = (&FileOffsetStore{Path: path}).Load()
+	require.NoError(t, err)
+	assert.Equal(t, 42, id)
+
+	// no temporary files left
+	entries, _ := os.ReadDir(filepath.Dir(path))
+	assert.Len(t, entries, 1)
+
+	require.NoError(t, os.WriteFile(path, []byte("garbage"), 0o600))
+	_, err = store.Load()
+	assert.Error(t, err)
+}
+
+// updatesServer serves the batches of updates queued, nothing if none is,
+// recording the offsets requested.
+type updatesServer struct {
+	*httptest.Server
+
+	mu      sync.Mutex
+	batches [][]Update
+	offsets []int
+}
+
+func newUpdatesServer(t *testing.T, batches ...[]Update) *updatesServer {
+	s := &updatesServer{batches: batches}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var params map[string]string
+		json.NewDecoder(r.Body).Decode(&params)
+
+		var offset int
+		json.Unmarshal([]byte(params["offset"]), &offset)
+
+		s.mu.Lock()
+		s.offsets = append(s.offsets, offset)
+		batch := []Update{}
+		if len(s.batches) > 0 {
+			batch, s.batches = s.batches[0], s.batches[1:]
+		}
+		s.mu.Unlock()
+
+		if len(batch) == 0 {
+			time.Sleep(5 * time.Millisecond)
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "result": batch})
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *updatesServer) requested() []int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]int(nil), s.offsets...)
+}
+
+func TestLongPollerStore(t *testing.T) {
+	srv := newUpdatesServer(t, []Update{{ID: 1}, {ID: 2}})
+	b, err := NewBot(Settings{URL: srv.URL, Offline: true})
+	require.NoError(t, err)
+
+	store := &MemoryOffsetStore{}
+	p := &LongPoller{Store: store}
+	dest, stop, done := make(chan Update, 2), make(chan struct{}), make(chan struct{})
+	go func() {
+		p.Poll(b, dest, stop)
+		close(done)
+	}()
+
+	assert.Equal(t, 1, (<-dest).ID)
+	assert.Equal(t, 2, (<-dest).ID)
+
+	// the next updates wait for the ones got to be processed
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(t, []int{1}, srv.requested())
+
+	p.Ack(Update{ID: 1})
+	id, _ := store.Load()
+	assert.Equal(t, 1, id)
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(t, []int{1}, srv.requested())
+
+	p.Ack(Update{ID: 2})
+	id, _ = store.Load()
+	assert.Equal(t, 2, id)
+	require.Eventually(t, func() bool { return len(srv.requested()) > 1 }, time.Second, time.Millisecond)
+	assert.Equal(t, 3, srv.requested()[1])
+
+	close(stop)
+	<-done
+
+	// restarted
+	srv = newUpdatesServer(t)
+	b.URL = srv.URL
+	p = &LongPoller{Store: store}
+	stop, done = make(chan struct{}), make(chan struct{})
+	go func() {
+		p.Poll(b, dest, stop)
+		close(done)
+	}()
+	require.Eventually(t, func() bool { return len(srv.requested()) > 0 }, time.Second, time.Millisecond)
+	assert.Equal(t, 3, srv.requested()[0])
+
+	close(stop)
+	<-done
+}
+
+func TestLongPollerAtMostOnce(t *testing.T) {
+	srv := newUpdatesServer(t, []Update{{ID: 1}, {ID: 2}})
+	b, err := NewBot(Settings{URL: srv.URL, Offline: true})
+	require.NoError(t, err)
+
+	store := &MemoryOffsetStore{}
+	p := &LongPoller{Store: store, AtMostOnce: true}
+	dest, stop, done := make(chan Update), make(chan struct{}), make(chan struct{})
+	go func() {
+		p.Poll(b, dest, stop)
+		close(done)
+	}()
+
+	// committed before it's dispatched
+	assert.Equal(t, 1, (<-dest).ID)
+	id, _ := store.Load()
+	assert.GreaterOrEqual(t, id, 1)
+	assert.Equal(t, 2, (<-dest).ID)
+	id, _ = store.Load()
+	assert.Equal(t, 2, id)
+
+	// not waiting for the acks
+	require.Eventually(t, func() bool { return len(srv.requested()) > 1 }, time.Second, time.Millisecond)
+
+	close(stop)
+	<-done
+}
+
+func TestStartAcks(t *testing.T) {
+	text := func(id int) Update {
+		return Update{ID: id, Message: &Message{Text: "hi", Chat: &Chat{ID: 1}}}
+	}
+	srv := newUpdatesServer(t, []Update{text(1), text(2)}, []Update{text(3)})
+
+	store := &MemoryOffsetStore{}
+	b, err := NewBot(Settings{
+		URL:         srv.URL,
+		Offline:     true,
+		Synchronous: true,
+		Poller: NewMiddlewarePoller(&LongPoller{Store: store}, func(u *Update) bool {
+			return u.ID != 2
+		}),
+	})
+	require.NoError(t, err)
+
+	var handled []int
+	b.Handle(OnText, func(c Context) error {
+		handled = append(handled, c.Update().ID)
+		return nil
+	})
+
+	go b.Start()
+	require.Eventually(t, func() bool {
+		return len(srv.requested()) >= 3
+	}, time.Second, time.Millisecond)
+	b.Stop()
+
+	id, _ := store.Load()
+	assert.Equal(t, 3, id)
+	assert.Equal(t, []int{1, 3}, handled)
+
+	// the update filtered out is committed as well
+	assert.Equal(t, []int{1, 3, 4}, srv.requested()[:3])
+}
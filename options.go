@@ -1,8 +1,11 @@
 package telebot
 
 import (
+	"context"
 	"encoding/json"
 	"strconv"
+
+	"github.com/graphomania/tg/scheduler"
 )
 
 // Option is a shortcut flag type for certain message features
@@ -84,6 +87,21 @@ type SendOptions struct {
 	// HasSpoiler marks the message as containing a spoiler.
 	HasSpoiler bool
 
+	// AllowPaidBroadcast lets the message exceed the free broadcasting limits for a fee,
+	// the scheduler applies the paid limits to it, see scheduler.TelegramProfile.
+	AllowPaidBroadcast bool
+
+	// Priority is the scheduler lane of the request, see scheduler.Priority.
+	Priority scheduler.Priority
+
+	// IdempotencyKey makes the outbox deliver the message once, see WithIdempotencyKey.
+	IdempotencyKey string
+
+	// Progress reports the progress of the upload of the files, see WithProgress.
+	Progress ProgressFunc
+
+	// ctx the request is bound to, see Bot.SendContext.
+	ctx context.Context
 }
 
 func (og *SendOptions) copy() *SendOptions {
@@ -94,6 +112,27 @@ func (og *SendOptions) copy() *SendOptions {
 	return &cp
 }
 
+// context returns the context of the request, carrying its priority and idempotency key.
+func (og *SendOptions) context() context.Context {
+	ctx := context.Background()
+	if og == nil {
+		return ctx
+	}
+	if og.ctx != nil {
+		ctx = og.ctx
+	}
+	if og.Priority != scheduler.Normal {
+		ctx = scheduler.WithPriority(ctx, og.Priority)
+	}
+	if og.IdempotencyKey != "" {
+		ctx = WithIdempotencyKey(ctx, og.IdempotencyKey)
+	}
+	if og.Progress != nil {
+		ctx = WithProgress(ctx, og.Progress)
+	}
+	return ctx
+}
+
 func extractOptions(how []interface{}) *SendOptions {
 	opts := &SendOptions{}
 
@@ -137,6 +176,8 @@ func extractOptions(how []interface{}) *SendOptions {
 			opts.ParseMode = opt
 		case Entities:
 			opts.Entities = opt
+		case scheduler.Priority:
+			opts.Priority = opt
 		default:
 			panic("telebot: unsupported send-option")
 		}
@@ -202,6 +243,10 @@ func (b *Bot) embedSendOptions(params map[string]string, opt *SendOptions) {
 	if opt.HasSpoiler {
 		params["spoiler"] = "true"
 	}
+
+	if opt.AllowPaidBroadcast {
+		params["allow_paid_broadcast"] = "true"
+	}
 }
 
 func processButtons(keys [][]InlineButton) {
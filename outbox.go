@@ -0,0 +1,393 @@
+package telebot
+
+import (
+	"bufio"
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/graphomania/tg/scheduler"
+)
+
+// OutboxKeyTTL is for how long the outbox remembers the delivered idempotency keys.
+const OutboxKeyTTL = 24 * time.Hour
+
+// ErrOutboxReader is returned for the files backed with io.Reader, since the outbox can't persist them.
+var ErrOutboxReader = errors.New("telebot: outbox can't persist a file backed with io.Reader, use FileLocal")
+
+// outboxMethods are the requests going through the outbox.
+var outboxMethods = func() map[string]bool {
+	methods := map[string]bool{"deleteMessage": true}
+	for _, group := range []string{"send", "edit"} {
+		for _, method := range scheduler.MethodGroups[group] {
+			methods[method] = true
+		}
+	}
+	return methods
+}()
+
+// Outbox is a durable on-disk log of the outgoing messages: sends, edits and deletes.
+// A request is written to the log before it is scheduled and acknowledged once it's done,
+// the ones left unacknowledged by a crash are delivered again by the next NewBot.
+//
+// The requests sharing an idempotency key are delivered once, the later ones get the first's
+// result for OutboxKeyTTL, see WithIdempotencyKey. Note, a request interrupted by a crash
+// after it has been sent, but before it has been acknowledged, is still delivered twice.
+//
+// The files are persisted by their paths, so they must outlive the process.
+// The ones backed with io.Reader are rejected with ErrOutboxReader.
+type Outbox struct {
+	mu    sync.Mutex
+	path  string
+	file  *os.File
+	seq   uint64
+	sweep time.Time
+
+	pending map[uint64]*outboxEntry
+	keys    map[string]*outboxEntry
+	unsent  []*outboxEntry // left by the previous run
+}
+
+type outboxEntry struct {
+	ID     uint64                `json:"id"`
+	Key    string                `json:"key,omitempty"`
+	Method string                `json:"method"`
+	Params map[string]string     `json:"params"`
+	Files  map[string]outboxFile `json:"files,omitempty"`
+
+	done   chan struct{}
+	result []byte
+	err    error
+	acked  time.Time
+}
+
+type outboxFile struct {
+	ID    string `json:"id,omitempty"`
+	URL   string `json:"url,omitempty"`
+	Local string `json:"local,omitempty"`
+	Name  string `json:"name,omitempty"`
+}
+
+// outboxRecord is a line of the log.
+type outboxRecord struct {
+	Op     string          `json:"op"` // put, ack or drop
+	At     time.Time       `json:"at"`
+	Entry  *outboxEntry    `json:"entry,omitempty"`
+	ID     uint64          `json:"id,omitempty"`
+	Key    string          `json:"key,omitempty"`
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  string          `json:"error,omitempty"`
+}
+
+// OpenOutbox opens the outbox logged to the file at path, creating it if needed.
+// Pass it to Settings.Outbox.
+func OpenOutbox(path string) (*Outbox, error) {
+	o := &Outbox{
+		path:    path,
+		pending: map[uint64]*outboxEntry{},
+		keys:    map[string]*outboxEntry{},
+	}
+	if err := o.load(); err != nil {
+		return nil, fmt.Errorf("telebot: outbox: %w", err)
+	}
+	if err := o.compact(); err != nil {
+		return nil, fmt.Errorf("telebot: outbox: %w", err)
+	}
+	o.unsent = o.entries()
+	return o, nil
+}
+
+// Close closes the log. The requests in progress stay unacknowledged.
+func (o *Outbox) Close() error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.file.Close()
+}
+
+// Pending returns the number of the unacknowledged requests.
+func (o *Outbox) Pending() int {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return len(o.pending)
+}
+
+// load restores the state from the log.
+func (o *Outbox) load() error {
+	data, err := os.ReadFile(o.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	for line := 1; len(data) > 0; line++ {
+		var raw []byte
+		raw, data, _ = bytes.Cut(data, []byte("\n"))
+
+		var rec outboxRecord
+		if err := json.Unmarshal(raw, &rec); err != nil {
+			if len(data) == 0 {
+				// the last line is incomplete, if the process died writing it
+				break
+			}
+			return fmt.Errorf("line %d: %w", line, err)
+		}
+
+		switch rec.Op {
+		case "put":
+			e := rec.Entry
+			if e == nil {
+				return fmt.Errorf("line %d: put without entry", line)
+			}
+			e.done = make(chan struct{})
+			o.pending[e.ID] = e
+			if e.Key != "" {
+				o.keys[e.Key] = e
+			}
+			o.seq = max(o.seq, e.ID)
+		case "ack":
+			if e, ok := o.pending[rec.ID]; ok && e.Key != "" {
+				delete(o.keys, e.Key)
+			}
+			delete(o.pending, rec.ID)
+			if rec.Key != "" && time.Since(rec.At) < OutboxKeyTTL {
+				e := &outboxEntry{ID: rec.ID, Key: rec.Key, result: rec.Result, acked: rec.At, done: make(chan struct{})}
+				close(e.done)
+				o.keys[rec.Key] = e
+			}
+			o.seq = max(o.seq, rec.ID)
+		case "drop":
+			if e, ok := o.pending[rec.ID]; ok && e.Key != "" {
+				delete(o.keys, e.Key)
+			}
+			delete(o.pending, rec.ID)
+		}
+	}
+	return nil
+}
+
+// compact rewrites the log, keeping the pending requests and the remembered keys only.
+func (o *Outbox) compact() error {
+	tmp, err := os.OpenFile(o.path+".tmp", os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+
+	w := bufio.NewWriter(tmp)
+	enc := json.NewEncoder(w)
+	for _, e := range o.keys {
+		if !e.acked.IsZero() {
+			enc.Encode(outboxRecord{Op: "ack", At: e.acked, ID: e.ID, Key: e.Key, Result: e.result})
+		}
+	}
+	for _, e := range o.entries() {
+		enc.Encode(outboxRecord{Op: "put", At: time.Now(), Entry: e})
+	}
+
+	if err := w.Flush(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), o.path); err != nil {
+		return err
+	}
+
+	o.file, err = os.OpenFile(o.path, os.O_WRONLY|os.O_APPEND, 0o600)
+	return err
+}
+
+// entries returns the pending requests in the order they were put.
+func (o *Outbox) entries() []*outboxEntry {
+	entries := make([]*outboxEntry, 0, len(o.pending))
+	for _, e := range o.pending {
+		entries = append(entries, e)
+	}
+	slices.SortFunc(entries, func(lhs, rhs *outboxEntry) int {
+		return cmp.Compare(lhs.ID, rhs.ID)
+	})
+	return entries
+}
+
+// journals tells whether the method goes through the outbox.
+func (o *Outbox) journals(method string) bool {
+	return o != nil && outboxMethods[method]
+}
+
+// deliver logs the request and sends it, unless the one with the same key
+// has already been delivered or is being delivered now. The failures to log
+// the completion go to Bot.OnError, since the request is done anyway.
+func (o *Outbox) deliver(ctx context.Context, b *Bot, method string, params map[string]string, files map[string]File, send func() ([]byte, error)) ([]byte, error) {
+	if hasReaders(files) {
+		return nil, ErrOutboxReader
+	}
+
+	key := IdempotencyKeyFrom(ctx)
+
+	o.mu.Lock()
+	if e, ok := o.keys[key]; ok && key != "" {
+		o.mu.Unlock()
+
+		select {
+		case <-e.done:
+			return e.result, e.err
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	e := &outboxEntry{
+		ID:     o.seq + 1,
+		Key:    key,
+		Method: method,
+		Params: maps.Clone(params),
+		done:   make(chan struct{}),
+	}
+	for name, f := range files {
+		if e.Files == nil {
+			e.Files = map[string]outboxFile{}
+		}
+		e.Files[name] = outboxFile{ID: f.FileID, URL: f.FileURL, Local: f.FileLocal, Name: f.fileName}
+	}
+
+	err := o.write(outboxRecord{Op: "put", At: time.Now(), Entry: e})
+	if err == nil {
+		o.seq = e.ID
+		o.pending[e.ID] = e
+		if key != "" {
+			o.keys[key] = e
+		}
+	}
+	o.mu.Unlock()
+
+	if err != nil {
+		return nil, fmt.Errorf("telebot: outbox: %w", err)
+	}
+
+	data, err := send()
+	if werr := o.complete(e, data, err); werr != nil {
+		b.OnError(werr, nil)
+	}
+	return data, err
+}
+
+// complete acknowledges the request on success and drops it otherwise,
+// since its caller gets the error. It returns the error of writing it to the log.
+func (o *Outbox) complete(e *outboxEntry, data []byte, err error) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	now := time.Now()
+	delete(o.pending, e.ID)
+	e.result, e.err = data, err
+
+	var rec outboxRecord
+	if err != nil {
+		if o.keys[e.Key] == e {
+			delete(o.keys, e.Key)
+		}
+		rec = outboxRecord{Op: "drop", At: now, ID: e.ID, Error: err.Error()}
+	} else {
+		e.acked = now
+		rec = outboxRecord{Op: "ack", At: now, ID: e.ID}
+		if e.Key != "" {
+			rec.Key, rec.Result = e.Key, data
+		}
+	}
+	werr := o.write(rec)
+	close(e.done)
+
+	if now.After(o.sweep) {
+		for key, e := range o.keys {
+			if !e.acked.IsZero() && now.Sub(e.acked) >= OutboxKeyTTL {
+				delete(o.keys, key)
+			}
+		}
+		o.sweep = now.Add(time.Hour)
+	}
+
+	if werr != nil {
+		// the request is delivered again by the next run
+		return fmt.Errorf("telebot: outbox: logging %s of %s #%d: %w", rec.Op, e.Method, e.ID, werr)
+	}
+	return nil
+}
+
+// write appends the record to the log and flushes it to the disk. Must be called with mu locked.
+func (o *Outbox) write(rec outboxRecord) error {
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	if _, err := o.file.Write(append(data, '\n')); err != nil {
+		return err
+	}
+	return o.file.Sync()
+}
+
+// takeUnsent returns the requests left unacknowledged by the previous run, once.
+func (o *Outbox) takeUnsent() []*outboxEntry {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	unsent := o.unsent
+	o.unsent = nil
+	return unsent
+}
+
+// replayOutbox delivers the requests left unacknowledged by the previous run.
+// The errors are passed to OnError, since there's nobody waiting for them.
+func (b *Bot) replayOutbox(entries []*outboxEntry) {
+	for _, e := range entries {
+		data, err := b.sendEntry(e)
+		if werr := b.outbox.complete(e, data, err); werr != nil {
+			b.OnError(werr, nil)
+		}
+		if err != nil {
+			b.OnError(fmt.Errorf("telebot: outbox: replaying %s #%d: %w", e.Method, e.ID, err), nil)
+		}
+	}
+}
+
+func (b *Bot) sendEntry(e *outboxEntry) ([]byte, error) {
+	ctx := context.Background()
+	params := maps.Clone(e.Params)
+	if len(e.Files) == 0 {
+		return b.rawContext(ctx, e.Method, params)
+	}
+
+	files := make(map[string]File, len(e.Files))
+	for name, f := range e.Files {
+		files[name] = File{FileID: f.ID, FileURL: f.URL, FileLocal: f.Local, fileName: f.Name}
+	}
+	return b.sendFilesRetrying(ctx, e.Method, files, params)
+}
+
+type idempotencyKey struct{}
+
+// WithIdempotencyKey returns a copy of ctx carrying the idempotency key of the request,
+// the outbox delivers the requests sharing a key once, see Outbox.
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKey{}, key)
+}
+
+// IdempotencyKeyFrom returns the idempotency key carried by ctx.
+func IdempotencyKeyFrom(ctx context.Context) string {
+	key, _ := ctx.Value(idempotencyKey{}).(string)
+	return key
+}
@@ -0,0 +1,179 @@
+package telebot
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// outboxServer records the methods called, answering with a message.
+func outboxServer(t *testing.T) (*httptest.Server, func() []string) {
+	var (
+		mu      sync.Mutex
+		methods []string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+
+		mu.Lock()
+		methods = append(methods, method)
+		id := len(methods)
+		mu.Unlock()
+
+		photo := ""
+		if method == "sendPhoto" {
+			// photos are uploaded without a file name, so they are parsed as values
+			if err := r.ParseMultipartForm(1 << 20); err != nil || r.FormValue("photo") != "jpeg" {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"ok":false,"error_code":400,"description":"Bad Request: no photo"}`))
+				return
+			}
+			photo = `,"photo":[{"file_id":"photo"}]`
+		}
+		w.Write([]byte(`{"ok":true,"result":{"message_id":` + strconv.Itoa(id) + `,"chat":{"id":1}` + photo + `}}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), methods...)
+	}
+}
+
+// testPhoto returns the path to a photo, which outlives the process in the tests.
+func testPhoto(t *testing.T) string {
+	path := filepath.Join(t.TempDir(), "photo.jpg")
+	require.NoError(t, os.WriteFile(path, []byte("jpeg"), 0o600))
+	return path
+}
+
+func TestOutbox(t *testing.T) {
+	srv, methods := outboxServer(t)
+	path := filepath.Join(t.TempDir(), "outbox.log")
+
+	outbox, err := OpenOutbox(path)
+	require.NoError(t, err)
+
+	b, err := NewBot(Settings{URL: srv.URL, Offline: true, Outbox: outbox})
+	require.NoError(t, err)
+
+	msg, err := b.Send(&Chat{ID: 1}, "hi")
+	require.NoError(t, err)
+	assert.Equal(t, 1, msg.ID)
+	require.NoError(t, b.Delete(msg))
+
+	// the requests sharing a key are delivered once
+	opts := &SendOptions{IdempotencyKey: "greeting"}
+	first, err := b.Send(&Chat{ID: 1}, "hello", opts)
+	require.NoError(t, err)
+	second, err := b.Send(&Chat{ID: 1}, "hello", opts)
+	require.NoError(t, err)
+	assert.Equal(t, first.ID, second.ID)
+
+	photo := &Photo{File: FromDisk(testPhoto(t))}
+	_, err = b.Send(&Chat{ID: 1}, photo)
+	require.NoError(t, err)
+
+	_, err = b.Send(&Chat{ID: 1}, &Photo{File: FromReader(bytes.NewReader(nil))})
+	assert.ErrorIs(t, err, ErrOutboxReader)
+
+	assert.Equal(t, []string{"sendMessage", "deleteMessage", "sendMessage", "sendPhoto"}, methods())
+	assert.Zero(t, outbox.Pending())
+	require.NoError(t, outbox.Close())
+
+	// the keys survive restarts
+	outbox, err = OpenOutbox(path)
+	require.NoError(t, err)
+	defer outbox.Close()
+
+	b, err = NewBot(Settings{URL: srv.URL, Offline: true, Outbox: outbox})
+	require.NoError(t, err)
+
+	third, err := b.Send(&Chat{ID: 1}, "hello", opts)
+	require.NoError(t, err)
+	assert.Equal(t, first.ID, third.ID)
+	assert.Len(t, methods(), 4)
+}
+
+func TestOutboxReplay(t *testing.T) {
+	srv, methods := outboxServer(t)
+	path := filepath.Join(t.TempDir(), "outbox.log")
+
+	// the process died with two requests waiting, the second put cut short
+	log := strings.ReplaceAll(`{"op":"put","at":"2024-01-01T00:00:00Z","entry":{"id":1,"method":"sendMessage","params":{"chat_id":"1","text":"hi"}}}
+{"op":"put","at":"2024-01-01T00:00:00Z","entry":{"id":2,"method":"sendPhoto","params":{"chat_id":"1"},"files":{"photo":{"local":"PHOTO"}}}}
+{"op":"put","at":"2024-01-01T00:00:00Z","entry":{"id":3,"meth`, "PHOTO", testPhoto(t))
+	require.NoError(t, os.WriteFile(path, []byte(log), 0o600))
+
+	outbox, err := OpenOutbox(path)
+	require.NoError(t, err)
+	defer outbox.Close()
+	assert.Equal(t, 2, outbox.Pending())
+
+	errs := make(chan error, 2)
+	_, err = NewBot(Settings{
+		URL:     srv.URL,
+		Offline: true,
+		Outbox:  outbox,
+		OnError: func(err error, _ Context) { errs <- err },
+	})
+	require.NoError(t, err)
+
+	assert.Eventually(t, func() bool {
+		return outbox.Pending() == 0
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, []string{"sendMessage", "sendPhoto"}, methods())
+	assert.Len(t, errs, 0)
+
+	// nothing is left to be replayed
+	require.NoError(t, outbox.Close())
+	outbox, err = OpenOutbox(path)
+	require.NoError(t, err)
+	defer outbox.Close()
+	assert.Zero(t, outbox.Pending())
+}
+
+func TestOutboxFailures(t *testing.T) {
+	dir := t.TempDir()
+
+	// the put records must carry the request
+	path := filepath.Join(dir, "broken.log")
+	require.NoError(t, os.WriteFile(path, []byte(`{"op":"put","at":"2024-01-01T00:00:00Z"}`+"\n"), 0o600))
+	_, err := OpenOutbox(path)
+	assert.ErrorContains(t, err, "line 1: put without entry")
+
+	outbox, err := OpenOutbox(filepath.Join(dir, "outbox.log"))
+	require.NoError(t, err)
+
+	// the log is gone by the time the request is acknowledged
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		outbox.Close()
+		w.Write([]byte(`{"ok":true,"result":{"message_id":1,"chat":{"id":1}}}`))
+	}))
+	defer srv.Close()
+
+	errs := make(chan error, 1)
+	b, err := NewBot(Settings{
+		URL:     srv.URL,
+		Offline: true,
+		Outbox:  outbox,
+		OnError: func(err error, _ Context) { errs <- err },
+	})
+	require.NoError(t, err)
+
+	_, err = b.Send(&Chat{ID: 1}, "hi")
+	require.NoError(t, err)
+	require.Len(t, errs, 1)
+	assert.ErrorContains(t, <-errs, "telebot: outbox: logging ack of sendMessage #1")
+}
@@ -1,6 +1,13 @@
 package telebot
 
-import "time"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
 
 // Poller is a provider of Updates.
 //
@@ -41,10 +48,140 @@ type LongPoller struct {
 	// 		poll_answer
 	//
 	AllowedUpdates []string `yaml:"allowed_updates"`
+
+	// Backoff is the delay after a failed getUpdates, growing with the failures in a row,
+	// defaulted to DefaultPollBackoff. Its Retries and MaxElapsed are ignored:
+	// the poller never gives up on the transient errors.
+	Backoff *Backoff `yaml:"-"`
+
+	// OnError gets the errors of getUpdates as *PollError, and those of the Store,
+	// defaulted to Bot.OnError.
+	OnError func(error) `yaml:"-"`
+
+	// Store keeps the offset across the restarts, so that the updates are neither lost
+	// nor processed again. The poller starts after the ID it has, if it's above LastUpdateID.
+	//
+	// By default, the update is committed once Bot.ProcessUpdate has returned for it
+	// (at-least-once): the next updates are requested once the ones got are processed.
+	// Note that ProcessUpdate returns before the handlers do, unless the bot is Synchronous.
+	// The bots consuming Bot.Updates themselves must call Ack.
+	Store OffsetStore `yaml:"-"`
+
+	// AtMostOnce commits the updates before they are dispatched, so that the update,
+	// which processing has been cut by a crash, is not processed again.
+	AtMostOnce bool `yaml:"at_most_once"`
+
+	mu        sync.Mutex
+	health    PollerHealth
+	onError   func(error)
+	processed int           // the ID of the last update acked
+	acked     chan struct{} // closed and replaced once an update is acked
+	commitMu  sync.Mutex    // serializes the saves
+	committed int
+}
+
+// DefaultPollBackoff returns the backoff used if LongPoller.Backoff is nil.
+func DefaultPollBackoff() *Backoff {
+	return &Backoff{Initial: time.Second, Max: time.Minute, Jitter: 0.2}
+}
+
+// PollError is the failure of getUpdates the LongPoller reports.
+type PollError struct {
+	Err error
+
+	// Failures is how many times in a row getUpdates has failed.
+	Failures int
+
+	// Fatal tells the poller has stopped polling, see IsFatal.
+	Fatal bool
+
+	// RetryIn is the delay before the poller calls getUpdates again, unless it's fatal.
+	RetryIn time.Duration
+}
+
+func (e *PollError) Error() string {
+	if e.Fatal {
+		return fmt.Sprintf("telebot: poller stopped: %v", e.Err)
+	}
+	return fmt.Sprintf("telebot: getUpdates failed %d time(s) in a row, retrying in %v: %v", e.Failures, e.RetryIn, e.Err)
+}
+
+func (e *PollError) Unwrap() error {
+	return e.Err
 }
 
-// Poll does long polling.
+// IsFatal tells whether the poller can't get the updates, unless someone fixes it:
+// the token is revoked (401), or the updates are taken by a webhook (ErrWebhookActive).
+// The other conflicts, e.g. with another instance of the bot during a deploy, are retried.
+func IsFatal(err error) bool {
+	var apiErr *Error
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	return apiErr.Code == http.StatusUnauthorized || errors.Is(err, ErrWebhookActive)
+}
+
+// PollerHealth is the state of the poller, see LongPoller.Health.
+type PollerHealth struct {
+	// LastSuccess is when getUpdates has last succeeded, zero if it hasn't yet.
+	LastSuccess time.Time
+
+	// Failures is how many times in a row getUpdates has failed since then.
+	Failures int
+
+	// LastError is the last error of getUpdates, nil once it succeeds.
+	LastError error
+
+	// Fatal tells the poller has stopped polling, see IsFatal.
+	Fatal bool
+}
+
+// Healthy tells whether the poller gets the updates: it's not stopped,
+// and it hasn't failed more than maxFailures times in a row.
+func (h PollerHealth) Healthy(maxFailures int) bool {
+	return !h.Fatal && h.Failures <= maxFailures
+}
+
+// Health returns the state of the poller, safe to be called concurrently with Poll,
+// e.g. by the health checks of an orchestrator.
+func (p *LongPoller) Health() PollerHealth {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.health
+}
+
+// Poll does long polling. The failed getUpdates are retried after the backoff,
+// except for the fatal failures (see IsFatal), after which the poller waits for stop.
 func (p *LongPoller) Poll(b *Bot, dest chan Update, stop chan struct{}) {
+	backoff := p.Backoff
+	if backoff == nil {
+		backoff = DefaultPollBackoff()
+	}
+	onError := p.OnError
+	if onError == nil {
+		onError = func(err error) { b.OnError(err, nil) }
+	}
+
+	p.mu.Lock()
+	p.onError = onError
+	p.acked = make(chan struct{})
+	p.mu.Unlock()
+
+	if p.Store != nil {
+		id, err := p.Store.Load()
+		if err != nil {
+			onError(fmt.Errorf("telebot: loading offset: %w", err))
+		} else if id > p.LastUpdateID {
+			p.LastUpdateID = id
+		}
+		p.mu.Lock()
+		p.processed = p.LastUpdateID
+		p.mu.Unlock()
+		p.commitMu.Lock()
+		p.committed = p.LastUpdateID
+		p.commitMu.Unlock()
+	}
+
 	for {
 		select {
 		case <-stop:
@@ -54,15 +191,116 @@ func (p *LongPoller) Poll(b *Bot, dest chan Update, stop chan struct{}) {
 
 		updates, err := b.getUpdates(p.LastUpdateID+1, p.Limit, p.Timeout, p.AllowedUpdates)
 		if err != nil {
-			b.debug(err)
+			if errors.Is(err, context.Canceled) {
+				// cancelled by Bot.Stop
+				<-stop
+				return
+			}
+
+			pollErr := p.fail(b, backoff, err)
+			onError(pollErr)
+
+			if pollErr.Fatal {
+				<-stop
+				return
+			}
+			select {
+			case <-b.clock.After(pollErr.RetryIn):
+			case <-stop:
+				return
+			}
 			continue
 		}
 
+		p.mu.Lock()
+		p.health = PollerHealth{LastSuccess: b.clock.Now()}
+		p.mu.Unlock()
+
 		for _, update := range updates {
+			if p.Store != nil && p.AtMostOnce {
+				p.commit(update.ID)
+			}
 			p.LastUpdateID = update.ID
 			dest <- update
 		}
+
+		if p.Store != nil && !p.AtMostOnce && !p.waitAcks(stop) {
+			return
+		}
+	}
+}
+
+// Ack commits the update processed, see Store.
+func (p *LongPoller) Ack(u Update) {
+	if p.Store == nil || p.AtMostOnce {
+		return
+	}
+	p.commit(u.ID)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if u.ID > p.processed {
+		p.processed = u.ID
+	}
+	if p.acked != nil {
+		close(p.acked)
+		p.acked = make(chan struct{})
+	}
+}
+
+// commit saves the offset, if it's ahead of the one saved.
+func (p *LongPoller) commit(id int) {
+	p.commitMu.Lock()
+	defer p.commitMu.Unlock()
+
+	if id <= p.committed {
+		return
+	}
+	if err := p.Store.Save(id); err != nil {
+		p.mu.Lock()
+		onError := p.onError
+		p.mu.Unlock()
+		if onError != nil {
+			onError(fmt.Errorf("telebot: saving offset: %w", err))
+		}
+		return
 	}
+	p.committed = id
+}
+
+// waitAcks waits for the updates dispatched to be processed. It returns false on stop.
+func (p *LongPoller) waitAcks(stop chan struct{}) bool {
+	for {
+		p.mu.Lock()
+		done := p.processed >= p.LastUpdateID
+		acked := p.acked
+		p.mu.Unlock()
+		if done {
+			return true
+		}
+
+		select {
+		case <-acked:
+		case <-stop:
+			return false
+		}
+	}
+}
+
+// fail records the failure of getUpdates into the health of the poller.
+func (p *LongPoller) fail(b *Bot, backoff *Backoff, err error) *PollError {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.health.Failures++
+	p.health.LastError = err
+	p.health.Fatal = IsFatal(err)
+
+	pollErr := &PollError{Err: err, Failures: p.health.Failures, Fatal: p.health.Fatal}
+	if !pollErr.Fatal {
+		pollErr.RetryIn = backoff.delay(pollErr.Failures)
+	}
+	return pollErr
 }
 
 // MiddlewarePoller is a special kind of poller that acts
@@ -85,6 +323,13 @@ func NewMiddlewarePoller(original Poller, filter func(*Update) bool) *Middleware
 	}
 }
 
+// Ack passes the ack on to the poller wrapped, see Acker.
+func (p *MiddlewarePoller) Ack(u Update) {
+	if acker, ok := p.Poller.(Acker); ok {
+		acker.Ack(u)
+	}
+}
+
 // Poll sieves updates through middleware filter.
 func (p *MiddlewarePoller) Poll(b *Bot, dest chan Update, stop chan struct{}) {
 	if p.Capacity < 1 {
@@ -109,6 +354,9 @@ func (p *MiddlewarePoller) Poll(b *Bot, dest chan Update, stop chan struct{}) {
 		case upd := <-middle:
 			if p.Filter(&upd) {
 				dest <- upd
+			} else {
+				// the update filtered out is done with
+				p.Ack(upd)
 			}
 		}
 	}
@@ -1,9 +1,14 @@
 package telebot
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/graphomania/tg/clock"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 type testPoller struct {
@@ -65,3 +70,92 @@ func TestMiddlewarePoller(t *testing.T) {
 	assert.Contains(t, ids, 1)
 	assert.Contains(t, ids, 2)
 }
+
+func TestLongPollerBackoff(t *testing.T) {
+	responses := make(chan func(w http.ResponseWriter), 10)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		(<-responses)(w)
+	}))
+	defer srv.Close()
+
+	badGateway := func(w http.ResponseWriter) {
+		w.WriteHeader(http.StatusBadGateway)
+		w.Write([]byte("<html>502 Bad Gateway</html>"))
+	}
+	responses <- badGateway
+	responses <- badGateway
+	responses <- func(w http.ResponseWriter) {
+		w.Write([]byte(`{"ok":true,"result":[{"update_id":5}]}`))
+	}
+	responses <- func(w http.ResponseWriter) {
+		w.WriteHeader(http.StatusConflict)
+		w.Write([]byte(`{"ok":false,"error_code":409,"description":"` + ErrWebhookActive.Description + `"}`))
+	}
+
+	fake := clock.NewFake(time.Now())
+	// the retry policy of the bot doesn't apply to the polling
+	b, err := NewBot(Settings{URL: srv.URL, Offline: true, Clock: fake, Retries: 3})
+	require.NoError(t, err)
+
+	errs := make(chan error, 10)
+	p := &LongPoller{
+		Backoff: &Backoff{Initial: time.Second, Max: 3 * time.Second},
+		OnError: func(err error) { errs <- err },
+	}
+	dest, stop, done := make(chan Update, 1), make(chan struct{}), make(chan struct{})
+	go func() {
+		p.Poll(b, dest, stop)
+		close(done)
+	}()
+
+	var pollErr *PollError
+	require.ErrorAs(t, <-errs, &pollErr)
+	assert.Equal(t, 1, pollErr.Failures)
+	assert.Equal(t, time.Second, pollErr.RetryIn)
+	assert.False(t, pollErr.Fatal)
+	assert.Equal(t, 1, p.Health().Failures)
+	assert.False(t, p.Health().Healthy(0))
+
+	// waiting for the backoff
+	fake.BlockUntil(1)
+	fake.Advance(time.Second)
+
+	require.ErrorAs(t, <-errs, &pollErr)
+	assert.Equal(t, 2, pollErr.Failures)
+	assert.Equal(t, 2*time.Second, pollErr.RetryIn)
+	fake.BlockUntil(1)
+	fake.Advance(2 * time.Second)
+
+	assert.Equal(t, 5, (<-dest).ID)
+
+	require.ErrorAs(t, <-errs, &pollErr)
+	assert.True(t, pollErr.Fatal)
+	assert.ErrorIs(t, pollErr, ErrWebhookActive)
+	assert.True(t, IsFatal(pollErr))
+
+	health := p.Health()
+	assert.True(t, health.Fatal)
+	assert.Equal(t, 1, health.Failures)
+	assert.Equal(t, fake.Now(), health.LastSuccess)
+	assert.False(t, health.Healthy(10))
+
+	// no more polling after the fatal error
+	select {
+	case <-done:
+		t.Fatal("poller returned before stop")
+	case <-time.After(10 * time.Millisecond):
+	}
+	close(stop)
+	<-done
+}
+
+func TestIsFatal(t *testing.T) {
+	assert.True(t, IsFatal(ErrUnauthorized))
+	assert.True(t, IsFatal(ErrWebhookActive))
+	assert.True(t, IsFatal(&PollError{Err: ErrWebhookActive}))
+	// another instance of the bot is running for a while, e.g. during a deploy
+	assert.False(t, IsFatal(ErrTerminatedByOther))
+	assert.False(t, IsFatal(NewError(409, "Conflict: something new")))
+	assert.False(t, IsFatal(ErrInternal))
+	assert.False(t, IsFatal(FloodError{err: NewError(429), RetryAfter: 1}))
+}
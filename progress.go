@@ -0,0 +1,204 @@
+package telebot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// Progress is the state of the upload of a file.
+type Progress struct {
+	// Field is the field of the request the file is sent in, e.g. video.
+	Field string
+
+	// Written is how many bytes of the file are uploaded.
+	Written int64
+
+	// Total is the size of the file, -1 if it's unknown,
+	// e.g. for the readers, which can't tell it.
+	Total int64
+}
+
+// Done tells whether the file is uploaded.
+func (p Progress) Done() bool {
+	return p.Total >= 0 && p.Written >= p.Total
+}
+
+// ProgressFunc is called as the files of a request are uploaded, see WithProgress.
+// It's called from the goroutine writing the request, so it must return quickly.
+// Once the request is retried, the progress of its files starts over.
+type ProgressFunc func(Progress)
+
+type progressKey struct{}
+
+// WithProgress returns a copy of ctx carrying the function
+// reporting the progress of the upload of the files of the request.
+// The files got by FileID or FileURL aren't uploaded, so aren't reported.
+func WithProgress(ctx context.Context, fn ProgressFunc) context.Context {
+	return context.WithValue(ctx, progressKey{}, fn)
+}
+
+// ProgressFrom returns the progress function carried by ctx, nil if there's none.
+func ProgressFrom(ctx context.Context) ProgressFunc {
+	fn, _ := ctx.Value(progressKey{}).(ProgressFunc)
+	return fn
+}
+
+// progressReader reports the bytes read from the file.
+type progressReader struct {
+	r        io.Reader
+	fn       ProgressFunc
+	progress Progress
+}
+
+func (pr *progressReader) Read(p []byte) (int, error) {
+	n, err := pr.r.Read(p)
+	if n > 0 {
+		pr.progress.Written += int64(n)
+		pr.fn(pr.progress)
+	}
+	return n, err
+}
+
+// sizeOf returns the bytes left in the reader, -1 if it can't tell.
+func sizeOf(r io.Reader) int64 {
+	switch v := r.(type) {
+	case interface{ Len() int }:
+		return int64(v.Len())
+	case io.Seeker:
+		cur, err := v.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return -1
+		}
+		end, err := v.Seek(0, io.SeekEnd)
+		if err != nil {
+			return -1
+		}
+		if _, err := v.Seek(cur, io.SeekStart); err != nil {
+			return -1
+		}
+		return end - cur
+	}
+	return -1
+}
+
+// sizeOfFile returns the size of the file to be uploaded, a path or a reader, -1 if it can't tell.
+func sizeOfFile(file interface{}) int64 {
+	switch v := file.(type) {
+	case io.Reader:
+		return sizeOf(v)
+	case string:
+		info, err := os.Stat(v)
+		if err != nil {
+			return -1
+		}
+		return info.Size()
+	}
+	return -1
+}
+
+// DefaultProgressFormat is the text of the status message, see Bot.ProgressEditor.
+const DefaultProgressFormat = "Uploading… %d%%"
+
+// ProgressEditor returns the progress function editing msg into the percentage
+// of the files uploaded, formatted by format (DefaultProgressFormat if empty).
+// The message is edited at most once per interval (3 seconds if zero), so that
+// the edits don't flood the chat, but once the upload is done it's edited at once.
+// The edits don't hold the upload up, their errors go to OnError.
+//
+// Example:
+//
+//	status, _ := b.Send(chat, "Uploading…")
+//	b.Send(chat, video, &tele.SendOptions{
+//		Progress: b.ProgressEditor(status, "", 0),
+//	})
+func (b *Bot) ProgressEditor(msg Editable, format string, interval time.Duration) ProgressFunc {
+	if format == "" {
+		format = DefaultProgressFormat
+	}
+	if interval == 0 {
+		interval = 3 * time.Second
+	}
+
+	e := &progressEditor{
+		b:        b,
+		msg:      msg,
+		format:   format,
+		interval: interval,
+		files:    make(map[string]Progress),
+		shown:    -1,
+	}
+	return e.update
+}
+
+type progressEditor struct {
+	b        *Bot
+	msg      Editable
+	format   string
+	interval time.Duration
+
+	mu      sync.Mutex
+	files   map[string]Progress
+	shown   int // the percentage in the message
+	last    time.Time
+	editing bool
+}
+
+func (e *progressEditor) update(p Progress) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.files[p.Field] = p
+	e.edit()
+}
+
+// edit starts editing the message, if it's time to. Must be called with mu locked.
+func (e *progressEditor) edit() {
+	percent := e.percent()
+	if percent == e.shown || e.editing {
+		return
+	}
+	if percent < 100 && e.b.clock.Now().Sub(e.last) < e.interval {
+		return
+	}
+
+	e.editing = true
+	go func() {
+		_, err := e.b.Edit(e.msg, fmt.Sprintf(e.format, percent))
+		if err != nil && !errors.Is(err, ErrSameMessageContent) {
+			e.b.OnError(fmt.Errorf("telebot: editing upload progress: %w", err), nil)
+		}
+
+		e.mu.Lock()
+		defer e.mu.Unlock()
+
+		e.editing = false
+		e.shown = percent
+		e.last = e.b.clock.Now()
+		// the upload might have been done while editing
+		if e.percent() == 100 {
+			e.edit()
+		}
+	}()
+}
+
+// percent returns the percentage of the files uploaded, of those with the size known.
+// Must be called with mu locked.
+func (e *progressEditor) percent() int {
+	var written, total int64
+	for _, p := range e.files {
+		if p.Total < 0 {
+			continue
+		}
+		written += p.Written
+		total += p.Total
+	}
+	if total == 0 {
+		return 0
+	}
+	return int(written * 100 / total)
+}
@@ -0,0 +1,104 @@
+package telebot
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/graphomania/tg/clock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProgress(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+		w.Write([]byte(`{"ok":true,"result":{"message_id":1,"chat":{"id":1}}}`))
+	}))
+	defer srv.Close()
+
+	b, err := NewBot(Settings{URL: srv.URL, Offline: true})
+	require.NoError(t, err)
+
+	var reports []Progress
+	content := bytes.Repeat([]byte("x"), 100<<10)
+	doc := &Document{File: FromReader(bytes.NewReader(content)), FileName: "x.txt"}
+
+	_, err = b.Send(ChatID(1), doc, &SendOptions{Progress: func(p Progress) {
+		reports = append(reports, p)
+	}})
+	require.NoError(t, err)
+
+	require.True(t, len(reports) > 2)
+	assert.Equal(t, Progress{Field: "document", Total: int64(len(content))}, reports[0])
+	for i := 1; i < len(reports); i++ {
+		assert.True(t, reports[i].Written >= reports[i-1].Written)
+	}
+	last := reports[len(reports)-1]
+	assert.True(t, last.Done())
+	assert.Equal(t, int64(len(content)), last.Written)
+
+	// the readers, which can't tell their size
+	reports = nil
+	_, err = b.Send(ChatID(1), &Document{File: FromReader(io.MultiReader(bytes.NewReader(content)))}, &SendOptions{
+		Progress: func(p Progress) { reports = append(reports, p) },
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(-1), reports[len(reports)-1].Total)
+	assert.False(t, reports[len(reports)-1].Done())
+}
+
+func TestProgressEditor(t *testing.T) {
+	var (
+		mu    sync.Mutex
+		edits []string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var params map[string]string
+		json.NewDecoder(r.Body).Decode(&params)
+
+		mu.Lock()
+		edits = append(edits, params["text"])
+		mu.Unlock()
+		w.Write([]byte(`{"ok":true,"result":{"message_id":1,"chat":{"id":1}}}`))
+	}))
+	defer srv.Close()
+
+	fake := clock.NewFake(time.Now())
+	b, err := NewBot(Settings{URL: srv.URL, Offline: true, Clock: fake})
+	require.NoError(t, err)
+
+	progress := b.ProgressEditor(&Message{ID: 1, Chat: &Chat{ID: 1}}, "", 0)
+	edited := func(want ...string) func() bool {
+		return func() bool {
+			mu.Lock()
+			defer mu.Unlock()
+			return assert.ObjectsAreEqual(want, edits)
+		}
+	}
+
+	progress(Progress{Field: "video", Total: 1000})
+	progress(Progress{Field: "thumbnail", Total: 1000})
+	require.Eventually(t, edited("Uploading… 0%"), time.Second, time.Millisecond)
+
+	// too soon
+	progress(Progress{Field: "video", Written: 500, Total: 1000})
+	time.Sleep(10 * time.Millisecond)
+	assert.True(t, edited("Uploading… 0%")())
+
+	fake.Advance(3 * time.Second)
+	require.Eventually(t, func() bool {
+		// reported until the edit before is done
+		progress(Progress{Field: "video", Written: 1000, Total: 1000})
+		return edited("Uploading… 0%", "Uploading… 50%")()
+	}, time.Second, time.Millisecond)
+
+	// the upload done is shown at once
+	progress(Progress{Field: "thumbnail", Written: 1000, Total: 1000})
+	require.Eventually(t, edited("Uploading… 0%", "Uploading… 50%", "Uploading… 100%"), time.Second, time.Millisecond)
+}
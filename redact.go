@@ -0,0 +1,64 @@
+package telebot
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// Redacted replaces the tokens in the errors and the logs.
+const Redacted = "<token>"
+
+// tokenPattern matches the tokens of the bots, e.g. 123456:ABC-DEF1234ghIkl-zyx57W2v1u123ew11.
+var tokenPattern = regexp.MustCompile(`[0-9]{5,}:[A-Za-z0-9_-]{30,}`)
+
+// RedactedError is the error, which message has the token of the bot masked.
+// It unwraps to the original error, so errors.Is and errors.As work as before,
+// but the messages of the errors it wraps may have the token.
+type RedactedError struct {
+	msg string
+	err error
+}
+
+func (e *RedactedError) Error() string {
+	return e.msg
+}
+
+func (e *RedactedError) Unwrap() error {
+	return e.err
+}
+
+// Redact masks the token in the message of the error. The *url.Error, which has
+// the token in its URL, is replaced with the copy of it having the URL masked.
+// Any string looking like a token of a bot is masked as well.
+func Redact(err error, token string) error {
+	if err == nil {
+		return nil
+	}
+	msg := redact(err.Error(), token)
+	if msg == err.Error() {
+		return err
+	}
+
+	if urlErr, ok := err.(*url.Error); ok {
+		return &url.Error{
+			Op:  urlErr.Op,
+			URL: redact(urlErr.URL, token),
+			Err: Redact(urlErr.Err, token),
+		}
+	}
+	return &RedactedError{msg: msg, err: err}
+}
+
+// redact masks the token and anything looking like a token in s.
+func redact(s, token string) string {
+	if token != "" {
+		s = strings.ReplaceAll(s, token, Redacted)
+	}
+	return tokenPattern.ReplaceAllString(s, Redacted)
+}
+
+// redact masks the token of the bot in the error, see Redact.
+func (b *Bot) redact(err error) error {
+	return Redact(err, b.Token)
+}
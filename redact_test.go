@@ -0,0 +1,80 @@
+package telebot
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedact(t *testing.T) {
+	const token = "123456:SECRET"
+
+	urlErr := &url.Error{Op: "Post", URL: "https://api.telegram.org/bot" + token + "/getMe", Err: errors.New("EOF")}
+	err := Redact(wrapError(urlErr), token)
+	assert.NotContains(t, err.Error(), token)
+	assert.Contains(t, err.Error(), "/bot"+Redacted+"/getMe")
+	assert.ErrorIs(t, err, urlErr)
+
+	err = Redact(urlErr, token)
+	require.IsType(t, &url.Error{}, err)
+	assert.Equal(t, "https://api.telegram.org/bot"+Redacted+"/getMe", err.(*url.Error).URL)
+	assert.Equal(t, urlErr.Err, errors.Unwrap(err))
+
+	// the tokens of the other bots
+	err = Redact(errors.New("bot 110201543:AAHdqTcvCH1vGWJxfSeofSAs0K5PALDsaw failed"), token)
+	assert.Equal(t, "bot "+Redacted+" failed", err.Error())
+
+	err = errors.New("no token")
+	assert.Equal(t, err, Redact(err, token))
+	assert.Nil(t, Redact(nil, token))
+}
+
+func TestRedactBot(t *testing.T) {
+	const token = "123456:SECRET"
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	url := srv.URL
+	srv.Close()
+
+	var handled []error
+	b, err := NewBot(Settings{
+		URL:         url,
+		Token:       token,
+		Offline:     true,
+		RetryPolicy: &Backoff{},
+		OnError:     func(err error, c Context) { handled = append(handled, err) },
+	})
+	require.NoError(t, err)
+
+	// the server is down
+	_, err = b.Send(ChatID(1), "hi")
+	require.Error(t, err)
+	assert.NotContains(t, err.Error(), token)
+	assert.True(t, Retryable(err))
+
+	_, err = b.File(&File{FilePath: "a.jpg"})
+	require.Error(t, err)
+	assert.NotContains(t, err.Error(), token)
+
+	b.URL = "http://api\x7f.telegram.org"
+	_, err = b.Send(ChatID(1), "hi")
+	require.Error(t, err)
+	assert.NotContains(t, err.Error(), token)
+
+	b.OnError(fmt.Errorf("failed with %s", token), nil)
+	require.Len(t, handled, 1)
+	assert.NotContains(t, handled[0].Error(), token)
+
+	var buf bytes.Buffer
+	OnErrorLog(log.New(&buf, "", 0))(fmt.Errorf("failed with %s", token), &nativeContext{b: b})
+	assert.NotContains(t, buf.String(), token)
+	assert.Contains(t, buf.String(), Redacted)
+}
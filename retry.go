@@ -0,0 +1,182 @@
+package telebot
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// RetryPolicy decides whether a failed request is sent again, see Settings.RetryPolicy.
+type RetryPolicy interface {
+	// Retry tells how long to wait before sending the request again after its
+	// attempt-th failure (counting from 1) with err, elapsed since it was first sent.
+	// The request fails with err if ok is false.
+	Retry(method string, attempt int, elapsed time.Duration, err error) (wait time.Duration, ok bool)
+}
+
+// DefaultRetryPolicy returns the recommended policy, see Settings.RetryPolicy.
+func DefaultRetryPolicy() *Backoff {
+	return &Backoff{
+		Retries:    3,
+		Initial:    500 * time.Millisecond,
+		Max:        30 * time.Second,
+		Jitter:     0.5,
+		MaxElapsed: 2 * time.Minute,
+	}
+}
+
+// Backoff is the retry policy sending the retryable requests (see Retryable) again
+// after exponentially growing delays, or after the time asked, if they flood.
+// The zero Backoff never retries.
+type Backoff struct {
+	// Retries is how many times a request is sent again at most.
+	Retries int
+
+	// Initial is the delay before the first retry, doubling with every next one up to Max.
+	Initial time.Duration
+	Max     time.Duration
+
+	// Jitter shortens the delays by a random fraction of them up to itself,
+	// so that the requests failed together aren't retried together.
+	Jitter float64
+
+	// MaxElapsed limits the time a request is retried for, including the waits, if positive.
+	MaxElapsed time.Duration
+
+	// FloodsOnly makes it retry the floods only. Unlike the other failures,
+	// they tell for sure the request hasn't been performed, so it's never duplicated.
+	FloodsOnly bool
+}
+
+func (p *Backoff) Retry(method string, attempt int, elapsed time.Duration, err error) (time.Duration, bool) {
+	if attempt > p.Retries || !Retryable(err) {
+		return 0, false
+	}
+
+	var wait time.Duration
+	var flood FloodError
+	if errors.As(err, &flood) {
+		wait = flood.RetryIn()
+	} else if p.FloodsOnly {
+		return 0, false
+	} else {
+		wait = p.delay(attempt)
+	}
+
+	if p.MaxElapsed > 0 && elapsed+wait > p.MaxElapsed {
+		return 0, false
+	}
+	return wait, true
+}
+
+// delay returns the backoff before the attempt-th retry.
+func (p *Backoff) delay(attempt int) time.Duration {
+	d := p.Initial
+	for i := 1; i < attempt && (p.Max <= 0 || d < p.Max); i++ {
+		d *= 2
+	}
+	if p.Max > 0 && d > p.Max {
+		d = p.Max
+	}
+	if p.Jitter > 0 {
+		d -= time.Duration(rand.Float64() * p.Jitter * float64(d))
+	}
+	return d
+}
+
+// Retryable tells whether the request failed with err may succeed, if sent again.
+// The floods, the failures of telegram servers (5xx) and of the network are retryable;
+// the other API errors (4xx) and the cancelled requests are not.
+func Retryable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var flood FloodError
+	if errors.As(err, &flood) {
+		return true
+	}
+	var apiErr *Error
+	if errors.As(err, &apiErr) {
+		return apiErr.Code >= http.StatusInternalServerError
+	}
+
+	// the malformed urls are no failure of the network
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) && urlErr.Op == "parse" {
+		return false
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// retry sends the request until it succeeds or the retry policy gives up.
+// The readers of the files are rewound before sending them again,
+// the requests with the readers, which can't be rewound, aren't retried.
+func (b *Bot) retry(ctx context.Context, method string, files map[string]File, send func() ([]byte, error)) ([]byte, error) {
+	rewind, rewindable := rewinder(files)
+
+	start := b.clock.Now()
+	for attempt := 1; ; attempt++ {
+		data, err := send()
+		if err == nil || !rewindable || ctx.Err() != nil {
+			return data, err
+		}
+
+		wait, ok := b.retryPolicy.Retry(method, attempt, b.clock.Now().Sub(start), err)
+		if !ok {
+			return data, err
+		}
+
+		select {
+		case <-b.clock.After(wait):
+		case <-ctx.Done():
+			return data, err
+		case <-b.stopClient:
+			return data, err
+		}
+
+		if rewind() != nil {
+			return data, err
+		}
+	}
+}
+
+// rewinder returns the function rewinding the readers of the files to where they are now.
+// It tells false, if some of them can't be rewound.
+func rewinder(files map[string]File) (func() error, bool) {
+	type mark struct {
+		seeker io.Seeker
+		offset int64
+	}
+
+	var marks []mark
+	for _, f := range files {
+		if !f.fromReader() {
+			continue
+		}
+		seeker, ok := f.FileReader.(io.Seeker)
+		if !ok {
+			return nil, false
+		}
+		offset, err := seeker.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return nil, false
+		}
+		marks = append(marks, mark{seeker: seeker, offset: offset})
+	}
+
+	return func() error {
+		for _, m := range marks {
+			if _, err := m.seeker.Seek(m.offset, io.SeekStart); err != nil {
+				return err
+			}
+		}
+		return nil
+	}, true
+}
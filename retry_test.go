@@ -0,0 +1,191 @@
+package telebot
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/graphomania/tg/clock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackoff(t *testing.T) {
+	p := &Backoff{Retries: 4, Initial: time.Second, Max: 3 * time.Second, MaxElapsed: time.Minute}
+
+	for attempt, want := range []time.Duration{time.Second, 2 * time.Second, 3 * time.Second, 3 * time.Second} {
+		wait, ok := p.Retry("sendMessage", attempt+1, 0, ErrInternal)
+		assert.True(t, ok)
+		assert.Equal(t, want, wait)
+	}
+	_, ok := p.Retry("sendMessage", 5, 0, ErrInternal)
+	assert.False(t, ok)
+
+	// the floods wait for the time asked, unless it's too long
+	wait, ok := p.Retry("sendMessage", 1, 0, FloodError{err: NewError(429), RetryAfter: 10})
+	assert.True(t, ok)
+	assert.Equal(t, 10*time.Second, wait)
+	_, ok = p.Retry("sendMessage", 1, 55*time.Second, FloodError{err: NewError(429), RetryAfter: 10})
+	assert.False(t, ok)
+
+	_, ok = p.Retry("sendMessage", 1, 0, ErrChatNotFound)
+	assert.False(t, ok)
+
+	p.Jitter = 0.5
+	for i := 0; i < 10; i++ {
+		wait, _ := p.Retry("sendMessage", 1, 0, ErrInternal)
+		assert.True(t, wait > time.Second/2 && wait <= time.Second, wait)
+	}
+
+	_, ok = (&Backoff{}).Retry("sendMessage", 1, 0, ErrInternal)
+	assert.False(t, ok)
+
+	floods := &Backoff{Retries: 1, FloodsOnly: true}
+	_, ok = floods.Retry("sendMessage", 1, 0, ErrInternal)
+	assert.False(t, ok)
+	wait, ok = floods.Retry("sendMessage", 1, 0, FloodError{err: NewError(429), RetryAfter: 1})
+	assert.True(t, ok)
+	assert.Equal(t, time.Second, wait)
+}
+
+func TestRetryable(t *testing.T) {
+	for err, want := range map[error]bool{
+		ErrInternal: true,
+		NewError(http.StatusBadGateway, "Bad Gateway"): true,
+		FloodError{err: NewError(429), RetryAfter: 1}:  true,
+		wrapError(&url.Error{Op: "Post", Err: io.EOF}): true,
+		wrapError(io.ErrUnexpectedEOF):                 true,
+
+		ErrChatNotFound: false,
+		errors.New("telegram: unknown error (400)"):                    false,
+		wrapError(&url.Error{Op: "Post", Err: context.Canceled}):       false,
+		wrapError(&url.Error{Op: "parse", Err: errors.New("bad url")}): false,
+		fmt.Errorf("telebot: %w", context.DeadlineExceeded):            false,
+	} {
+		assert.Equal(t, want, Retryable(err), err)
+	}
+}
+
+func TestRetry(t *testing.T) {
+	var (
+		failures int
+		bodies   []string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var params map[string]string
+		if r.Header.Get("Content-Type") == "application/json" {
+			json.NewDecoder(r.Body).Decode(&params)
+		}
+		bodies = append(bodies, r.FormValue("photo")+params["text"])
+		if failures > 0 {
+			failures--
+			if failures%2 == 0 {
+				w.WriteHeader(http.StatusBadGateway)
+				w.Write([]byte("<html>502 Bad Gateway</html>"))
+			} else {
+				w.WriteHeader(http.StatusInternalServerError)
+				w.Write([]byte(`{"ok":false,"error_code":500,"description":"Internal Server Error"}`))
+			}
+			return
+		}
+		if strings.HasSuffix(r.URL.Path, "/sendMessage") && params["text"] == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"ok":false,"error_code":400,"description":"Bad Request: message text is empty"}`))
+			return
+		}
+		w.Write([]byte(`{"ok":true,"result":{"message_id":1,"chat":{"id":1},"photo":[{"file_id":"photo"}]}}`))
+	}))
+	defer srv.Close()
+
+	fake := clock.NewFake(time.Now())
+	b, err := NewBot(Settings{
+		URL:         srv.URL,
+		Offline:     true,
+		Clock:       fake,
+		RetryPolicy: &Backoff{Retries: 3, Initial: time.Second},
+	})
+	require.NoError(t, err)
+
+	// send runs the request, advancing the clock over the retries expected
+	send := func(retries int, what interface{}) error {
+		done := make(chan error, 1)
+		go func() {
+			_, err := b.Send(&Chat{ID: 1}, what)
+			done <- err
+		}()
+		for i := 0; i < retries; i++ {
+			fake.BlockUntil(1)
+			fake.Advance(time.Minute)
+		}
+		return <-done
+	}
+
+	failures, bodies = 2, nil
+	require.NoError(t, send(2, "hi"))
+	assert.Equal(t, []string{"hi", "hi", "hi"}, bodies)
+
+	// the readers are rewound
+	failures, bodies = 1, nil
+	require.NoError(t, send(1, &Photo{File: FromReader(strings.NewReader("jpeg"))}))
+	assert.Equal(t, []string{"jpeg", "jpeg"}, bodies)
+
+	// or not retried
+	failures, bodies = 1, nil
+	err = send(0, &Photo{File: FromReader(io.MultiReader(bytes.NewReader([]byte("jpeg"))))})
+	assert.Equal(t, NewError(http.StatusBadGateway, "Bad Gateway"), err)
+	assert.Len(t, bodies, 1)
+
+	// the policy gives up
+	failures, bodies = 5, nil
+	assert.Equal(t, ErrInternal, send(3, "hi"))
+	assert.Len(t, bodies, 4)
+
+	// the other API errors are final
+	failures, bodies = 0, nil
+	_, err = b.Raw("sendMessage", map[string]string{"chat_id": "1"})
+	assert.EqualError(t, err, "telegram: Bad Request: message text is empty (400)")
+	assert.Len(t, bodies, 1)
+}
+
+func TestRetryDefault(t *testing.T) {
+	var requests atomic.Int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusBadGateway)
+		w.Write([]byte(`{"ok":false,"error_code":502,"description":"Bad Gateway"}`))
+	}))
+	defer srv.Close()
+
+	// the failures other than floods aren't retried by default
+	b, err := NewBot(Settings{URL: srv.URL, Offline: true})
+	require.NoError(t, err)
+	_, err = b.Send(&Chat{ID: 1}, "hi")
+	assert.Error(t, err)
+	assert.EqualValues(t, 1, requests.Load())
+
+	// unless asked to
+	fake := clock.NewFake(time.Now())
+	b, err = NewBot(Settings{URL: srv.URL, Offline: true, Clock: fake, Retries: 1})
+	require.NoError(t, err)
+
+	requests.Store(0)
+	done := make(chan error, 1)
+	go func() {
+		_, err := b.Send(&Chat{ID: 1}, "hi")
+		done <- err
+	}()
+	fake.BlockUntil(1)
+	fake.Advance(time.Minute)
+	assert.Error(t, <-done)
+	assert.EqualValues(t, 2, requests.Load())
+}
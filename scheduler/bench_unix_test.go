@@ -0,0 +1,53 @@
+//go:build unix
+
+package scheduler
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// cpuTime returns the CPU time the process has used.
+func cpuTime() time.Duration {
+	var usage syscall.Rusage
+	syscall.Getrusage(syscall.RUSAGE_SELF, &usage)
+	return time.Duration(usage.Utime.Nano() + usage.Stime.Nano())
+}
+
+// benchmarkContended keeps lots of requests to distinct chats waiting for the exhausted
+// global quota for a while and reports the CPU time spent meanwhile.
+func benchmarkContended(b *testing.B, newScheduler func() Scheduler) {
+	const (
+		waiters = 1000
+		period  = 500 * time.Millisecond
+	)
+
+	var cpu time.Duration
+	for i := 0; i < b.N; i++ {
+		sch := newScheduler()
+		ctx, cancel := context.WithTimeout(context.Background(), period)
+
+		start := cpuTime()
+		var wg sync.WaitGroup
+		for j := 0; j < waiters; j++ {
+			wg.Add(1)
+			go func(chat string) {
+				defer wg.Done()
+				sch.SyncFuncContext(ctx, 1, chat, func() ([]byte, error) { return nil, nil })
+			}(strconv.Itoa(-j - 1))
+		}
+		wg.Wait()
+		cpu += cpuTime() - start
+		cancel()
+	}
+	b.ReportMetric(float64(cpu.Microseconds())/float64(b.N), "cpu-µs/op")
+}
+
+func BenchmarkContended(b *testing.B) {
+	b.Run("Default", func(b *testing.B) { benchmarkContended(b, Default) })
+	b.Run("DefaultBucket", func(b *testing.B) { benchmarkContended(b, DefaultBucket) })
+}
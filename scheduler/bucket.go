@@ -0,0 +1,454 @@
+package scheduler
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/graphomania/tg/clock"
+)
+
+var (
+	_ Scheduler = &bucket{}
+	_ Observer  = &bucket{}
+	_ Clocked   = &bucket{}
+)
+
+// DefaultBucket is an event-driven alternative to Default with the same telegram API limits.
+func DefaultBucket() Scheduler {
+	return Bucket(ApiRequestQuota, ApiRequestQuotaPerChat)
+}
+
+// Bucket returns an event-driven scheduler, which allows `global` units per second and
+// `perChat` units per minute for every non-personal chat.
+//
+// Unlike Custom, it doesn't poll: for every waiting request it computes the exact moment
+// the quota frees up and sleeps until then. The quota is taken before the request is sent,
+// no lock is held while the request is being performed.
+//
+// Priority lanes are served strictly: a lower lane gets a slot only if no higher one is waiting for it.
+// Within a lane the chats take turns, so the waiting time of a chat below its quota is bounded
+// by the number of the chats waiting, no matter how many requests the others have queued.
+func Bucket(global, perChat int) Scheduler {
+	return Shared(MemoryStore(global, perChat))
+}
+
+// WeightedBucket is a Bucket serving the priority lanes in proportion to their weights,
+// so that bulk sends don't starve completely while there are interactive ones.
+// I.e. WeightedBucket(30, 20, 6, 3, 1) gives 60% of the contended slots to Interactive.
+func WeightedBucket(global, perChat int, interactive, normal, bulk int) Scheduler {
+	return WeightedShared(MemoryStore(global, perChat), interactive, normal, bulk)
+}
+
+// Shared is a Bucket spending the quota kept by the store.
+// Schedulers sharing a store never exceed its limits together, see RemoteStore.
+func Shared(store Store) Scheduler {
+	return newBucket(store)
+}
+
+// WeightedShared is a WeightedBucket spending the quota kept by the store.
+func WeightedShared(store Store, interactive, normal, bulk int) Scheduler {
+	sch := newBucket(store)
+	order := &weighted{}
+	for i, weight := range [...]int{interactive, normal, bulk} {
+		order.weights[i] = max(weight, 1)
+	}
+	sch.order = order
+	return sch
+}
+
+func newBucket(store Store) *bucket {
+	return &bucket{
+		store:    store,
+		clock:    clock.Real(),
+		blocked:  map[string]time.Time{},
+		order:    strict{},
+		inFlight: map[string]int{},
+	}
+}
+
+type bucket struct {
+	mu sync.Mutex
+
+	store Store
+	clock clock.Clock
+	// blocked caches the moments the store expects the quota to free up,
+	// so that the store isn't asked in vain
+	blocked       map[string]time.Time
+	globalBlocked time.Time
+
+	lanes [len(lanes)]queue
+	order ordering
+	timer clock.Timer
+	wake  time.Time
+	sweep time.Time
+
+	// dispatching is set while a dispatch runs, redispatch asks it
+	// to look at the queues again, as they've changed meanwhile
+	dispatching bool
+	redispatch  bool
+
+	hooks    []Hooks
+	inFlight map[string]int
+	waits    [len(reasons)]Histogram
+	requests Histogram
+}
+
+type waiter struct {
+	ctx   context.Context
+	count int
+	chat  string
+	ready chan struct{}
+	err   error
+
+	enqueued   time.Time
+	dispatched time.Time
+	reason     Reason
+}
+
+// event describes the waiter for the hooks.
+func (w *waiter) event() Event {
+	e := Event{
+		Chat:     w.chat,
+		Count:    w.count,
+		Method:   MethodFrom(w.ctx),
+		Priority: PriorityFrom(w.ctx),
+	}
+	if !w.dispatched.IsZero() {
+		e.Reason = w.reason
+		e.Waited = w.dispatched.Sub(w.enqueued)
+	}
+	return e
+}
+
+func (sch *bucket) SyncFunc(count int, chat string, fn RawFunc) ([]byte, error) {
+	return sch.SyncFuncContext(context.Background(), count, chat, fn)
+}
+
+func (sch *bucket) SyncFuncContext(ctx context.Context, count int, chat string, fn RawFunc) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	now := sch.clock.Now()
+	w := &waiter{ctx: ctx, count: count, chat: chat, ready: make(chan struct{}), enqueued: now}
+
+	sch.mu.Lock()
+	hooks := sch.hooks
+	lane := PriorityFrom(ctx).lane()
+	enqueued := sch.enqueue(lane, w)
+	sch.mu.Unlock()
+
+	for _, h := range hooks {
+		if h.OnEnqueue != nil {
+			h.OnEnqueue(enqueued)
+		}
+	}
+
+	select {
+	case <-w.ready:
+	case <-ctx.Done():
+		sch.cancel(lane, w)
+		return nil, ctx.Err()
+	}
+	if w.err != nil {
+		return nil, w.err
+	}
+
+	sch.mu.Lock()
+	sch.inFlight[chat]++
+	dispatched := w.event()
+	sch.mu.Unlock()
+
+	for _, h := range hooks {
+		if h.OnDispatch != nil {
+			h.OnDispatch(dispatched)
+		}
+	}
+
+	start := sch.clock.Now()
+	ret, err := fn()
+	if wait, ok := floodOf(err); ok {
+		sch.flood(ctx, chat, wait)
+	}
+	took := sch.clock.Now().Sub(start)
+
+	sch.mu.Lock()
+	if sch.inFlight[chat]--; sch.inFlight[chat] <= 0 {
+		delete(sch.inFlight, chat)
+	}
+	sch.requests.observe(took)
+	sch.mu.Unlock()
+
+	for _, h := range hooks {
+		if h.OnComplete != nil {
+			e := dispatched
+			e.Took, e.Err = took, err
+			h.OnComplete(e)
+		}
+	}
+	return ret, err
+}
+
+// enqueue puts the waiter into the lane and dispatches it, if the quota allows.
+// It returns the event of the waiter enqueued, since the waiter is changed
+// by the dispatches once mu is unlocked. Must be called with mu locked.
+func (sch *bucket) enqueue(lane int, w *waiter) Event {
+	if at, ok := sch.blocked[w.chat]; ok && at.After(w.enqueued) {
+		w.reason = ChatQuota
+	}
+	sch.lanes[lane].push(w)
+	sch.dispatch(w.enqueued)
+	return w.event()
+}
+
+// flood reports the flood to the store, blocking the chat locally right away.
+func (sch *bucket) flood(ctx context.Context, chat string, wait time.Duration) {
+	sch.mu.Lock()
+	if blocked := sch.clock.Now().Add(wait); chat != "" {
+		sch.block(chat, blocked)
+	} else if blocked.After(sch.globalBlocked) {
+		sch.globalBlocked = blocked
+	}
+	sch.mu.Unlock()
+
+	// the local block is enough to wait out, if the store is unavailable
+	sch.store.Flood(ctx, chat, wait)
+}
+
+// cancel removes the waiter from its lane, unless it's already been dispatched.
+func (sch *bucket) cancel(lane int, w *waiter) {
+	sch.mu.Lock()
+	defer sch.mu.Unlock()
+
+	if sch.lanes[lane].remove(w) {
+		// the waiter could have been blocking the others
+		sch.dispatch(sch.clock.Now())
+	}
+}
+
+// dispatch releases every waiter which fits into the quota and arms the timer
+// for the moment the next one will. Must be called with mu locked.
+//
+// The store may be remote, so mu is unlocked while it's asked. Only one dispatch runs
+// at a time, the ones called meanwhile make it run once more instead.
+func (sch *bucket) dispatch(now time.Time) {
+	if sch.dispatching {
+		sch.redispatch = true
+		return
+	}
+
+	sch.dispatching = true
+	for {
+		sch.redispatch = false
+		sch.release(now)
+		if !sch.redispatch {
+			break
+		}
+		now = sch.clock.Now()
+	}
+	sch.dispatching = false
+}
+
+// release is a pass of dispatch, see it.
+func (sch *bucket) release(now time.Time) {
+	if now.After(sch.sweep) {
+		sch.cleanup(now)
+	}
+	if sch.globalBlocked.After(now) {
+		sch.wakeAt(sch.globalBlocked)
+		return
+	}
+
+	var next time.Time
+	earliest := func(at time.Time) {
+		if next.IsZero() || at.Before(next) {
+			next = at
+		}
+	}
+
+	// the chats blocked by their quota, which can't free up until the next dispatch,
+	// are skipped keeping their turns
+	var skipped [len(lanes)][]string
+	for {
+		var pending [len(lanes)]bool
+		for lane := range sch.lanes {
+			q := &sch.lanes[lane]
+			for w := q.front(); w != nil; w = q.front() {
+				at, ok := sch.blocked[w.chat]
+				if !ok || !at.After(now) {
+					break
+				}
+				earliest(at)
+				skipped[lane] = append(skipped[lane], q.skip())
+			}
+			pending[lane] = q.front() != nil
+		}
+
+		lane, ok := sch.order.pick(pending)
+		if !ok {
+			break
+		}
+
+		w := sch.lanes[lane].front()
+		sch.mu.Unlock()
+		wait, global, err := sch.store.Take(w.ctx, w.count, w.chat)
+		sch.mu.Lock()
+
+		if sch.lanes[lane].front() != w {
+			// cancelled meanwhile, the quota taken for it is lost
+			continue
+		}
+		if err == nil && wait > 0 {
+			if !global {
+				sch.block(w.chat, now.Add(wait))
+				continue
+			}
+			// the global quota is shared, so nobody may overtake
+			sch.globalBlocked = now.Add(wait)
+			earliest(sch.globalBlocked)
+			break
+		}
+
+		sch.lanes[lane].pop()
+		if w.err = err; err == nil {
+			sch.order.served(pending, lane)
+			sch.dispatched(w, now)
+		}
+		close(w.ready)
+	}
+
+	for lane := range sch.lanes {
+		sch.lanes[lane].restore(skipped[lane])
+	}
+	if !next.IsZero() {
+		sch.wakeAt(next)
+	}
+}
+
+// block blocks the chat until the moment, its waiters are marked as waiting for the chat's quota.
+func (sch *bucket) block(chat string, until time.Time) {
+	sch.blocked[chat] = until
+	for _, q := range sch.lanes {
+		for _, w := range q.chats[chat] {
+			w.reason = ChatQuota
+		}
+	}
+}
+
+// dispatched records the waiter's wait.
+func (sch *bucket) dispatched(w *waiter, now time.Time) {
+	w.dispatched = now
+	switch {
+	case now.Equal(w.enqueued):
+		w.reason = Unblocked
+	case w.reason == "":
+		// it wasn't its chat blocking it
+		w.reason = GlobalQuota
+	}
+	sch.waits[slices.Index(reasons[:], w.reason)].observe(now.Sub(w.enqueued))
+}
+
+func (sch *bucket) wakeAt(at time.Time) {
+	if sch.timer == nil {
+		sch.timer = sch.clock.AfterFunc(at.Sub(sch.clock.Now()), sch.onTimer)
+		sch.wake = at
+		return
+	}
+	if !sch.wake.IsZero() && !at.Before(sch.wake) {
+		return
+	}
+	sch.timer.Reset(at.Sub(sch.clock.Now()))
+	sch.wake = at
+}
+
+func (sch *bucket) onTimer() {
+	sch.mu.Lock()
+	defer sch.mu.Unlock()
+
+	sch.wake = time.Time{}
+	sch.dispatch(sch.clock.Now())
+}
+
+// cleanup forgets the chats, which aren't blocked anymore.
+func (sch *bucket) cleanup(now time.Time) {
+	for chat, at := range sch.blocked {
+		if !at.After(now) {
+			delete(sch.blocked, chat)
+		}
+	}
+	sch.sweep = now.Add(ApiRequestQuotaPerChatTimeout)
+}
+
+func (sch *bucket) UseClock(c clock.Clock) {
+	sch.mu.Lock()
+	sch.clock = c
+	sch.mu.Unlock()
+
+	if store, ok := sch.store.(Clocked); ok {
+		store.UseClock(c)
+	}
+}
+
+func (sch *bucket) Observe(hooks Hooks) {
+	sch.mu.Lock()
+	defer sch.mu.Unlock()
+
+	// the slice is shared with the requests in progress
+	sch.hooks = append(slices.Clip(sch.hooks), hooks)
+}
+
+func (sch *bucket) Snapshot() Snapshot {
+	sch.mu.Lock()
+	defer sch.mu.Unlock()
+
+	now := sch.clock.Now()
+	snap := Snapshot{
+		At:       now,
+		Queued:   map[Priority]int{},
+		Chats:    map[string]ChatStats{},
+		Waits:    map[Reason]Histogram{},
+		Requests: sch.requests.copy(),
+	}
+	for i, reason := range reasons {
+		snap.Waits[reason] = sch.waits[i].copy()
+	}
+
+	for i, q := range sch.lanes {
+		snap.Queued[lanes[i]] = q.len
+		for id, waiters := range q.chats {
+			snap.OldestWait = max(snap.OldestWait, now.Sub(waiters[0].enqueued))
+			chat := snap.Chats[id]
+			chat.Queued += len(waiters)
+			snap.Chats[id] = chat
+		}
+	}
+	for id, n := range sch.inFlight {
+		snap.InFlight += n
+		chat := snap.Chats[id]
+		chat.InFlight = n
+		snap.Chats[id] = chat
+	}
+	for id, at := range sch.blocked {
+		if at.After(now) {
+			chat := snap.Chats[id]
+			chat.Blocked = at.Sub(now)
+			snap.Chats[id] = chat
+		}
+	}
+	if sch.globalBlocked.After(now) {
+		snap.GlobalBlocked = sch.globalBlocked.Sub(now)
+	}
+
+	if usage, ok := sch.store.(Usage); ok {
+		global, chats := usage.Usage()
+		snap.GlobalUsed = global
+		for id, used := range chats {
+			chat := snap.Chats[id]
+			chat.Used = used
+			snap.Chats[id] = chat
+		}
+	}
+	return snap
+}
@@ -0,0 +1,327 @@
+package scheduler
+
+import (
+	"context"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// run performs n requests to the chat concurrently and returns the moments they were sent at.
+func run(sch Scheduler, n int, count int, chat string) []time.Time {
+	var (
+		mu    sync.Mutex
+		wg    sync.WaitGroup
+		times []time.Time
+	)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sch.SyncFunc(count, chat, func() ([]byte, error) {
+				mu.Lock()
+				times = append(times, time.Now())
+				mu.Unlock()
+				return nil, nil
+			})
+		}()
+	}
+	wg.Wait()
+	return times
+}
+
+// maxInWindow returns the biggest amount of requests sent within any period.
+func maxInWindow(times []time.Time, period time.Duration) (most int) {
+	for _, lhs := range times {
+		n := 0
+		for _, rhs := range times {
+			if !rhs.Before(lhs) && rhs.Sub(lhs) < period {
+				n++
+			}
+		}
+		most = max(most, n)
+	}
+	return most
+}
+
+// dispatchTimes returns the moments the waiters were dispatched at.
+func dispatchTimes(waiters []*waiter) (times []time.Time) {
+	for _, w := range waiters {
+		if !w.dispatched.IsZero() {
+			times = append(times, w.dispatched)
+		}
+	}
+	return times
+}
+
+func TestBucketGlobal(t *testing.T) {
+	const period = 100 * time.Millisecond
+	sch, clock := fakeBucket(newMemoryStore(3, period, 100, time.Minute))
+	start := clock.Now()
+
+	var waiters []*waiter
+	for i := 0; i < 9; i++ {
+		waiters = append(waiters, enqueue(sch, 1, "1"))
+	}
+	clock.Advance(2 * period)
+
+	times := dispatchTimes(waiters)
+	assert.Len(t, times, 9)
+	assert.Equal(t, start.Add(2*period), times[8])
+	assert.LessOrEqual(t, maxInWindow(times, period), 3)
+}
+
+func TestBucketPerChat(t *testing.T) {
+	const period = 100 * time.Millisecond
+	sch, clock := fakeBucket(newMemoryStore(100, time.Minute, 2, period))
+	start := clock.Now()
+
+	var waiters []*waiter
+	for i := 0; i < 6; i++ {
+		waiters = append(waiters, enqueue(sch, 1, "-100"))
+	}
+	clock.Advance(2 * period)
+
+	times := dispatchTimes(waiters)
+	assert.Len(t, times, 6)
+	assert.Equal(t, start.Add(2*period), times[5])
+	assert.LessOrEqual(t, maxInWindow(times, period), 2)
+
+	// personal chats are limited only by the global quota
+	waiters = waiters[:0]
+	for i := 0; i < 6; i++ {
+		waiters = append(waiters, enqueue(sch, 1, "100"))
+	}
+	for _, w := range waiters {
+		assert.Equal(t, clock.Now(), w.dispatched)
+	}
+}
+
+func TestBucketOversized(t *testing.T) {
+	sch := newBucket(newMemoryStore(2, 50*time.Millisecond, 2, 50*time.Millisecond))
+
+	done := make(chan struct{})
+	go func() {
+		run(sch, 2, 10, "-100")
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("requests bigger than the limit must not block forever")
+	}
+}
+
+func BenchmarkBucket(b *testing.B) {
+	sch := newBucket(newMemoryStore(b.N, time.Second, b.N, time.Minute))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		sch.SyncFunc(1, "-100", func() ([]byte, error) { return nil, nil })
+	}
+}
+
+func TestBucketPriority(t *testing.T) {
+	sch := newBucket(newMemoryStore(1, 50*time.Millisecond, 100, time.Minute))
+
+	var (
+		mu    sync.Mutex
+		order []Priority
+		wg    sync.WaitGroup
+	)
+	send := func(p Priority) {
+		defer wg.Done()
+		sch.SyncFuncContext(WithPriority(context.Background(), p), 1, "1", func() ([]byte, error) {
+			mu.Lock()
+			order = append(order, p)
+			mu.Unlock()
+			return nil, nil
+		})
+	}
+
+	// takes the only slot, so the rest have to queue up
+	sch.SyncFunc(1, "1", func() ([]byte, error) { return nil, nil })
+
+	for _, p := range []Priority{Bulk, Bulk, Normal, Interactive} {
+		wg.Add(1)
+		go send(p)
+		time.Sleep(5 * time.Millisecond)
+	}
+	wg.Wait()
+
+	assert.Equal(t, []Priority{Interactive, Normal, Bulk, Bulk}, order)
+}
+
+func TestWeightedOrdering(t *testing.T) {
+	order := &weighted{weights: [len(lanes)]int{3, 1, 1}}
+	pending := [len(lanes)]bool{true, false, true}
+
+	var served [len(lanes)]int
+	for i := 0; i < 8; i++ {
+		lane, ok := order.pick(pending)
+		assert.True(t, ok)
+		order.served(pending, lane)
+		served[lane]++
+	}
+	assert.Equal(t, [len(lanes)]int{6, 0, 2}, served)
+
+	_, ok := order.pick([len(lanes)]bool{})
+	assert.False(t, ok)
+}
+
+func TestBucketCancel(t *testing.T) {
+	sch := newBucket(newMemoryStore(1, time.Minute, 100, time.Minute))
+	sch.SyncFunc(1, "1", func() ([]byte, error) { return nil, nil })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	sent := false
+	_, err := sch.SyncFuncContext(ctx, 1, "1", func() ([]byte, error) {
+		sent = true
+		return nil, nil
+	})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.False(t, sent)
+
+	sch.mu.Lock()
+	defer sch.mu.Unlock()
+	assert.Zero(t, sch.lanes[Normal.lane()].len)
+	assert.Empty(t, sch.lanes[Normal.lane()].chats)
+	assert.Empty(t, sch.lanes[Normal.lane()].turns)
+}
+
+func TestRemoteStore(t *testing.T) {
+	const period = 100 * time.Millisecond
+	srv := httptest.NewServer(Coordinator(newMemoryStore(100, time.Minute, 2, period)))
+	defer srv.Close()
+
+	// two replicas spending the same budget
+	var (
+		mu    sync.Mutex
+		wg    sync.WaitGroup
+		times []time.Time
+	)
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			replica := Shared(RemoteStore(srv.URL, srv.Client()))
+			sent := run(replica, 3, 1, "-100")
+			mu.Lock()
+			times = append(times, sent...)
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	// the moments are taken after the round trip to the coordinator, so they're a bit off
+	assert.Len(t, times, 6)
+	assert.LessOrEqual(t, maxInWindow(times, period*3/4), 2)
+
+	_, _, err := RemoteStore(srv.URL+"/404", nil).Take(context.Background(), 1, "-100")
+	assert.Error(t, err)
+	assert.Equal(t, RemoteTimeout, RemoteStore(srv.URL, nil).(*remoteStore).client.Timeout)
+}
+
+// slowStore is the store, which Take blocks until release is closed.
+type slowStore struct {
+	Store
+	taking  chan struct{}
+	release chan struct{}
+}
+
+func (s *slowStore) Take(ctx context.Context, count int, chat string) (time.Duration, bool, error) {
+	s.taking <- struct{}{}
+	<-s.release
+	return s.Store.Take(ctx, count, chat)
+}
+
+func TestBucketSlowStore(t *testing.T) {
+	store := &slowStore{
+		Store:   MemoryStore(30, 20),
+		taking:  make(chan struct{}, 10),
+		release: make(chan struct{}),
+	}
+	sch := newBucket(store)
+
+	var wg sync.WaitGroup
+	send := func(chat string) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sch.SyncFunc(1, chat, func() ([]byte, error) { return nil, nil })
+		}()
+	}
+
+	send("1")
+	<-store.taking
+
+	// the scheduler isn't locked while the store is asked
+	snapshot := make(chan Snapshot)
+	go func() { snapshot <- sch.Snapshot() }()
+	select {
+	case snap := <-snapshot:
+		assert.Equal(t, 1, snap.Queued[Normal])
+	case <-time.After(time.Second):
+		t.Fatal("snapshot is blocked by the store")
+	}
+
+	send("2")
+	assert.Eventually(t, func() bool {
+		return sch.Snapshot().Queued[Normal] == 2
+	}, time.Second, time.Millisecond)
+
+	close(store.release)
+	wg.Wait()
+	assert.Zero(t, sch.Snapshot().Queued[Normal])
+}
+
+type floodError time.Duration
+
+func (err floodError) Error() string          { return "flood" }
+func (err floodError) RetryIn() time.Duration { return time.Duration(err) }
+
+func TestBucketFlood(t *testing.T) {
+	store := newMemoryStore(100, time.Minute, 8, time.Minute)
+	sch := newBucket(store)
+
+	_, err := sch.SyncFunc(1, "-100", func() ([]byte, error) {
+		return nil, floodError(50 * time.Millisecond)
+	})
+	assert.Error(t, err)
+
+	// the chat is blocked for the time asked
+	start := time.Now()
+	sch.SyncFunc(1, "-100", func() ([]byte, error) { return nil, nil })
+	assert.GreaterOrEqual(t, time.Since(start), 40*time.Millisecond)
+
+	// and its quota is halved: 2 units are taken already
+	for i := 0; i < 2; i++ {
+		wait, _, _ := store.Take(context.Background(), 1, "-100")
+		assert.Zero(t, wait)
+	}
+	wait, global, _ := store.Take(context.Background(), 1, "-100")
+	assert.NotZero(t, wait)
+	assert.False(t, global)
+
+	// personal chats block only themselves
+	sch.SyncFunc(1, "1", func() ([]byte, error) { return nil, floodError(time.Second) })
+	start = time.Now()
+	sch.SyncFunc(1, "2", func() ([]byte, error) { return nil, nil })
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+	store.Flood(context.Background(), "1", time.Second)
+	wait, global, _ = store.Take(context.Background(), 1, "1")
+	assert.NotZero(t, wait)
+	assert.False(t, global)
+	wait, _, _ = store.Take(context.Background(), 1, "2")
+	assert.Zero(t, wait)
+
+	// the requests to no chat flood the global quota
+	store.Flood(context.Background(), "", time.Second)
+	_, global, _ = store.Take(context.Background(), 1, "-200")
+	assert.True(t, global)
+}
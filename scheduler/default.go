@@ -1,10 +1,13 @@
 package scheduler
 
 import (
+	"context"
 	"slices"
 	"strconv"
 	"sync"
 	"time"
+
+	"github.com/graphomania/tg/clock"
 )
 
 const (
@@ -19,7 +22,10 @@ const (
 	DefaultPollingRate = time.Millisecond * 10
 )
 
-var _ Scheduler = &scheduler{}
+var (
+	_ Scheduler = &scheduler{}
+	_ Clocked   = &scheduler{}
+)
 
 // Conservative gives you a headroom of 25% compared to Default, just in case something goes wrong.
 func Conservative() Scheduler {
@@ -42,9 +48,11 @@ func Custom(global int, perChat int, pollingRate time.Duration) Scheduler {
 		global:       0,
 		perChatLimit: perChat,
 		perChat:      map[string]int{},
+		floods:       map[string]*flood{},
 		sync:         &sync.RWMutex{},
 		events:       []event{},
 		pollingRate:  pollingRate,
+		clock:        clock.Real(),
 	}
 }
 
@@ -55,30 +63,58 @@ type scheduler struct {
 	perChatLimit int
 	perChat      map[string]int
 
+	// floods are the chats told to wait by telegram, see Store.Flood
+	floods        map[string]*flood
+	globalBlocked time.Time
+
 	sync        *sync.RWMutex
 	events      []event
 	pollingRate time.Duration
+	clock       clock.Clock
+}
+
+func (sch *scheduler) UseClock(c clock.Clock) {
+	sch.sync.Lock()
+	defer sch.sync.Unlock()
+	sch.clock = c
 }
 
 func (sch *scheduler) SyncFunc(count int, chat string, fn RawFunc) (ret []byte, err error) {
+	return sch.SyncFuncContext(context.Background(), count, chat, fn)
+}
+
+func (sch *scheduler) SyncFuncContext(ctx context.Context, count int, chat string, fn RawFunc) (ret []byte, err error) {
 	if sch == nil {
 		ret, err = fn()
 		return
 	}
 
-	ticker := time.NewTicker(sch.pollingRate)
-	defer ticker.Stop()
-	for now := time.Now(); true; now = <-ticker.C {
+	sch.sync.RLock()
+	clock := sch.clock
+	sch.sync.RUnlock()
+
+	for now := clock.Now(); true; {
+		if err = ctx.Err(); err != nil {
+			return
+		}
+
 		sch.sync.Lock()
 		sch.handleEvents(now)
 
-		if !sch.isReadyFor(count, chat) {
+		if !sch.isReadyFor(count, chat, now) {
 			sch.sync.Unlock()
+			select {
+			case now = <-clock.After(sch.pollingRate):
+			case <-ctx.Done():
+			}
 			continue
 		}
 
 		ret, err = fn()
 		sch.add(count, chat)
+		if wait, ok := floodOf(err); ok {
+			sch.flood(chat, wait)
+		}
 
 		sch.sync.Unlock()
 		break
@@ -87,17 +123,49 @@ func (sch *scheduler) SyncFunc(count int, chat string, fn RawFunc) (ret []byte,
 	return
 }
 
-func (sch *scheduler) isReadyFor(count int, chat string) bool {
-	if sch.globalLimit < sch.global+count {
+func (sch *scheduler) isReadyFor(count int, chat string, now time.Time) bool {
+	if now.Before(sch.globalBlocked) || sch.globalLimit < sch.global+count {
 		return false
 	}
-	if perChat, contains := sch.perChat[chat]; contains && sch.perChatLimit < perChat+count {
+
+	limit := sch.perChatLimit
+	if f, ok := sch.floods[chat]; ok {
+		if now.Before(f.blocked) {
+			return false
+		}
+		limit = max(limit/f.divisor, 1)
+	}
+	if perChat, contains := sch.perChat[chat]; contains && limit < perChat+count {
 		return false
 	}
 
 	return true
 }
 
+// flood blocks the chat for the time asked by telegram and halves its quota for FloodRelief,
+// as Store.Flood does. The empty chat blocks the global quota.
+func (sch *scheduler) flood(chat string, wait time.Duration) {
+	now := sch.clock.Now()
+	blocked := now.Add(wait)
+	if chat == "" {
+		if blocked.After(sch.globalBlocked) {
+			sch.globalBlocked = blocked
+		}
+		return
+	}
+
+	f, ok := sch.floods[chat]
+	if !ok || !f.relief.After(now) {
+		f = &flood{divisor: 1}
+		sch.floods[chat] = f
+	}
+	if blocked.After(f.blocked) {
+		f.blocked = blocked
+	}
+	f.relief = f.blocked.Add(FloodRelief)
+	f.divisor *= 2
+}
+
 type event struct {
 	time  time.Time
 	count int
@@ -111,7 +179,7 @@ func (sch *scheduler) order() {
 }
 
 func (sch *scheduler) add(count int, chat string) {
-	now := time.Now()
+	now := sch.clock.Now()
 
 	sch.global += count
 	sch.events = append(sch.events, event{
@@ -120,7 +188,7 @@ func (sch *scheduler) add(count int, chat string) {
 		chat:  "",
 	})
 
-	if !isPersonal(chat) {
+	if chat != "" && !isPersonal(chat) {
 		sch.perChat[chat] += count
 		sch.events = append(sch.events, event{
 			time:  now.Add(ApiRequestQuotaPerChatTimeout),
@@ -133,6 +201,11 @@ func (sch *scheduler) add(count int, chat string) {
 }
 
 func (sch *scheduler) handleEvents(now time.Time) {
+	for chat, f := range sch.floods {
+		if !f.relief.After(now) {
+			delete(sch.floods, chat)
+		}
+	}
 	if len(sch.events) == 0 {
 		return
 	}
@@ -145,7 +218,7 @@ func (sch *scheduler) handleEvents(now time.Time) {
 
 		handled += 1
 		sch.global -= event.count
-		if isPersonal(event.chat) {
+		if event.chat == "" || isPersonal(event.chat) {
 			continue
 		}
 
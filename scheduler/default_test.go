@@ -0,0 +1,45 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPollingFlood(t *testing.T) {
+	sch := Custom(100, 4, time.Millisecond).(*scheduler)
+	ok := func() ([]byte, error) { return nil, nil }
+
+	_, err := sch.SyncFunc(1, "-100", func() ([]byte, error) {
+		return nil, floodError(50 * time.Millisecond)
+	})
+	assert.Error(t, err)
+
+	// the chat is blocked for the time asked
+	start := time.Now()
+	sch.SyncFunc(1, "-100", ok)
+	assert.GreaterOrEqual(t, time.Since(start), 40*time.Millisecond)
+
+	// the other chats aren't
+	start = time.Now()
+	sch.SyncFunc(1, "-200", ok)
+	assert.Less(t, time.Since(start), 40*time.Millisecond)
+
+	// and its quota is halved: 2 units are taken already
+	sch.sync.Lock()
+	assert.False(t, sch.isReadyFor(1, "-100", time.Now()))
+	assert.True(t, sch.isReadyFor(1, "-200", time.Now()))
+	sch.sync.Unlock()
+
+	// the requests to no chat flood the global quota
+	sch.SyncFunc(1, "", func() ([]byte, error) { return nil, floodError(50 * time.Millisecond) })
+	start = time.Now()
+	sch.SyncFunc(1, "-300", ok)
+	assert.GreaterOrEqual(t, time.Since(start), 40*time.Millisecond)
+
+	// not taking any chat's quota
+	sch.sync.Lock()
+	assert.NotContains(t, sch.perChat, "")
+	sch.sync.Unlock()
+}
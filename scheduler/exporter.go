@@ -0,0 +1,94 @@
+package scheduler
+
+import (
+	"bufio"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Exporter serves the scheduler's Snapshot in the Prometheus text format.
+// The chats aren't exported one by one, since there may be too many of them.
+//
+// Example:
+//
+//	http.Handle("/metrics", scheduler.Exporter(sch.(scheduler.Observer)))
+func Exporter(sch Observer) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+
+		buf := bufio.NewWriter(w)
+		writeMetrics(buf, sch.Snapshot())
+		buf.Flush()
+	})
+}
+
+func writeMetrics(w *bufio.Writer, snap Snapshot) {
+	gauge := func(name, help string) {
+		fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s gauge\n", name, help, name)
+	}
+
+	gauge("tg_scheduler_queued", "Requests waiting for the quota.")
+	for _, lane := range lanes {
+		fmt.Fprintf(w, "tg_scheduler_queued{lane=%q} %d\n", lane.String(), snap.Queued[lane])
+	}
+
+	gauge("tg_scheduler_in_flight", "Requests being sent.")
+	fmt.Fprintf(w, "tg_scheduler_in_flight %d\n", snap.InFlight)
+
+	gauge("tg_scheduler_oldest_wait_seconds", "For how long the oldest waiting request has been waiting.")
+	fmt.Fprintf(w, "tg_scheduler_oldest_wait_seconds %s\n", seconds(snap.OldestWait))
+
+	gauge("tg_scheduler_global_blocked_seconds", "Time left until the global quota frees up.")
+	fmt.Fprintf(w, "tg_scheduler_global_blocked_seconds %s\n", seconds(snap.GlobalBlocked))
+
+	gauge("tg_scheduler_global_used", "Units of the global quota in use.")
+	fmt.Fprintf(w, "tg_scheduler_global_used %d\n", snap.GlobalUsed)
+
+	var active, blocked int
+	for _, chat := range snap.Chats {
+		if chat.Queued > 0 || chat.InFlight > 0 || chat.Used > 0 {
+			active++
+		}
+		if chat.Blocked > 0 {
+			blocked++
+		}
+	}
+	gauge("tg_scheduler_chats_active", "Chats with requests waiting, in flight or using the quota.")
+	fmt.Fprintf(w, "tg_scheduler_chats_active %d\n", active)
+	gauge("tg_scheduler_chats_blocked", "Chats with the exhausted quota.")
+	fmt.Fprintf(w, "tg_scheduler_chats_blocked %d\n", blocked)
+
+	fmt.Fprint(w, "# HELP tg_scheduler_wait_seconds Time the requests have waited for the quota, by what they have waited on.\n")
+	fmt.Fprint(w, "# TYPE tg_scheduler_wait_seconds histogram\n")
+	for _, reason := range reasons {
+		writeHistogram(w, "tg_scheduler_wait_seconds", fmt.Sprintf("reason=%q,", reason), snap.Waits[reason])
+	}
+
+	fmt.Fprint(w, "# HELP tg_scheduler_request_seconds Time the requests took to be sent.\n")
+	fmt.Fprint(w, "# TYPE tg_scheduler_request_seconds histogram\n")
+	writeHistogram(w, "tg_scheduler_request_seconds", "", snap.Requests)
+}
+
+// writeHistogram writes the histogram, labels must be either empty or end with a comma.
+func writeHistogram(w *bufio.Writer, name, labels string, h Histogram) {
+	h = h.copy()
+
+	var total uint64
+	for i, bound := range h.Bounds {
+		total += h.Counts[i]
+		fmt.Fprintf(w, "%s_bucket{%sle=%q} %d\n", name, labels, seconds(bound), total)
+	}
+	fmt.Fprintf(w, "%s_bucket{%sle=\"+Inf\"} %d\n", name, labels, h.Count)
+
+	if labels != "" {
+		labels = "{" + labels[:len(labels)-1] + "}"
+	}
+	fmt.Fprintf(w, "%s_sum%s %s\n", name, labels, seconds(h.Sum))
+	fmt.Fprintf(w, "%s_count%s %d\n", name, labels, h.Count)
+}
+
+func seconds(d time.Duration) string {
+	return strconv.FormatFloat(d.Seconds(), 'g', -1, 64)
+}
@@ -0,0 +1,166 @@
+package scheduler
+
+import (
+	"time"
+)
+
+// Observer is implemented by the schedulers exposing their state, i.e. Bucket.
+//
+// Example:
+//
+//	sch := scheduler.DefaultBucket()
+//	http.Handle("/metrics", scheduler.Exporter(sch.(scheduler.Observer)))
+type Observer interface {
+	// Snapshot returns the current state of the scheduler.
+	Snapshot() Snapshot
+
+	// Observe adds the hooks, called for every request.
+	Observe(hooks Hooks)
+}
+
+// Usage is implemented by the stores able to report the quota usage, i.e. MemoryStore.
+type Usage interface {
+	// Usage returns the units of the global quota in use and the ones of the chats,
+	// the busiest of the chat's windows counts.
+	Usage() (global int, chats map[string]int)
+}
+
+// Reason is what the request has been waiting on.
+type Reason string
+
+const (
+	// Unblocked requests are dispatched right away.
+	Unblocked Reason = "none"
+
+	// GlobalQuota requests have been waiting for the global quota or the requests
+	// of the higher priority lanes.
+	GlobalQuota Reason = "global"
+
+	// ChatQuota requests have been waiting for the quota of their chat.
+	ChatQuota Reason = "chat"
+)
+
+var reasons = [...]Reason{Unblocked, GlobalQuota, ChatQuota}
+
+// Event describes the request the hook is called for.
+type Event struct {
+	Chat     string
+	Count    int
+	Method   string
+	Priority Priority
+
+	// Reason and Waited are known since the dispatch.
+	Reason Reason
+	Waited time.Duration
+
+	// Took and Err are known on the completion.
+	Took time.Duration
+	Err  error
+}
+
+// Hooks are called for every request, the nil ones are skipped.
+// They are called from the goroutine performing the request, so must be fast.
+type Hooks struct {
+	// OnEnqueue is called when the request starts waiting for the quota.
+	OnEnqueue func(Event)
+
+	// OnDispatch is called when the request has got the quota and is about to be sent.
+	OnDispatch func(Event)
+
+	// OnComplete is called when the request has been sent.
+	OnComplete func(Event)
+}
+
+// Snapshot is the state of the scheduler at the moment.
+type Snapshot struct {
+	At time.Time
+
+	// Queued is the number of the requests waiting for the quota by lane.
+	Queued map[Priority]int
+
+	// InFlight is the number of the requests being sent.
+	InFlight int
+
+	// OldestWait is for how long the oldest waiting request has been waiting.
+	OldestWait time.Duration
+
+	// GlobalBlocked is the time left until the global quota frees up, if it's exhausted.
+	GlobalBlocked time.Duration
+
+	// GlobalUsed is the units of the global quota in use, if the store reports its Usage.
+	GlobalUsed int
+
+	// Chats are the ones with requests waiting or in flight, blocked ones or the ones using the quota.
+	Chats map[string]ChatStats
+
+	// Waits are the times the requests have waited for the quota, by what they have waited on.
+	Waits map[Reason]Histogram
+
+	// Requests are the times the requests took to be sent.
+	Requests Histogram
+}
+
+// ChatStats is the state of the chat.
+type ChatStats struct {
+	Queued   int
+	InFlight int
+
+	// Blocked is the time left until the chat's quota frees up, if it's known to be exhausted.
+	Blocked time.Duration
+
+	// Used is the units of the chat's quota in use, if the store reports its Usage.
+	Used int
+}
+
+// histogramBounds are the upper bounds of the Histogram buckets.
+var histogramBounds = []time.Duration{
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+	25 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	2500 * time.Millisecond,
+	5 * time.Second,
+	10 * time.Second,
+	30 * time.Second,
+	time.Minute,
+}
+
+// Histogram is a distribution of durations.
+type Histogram struct {
+	// Bounds are the upper bounds of the buckets.
+	Bounds []time.Duration
+
+	// Counts are the observations by bucket, the last one is for the ones above all the bounds.
+	Counts []uint64
+	Count  uint64
+	Sum    time.Duration
+}
+
+func (h *Histogram) observe(d time.Duration) {
+	if h.Counts == nil {
+		h.Bounds = histogramBounds
+		h.Counts = make([]uint64, len(histogramBounds)+1)
+	}
+
+	i := 0
+	for i < len(h.Bounds) && d > h.Bounds[i] {
+		i++
+	}
+	h.Counts[i]++
+	h.Count++
+	h.Sum += d
+}
+
+func (h Histogram) copy() Histogram {
+	if h.Counts == nil {
+		h.Bounds = histogramBounds
+		h.Counts = make([]uint64, len(histogramBounds)+1)
+	} else {
+		h.Counts = append([]uint64(nil), h.Counts...)
+	}
+	return h
+}
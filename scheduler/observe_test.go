@@ -0,0 +1,138 @@
+package scheduler
+
+import (
+	"context"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBucketSnapshot(t *testing.T) {
+	const period = 100 * time.Millisecond
+	sch := newBucket(newMemoryStore(100, period, 1, time.Minute))
+
+	var (
+		mu     sync.Mutex
+		events = map[string][]Event{}
+	)
+	record := func(kind string) func(Event) {
+		return func(e Event) {
+			mu.Lock()
+			events[kind] = append(events[kind], e)
+			mu.Unlock()
+		}
+	}
+	sch.Observe(Hooks{
+		OnEnqueue:  record("enqueue"),
+		OnDispatch: record("dispatch"),
+		OnComplete: record("complete"),
+	})
+
+	release := make(chan struct{})
+	sent := make(chan struct{})
+	go sch.SyncFunc(1, "-1", func() ([]byte, error) {
+		close(sent)
+		<-release
+		return nil, nil
+	})
+	<-sent
+
+	// the chat's quota is exhausted, the second one waits
+	ctx, cancel := context.WithCancel(WithPriority(context.Background(), Bulk))
+	defer cancel()
+	go sch.SyncFuncContext(ctx, 1, "-1", func() ([]byte, error) { return nil, nil })
+
+	assert.Eventually(t, func() bool {
+		return sch.Snapshot().Queued[Bulk] == 1
+	}, time.Second, time.Millisecond)
+
+	snap := sch.Snapshot()
+	assert.Equal(t, 1, snap.InFlight)
+	assert.Equal(t, 1, snap.GlobalUsed)
+	assert.Equal(t, ChatStats{Queued: 1, InFlight: 1, Used: 1, Blocked: snap.Chats["-1"].Blocked}, snap.Chats["-1"])
+	assert.Positive(t, snap.Chats["-1"].Blocked)
+	assert.Positive(t, snap.OldestWait)
+
+	close(release)
+	assert.Eventually(t, func() bool {
+		return sch.Snapshot().InFlight == 0
+	}, time.Second, time.Millisecond)
+
+	snap = sch.Snapshot()
+	assert.EqualValues(t, 1, snap.Waits[Unblocked].Count)
+	assert.EqualValues(t, 1, snap.Requests.Count)
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Len(t, events["enqueue"], 2)
+	assert.Len(t, events["dispatch"], 1)
+	require.Len(t, events["complete"], 1)
+	assert.Equal(t, "-1", events["complete"][0].Chat)
+	assert.Equal(t, Unblocked, events["complete"][0].Reason)
+	assert.Equal(t, Bulk, events["enqueue"][1].Priority)
+}
+
+func TestBucketWaitReason(t *testing.T) {
+	const period = 50 * time.Millisecond
+	sch := newBucket(newMemoryStore(1, period, 100, time.Minute))
+
+	var reasons []Reason
+	sch.Observe(Hooks{OnDispatch: func(e Event) { reasons = append(reasons, e.Reason) }})
+
+	for i := 0; i < 2; i++ {
+		sch.SyncFunc(1, "-1", func() ([]byte, error) { return nil, nil })
+	}
+
+	assert.Equal(t, []Reason{Unblocked, GlobalQuota}, reasons)
+	assert.EqualValues(t, 1, sch.Snapshot().Waits[GlobalQuota].Count)
+}
+
+func TestExporter(t *testing.T) {
+	sch := newBucket(newMemoryStore(10, time.Second, 10, time.Minute))
+	sch.SyncFunc(1, "-1", func() ([]byte, error) { return nil, nil })
+
+	rec := httptest.NewRecorder()
+	Exporter(sch).ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+
+	body := rec.Body.String()
+	assert.Contains(t, body, `tg_scheduler_queued{lane="interactive"} 0`)
+	assert.Contains(t, body, "tg_scheduler_global_used 1\n")
+	assert.Contains(t, body, "tg_scheduler_chats_active 1\n")
+	assert.Contains(t, body, `tg_scheduler_wait_seconds_bucket{reason="none",le="0.005"} 1`)
+	assert.Contains(t, body, `tg_scheduler_wait_seconds_count{reason="none"} 1`)
+	assert.Contains(t, body, `tg_scheduler_request_seconds_bucket{le="+Inf"} 1`)
+	assert.Contains(t, body, "tg_scheduler_request_seconds_count 1\n")
+}
+
+func TestBucketHooksContention(t *testing.T) {
+	sch := newBucket(newMemoryStore(5, 5*time.Millisecond, 100, time.Minute))
+
+	var enqueued, completed atomic.Int64
+	sch.Observe(Hooks{
+		OnEnqueue: func(e Event) {
+			// the waiters are dispatched meanwhile
+			time.Sleep(time.Millisecond)
+			enqueued.Add(1)
+		},
+		OnComplete: func(e Event) { completed.Add(1) },
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(chat string) {
+			defer wg.Done()
+			sch.SyncFunc(1, chat, func() ([]byte, error) { return nil, nil })
+		}(strconv.Itoa(-i - 1))
+	}
+	wg.Wait()
+
+	assert.EqualValues(t, 50, enqueued.Load())
+	assert.EqualValues(t, 50, completed.Load())
+}
@@ -0,0 +1,107 @@
+package scheduler
+
+import "context"
+
+// Priority is a lane of outgoing requests. When the quota is short, requests
+// of the higher lanes are given the free slots first.
+type Priority int
+
+const (
+	// Normal is the default priority.
+	Normal Priority = iota
+	// Interactive is meant for the replies users are waiting for.
+	Interactive
+	// Bulk is meant for broadcasts and other background sends.
+	Bulk
+)
+
+// lanes in the order of importance.
+var lanes = [...]Priority{Interactive, Normal, Bulk}
+
+// lane returns the index of priority in lanes, unknown priorities are treated as Normal.
+func (p Priority) lane() int {
+	for i, l := range lanes {
+		if l == p {
+			return i
+		}
+	}
+	return Normal.lane()
+}
+
+func (p Priority) String() string {
+	switch p {
+	case Interactive:
+		return "interactive"
+	case Bulk:
+		return "bulk"
+	default:
+		return "normal"
+	}
+}
+
+type priorityKey struct{}
+
+// WithPriority returns a copy of ctx carrying the priority.
+func WithPriority(ctx context.Context, priority Priority) context.Context {
+	return context.WithValue(ctx, priorityKey{}, priority)
+}
+
+// PriorityFrom returns the priority carried by ctx, Normal if there's none.
+func PriorityFrom(ctx context.Context) Priority {
+	if p, ok := ctx.Value(priorityKey{}).(Priority); ok {
+		return p
+	}
+	return Normal
+}
+
+// ordering decides which of the lanes with pending requests gets the next free slot.
+type ordering interface {
+	// pick returns the lane to be served next, ok is false if no lane is pending.
+	pick(pending [len(lanes)]bool) (lane int, ok bool)
+	// served is called after the lane picked has been served.
+	served(pending [len(lanes)]bool, lane int)
+}
+
+// strict always serves the most important pending lane.
+type strict struct{}
+
+func (strict) pick(pending [len(lanes)]bool) (int, bool) {
+	for i, ok := range pending {
+		if ok {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+func (strict) served([len(lanes)]bool, int) {}
+
+// weighted is a smooth weighted round-robin: out of every sum(weights) slots,
+// a constantly pending lane gets its weight, evenly spread.
+type weighted struct {
+	weights [len(lanes)]int
+	credits [len(lanes)]int
+}
+
+func (o *weighted) pick(pending [len(lanes)]bool) (best int, ok bool) {
+	for i := range pending {
+		if !pending[i] {
+			continue
+		}
+		if !ok || o.credits[i]+o.weights[i] > o.credits[best]+o.weights[best] {
+			best, ok = i, true
+		}
+	}
+	return best, ok
+}
+
+func (o *weighted) served(pending [len(lanes)]bool, lane int) {
+	total := 0
+	for i := range pending {
+		if pending[i] {
+			o.credits[i] += o.weights[i]
+			total += o.weights[i]
+		}
+	}
+	o.credits[lane] -= total
+}
@@ -0,0 +1,181 @@
+package scheduler
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ChatKind tells the kind of chat by its id.
+type ChatKind string
+
+const (
+	Private ChatKind = "private"
+	Group   ChatKind = "group"
+	// Channel is any chat with the -100 prefixed id or a username, supergroups included,
+	// since there's no telling them apart by the id.
+	Channel ChatKind = "channel"
+)
+
+// KindOf returns the kind of the chat, passed as chat_id.
+func KindOf(chat string) ChatKind {
+	if strings.HasPrefix(chat, "@") {
+		return Channel
+	}
+	id, err := strconv.ParseInt(chat, 10, 64)
+	switch {
+	case err != nil:
+		return Channel
+	case id >= 0:
+		return Private
+	case strings.HasPrefix(chat, "-100"):
+		return Channel
+	default:
+		return Group
+	}
+}
+
+// MethodGroups are the names Rule.Methods may use instead of listing the methods one by one.
+var MethodGroups = map[string][]string{
+	"send": {
+		"sendMessage", "forwardMessage", "copyMessage", "sendPhoto", "sendAudio",
+		"sendDocument", "sendVideo", "sendAnimation", "sendVoice", "sendVideoNote",
+		"sendMediaGroup", "sendLocation", "sendVenue", "sendContact", "sendPoll",
+		"sendDice", "sendSticker", "sendInvoice", "sendGame",
+	},
+	"edit": {
+		"editMessageText", "editMessageCaption", "editMessageMedia", "editMessageReplyMarkup",
+		"editMessageLiveLocation", "stopMessageLiveLocation", "stopPoll",
+	},
+	"answer": {
+		"answerCallbackQuery", "answerInlineQuery", "answerWebAppQuery",
+		"answerShippingQuery", "answerPreCheckoutQuery",
+	},
+}
+
+// Limit is `count` units per `period`.
+type Limit struct {
+	Count  int           `yaml:"count" json:"count"`
+	Period time.Duration `yaml:"period" json:"period"`
+}
+
+// Rule limits the requests matching it. Every matching rule applies,
+// so a request must fit into all of them.
+type Rule struct {
+	Limit `yaml:",inline"`
+
+	// Methods the rule applies to, method names or MethodGroups. Empty matches any.
+	Methods []string `yaml:"methods" json:"methods"`
+
+	// Chats the rule applies to. Empty matches any.
+	Chats []ChatKind `yaml:"chats" json:"chats"`
+
+	// Paid makes the rule match paid broadcasts only, see WithPaidBroadcast.
+	// Paid broadcasts matching a Paid rule aren't limited by the global quota, nor by the rules
+	// without Paid. The ones matching none, i.e. with a profile having no Paid rules, are limited as usual.
+	Paid bool `yaml:"paid" json:"paid"`
+
+	// Shared makes all the matching chats spend one limit, instead of one per chat.
+	Shared bool `yaml:"shared" json:"shared"`
+
+	// Cost is charged for a request instead of its count, if set.
+	Cost int `yaml:"cost" json:"cost"`
+}
+
+// Profile is a declarative description of the quota.
+//
+// Example of the layout settings:
+//
+//	scheduler:
+//	  global: { count: 30, period: 1s }
+//	  rules:
+//	    - { chats: [ group, channel ], count: 20, period: 1m }
+//	    - { chats: [ private ], methods: [ send ], count: 1, period: 1s }
+type Profile struct {
+	Global Limit  `yaml:"global" json:"global"`
+	Rules  []Rule `yaml:"rules" json:"rules"`
+}
+
+// CustomProfile is the profile of Custom and Bucket: `global` units per second
+// and `perChat` units per minute for every non-personal chat.
+func CustomProfile(global, perChat int) Profile {
+	return Profile{
+		Global: Limit{Count: global, Period: ApiRequestQuotaTimeout},
+		Rules: []Rule{{
+			Limit: Limit{Count: perChat, Period: ApiRequestQuotaPerChatTimeout},
+			Chats: []ChatKind{Group, Channel},
+		}},
+	}
+}
+
+// TelegramProfile follows the limits from the bots FAQ:
+// https://core.telegram.org/bots/faq#my-bot-is-hitting-limits-how-do-i-avoid-this
+func TelegramProfile() Profile {
+	profile := CustomProfile(ApiRequestQuota, ApiRequestQuotaPerChat)
+	profile.Rules = append(profile.Rules,
+		Rule{
+			Limit:   Limit{Count: 1, Period: time.Second},
+			Chats:   []ChatKind{Private},
+			Methods: []string{"send"},
+		},
+		Rule{
+			Limit:  Limit{Count: 1000, Period: time.Second},
+			Paid:   true,
+			Shared: true,
+		},
+	)
+	return profile
+}
+
+// Profiled is a Bucket following the profile.
+func Profiled(profile Profile) Scheduler {
+	return Shared(ProfileStore(profile))
+}
+
+// matches tells whether the rule applies to the request.
+func (r *Rule) matches(method string, kind ChatKind, paid bool) bool {
+	if r.Paid != paid {
+		return false
+	}
+	if len(r.Chats) > 0 && !slices.Contains(r.Chats, kind) {
+		return false
+	}
+	if len(r.Methods) == 0 {
+		return true
+	}
+	for _, m := range r.Methods {
+		if m == method || slices.Contains(MethodGroups[m], method) {
+			return true
+		}
+	}
+	return false
+}
+
+type (
+	methodKey struct{}
+	paidKey   struct{}
+)
+
+// WithMethod returns a copy of ctx carrying the API method of the request.
+func WithMethod(ctx context.Context, method string) context.Context {
+	return context.WithValue(ctx, methodKey{}, method)
+}
+
+// MethodFrom returns the API method carried by ctx.
+func MethodFrom(ctx context.Context) string {
+	method, _ := ctx.Value(methodKey{}).(string)
+	return method
+}
+
+// WithPaidBroadcast returns a copy of ctx marking the request as a paid broadcast.
+func WithPaidBroadcast(ctx context.Context) context.Context {
+	return context.WithValue(ctx, paidKey{}, true)
+}
+
+// IsPaidBroadcast tells whether ctx marks the request as a paid broadcast.
+func IsPaidBroadcast(ctx context.Context) bool {
+	paid, _ := ctx.Value(paidKey{}).(bool)
+	return paid
+}
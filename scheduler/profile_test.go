@@ -0,0 +1,95 @@
+package scheduler
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKindOf(t *testing.T) {
+	assert.Equal(t, Private, KindOf("123"))
+	assert.Equal(t, Group, KindOf("-123"))
+	assert.Equal(t, Channel, KindOf("-100123"))
+	assert.Equal(t, Channel, KindOf("@channel"))
+}
+
+func TestRuleMatches(t *testing.T) {
+	rule := Rule{Methods: []string{"send", "pinChatMessage"}, Chats: []ChatKind{Private}}
+	assert.True(t, rule.matches("sendPhoto", Private, false))
+	assert.True(t, rule.matches("pinChatMessage", Private, false))
+	assert.False(t, rule.matches("editMessageText", Private, false))
+	assert.False(t, rule.matches("sendPhoto", Group, false))
+	assert.False(t, rule.matches("sendPhoto", Private, true))
+
+	paid := Rule{Paid: true}
+	assert.True(t, paid.matches("sendMessage", Channel, true))
+	assert.False(t, paid.matches("sendMessage", Channel, false))
+}
+
+func TestProfileStore(t *testing.T) {
+	ctx := context.Background()
+	send := WithMethod(ctx, "sendMessage")
+	paid := WithPaidBroadcast(send)
+
+	t.Run("private", func(t *testing.T) {
+		s := ProfileStore(TelegramProfile())
+
+		wait, global, _ := s.Take(send, 1, "1")
+		assert.Zero(t, wait)
+		wait, global, _ = s.Take(send, 1, "1")
+		assert.Positive(t, wait)
+		assert.False(t, global)
+
+		// the other methods and chats aren't limited by the rule
+		wait, _, _ = s.Take(WithMethod(ctx, "answerCallbackQuery"), 1, "1")
+		assert.Zero(t, wait)
+		wait, _, _ = s.Take(send, 1, "2")
+		assert.Zero(t, wait)
+	})
+
+	t.Run("paid", func(t *testing.T) {
+		s := ProfileStore(TelegramProfile())
+
+		for i := 0; i < ApiRequestQuota; i++ {
+			s.Take(send, 1, strconv.Itoa(i+1))
+		}
+		wait, global, _ := s.Take(send, 1, "42")
+		assert.Positive(t, wait)
+		assert.True(t, global)
+
+		// paid broadcasts have the quota of their own
+		wait, _, _ = s.Take(paid, 1, "42")
+		assert.Zero(t, wait)
+
+		// unless the profile has none for them
+		s = ProfileStore(CustomProfile(1, 1))
+		wait, _, _ = s.Take(paid, 1, "-42")
+		assert.Zero(t, wait)
+		wait, _, _ = s.Take(paid, 1, "-42")
+		assert.Positive(t, wait)
+	})
+
+	t.Run("cost", func(t *testing.T) {
+		s := ProfileStore(Profile{
+			Global: Limit{Count: 100, Period: time.Second},
+			Rules: []Rule{{
+				Limit:   Limit{Count: 10, Period: time.Minute},
+				Methods: []string{"sendMediaGroup"},
+				Shared:  true,
+				Cost:    5,
+			}},
+		})
+
+		album := WithMethod(ctx, "sendMediaGroup")
+		for _, chat := range []string{"-1", "-2"} {
+			wait, _, _ := s.Take(album, 1, chat)
+			assert.Zero(t, wait)
+		}
+		wait, global, _ := s.Take(album, 1, "-3")
+		assert.Positive(t, wait)
+		assert.False(t, global)
+	})
+}
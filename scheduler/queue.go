@@ -0,0 +1,94 @@
+package scheduler
+
+import "slices"
+
+// queue holds the waiters of a lane. Its chats take turns, so that a chat
+// with lots of requests can't starve the others, the waiters of a chat are served in order.
+type queue struct {
+	chats map[string][]*waiter
+	turns []string // chats with waiters, the front one is the next to be served
+	len   int
+
+	// skipped are the chats taken out of turns, until they're restored
+	skipped map[string]bool
+}
+
+func (q *queue) push(w *waiter) {
+	if q.chats == nil {
+		q.chats = map[string][]*waiter{}
+	}
+	if len(q.chats[w.chat]) == 0 && !q.skipped[w.chat] {
+		q.turns = append(q.turns, w.chat)
+	}
+	q.chats[w.chat] = append(q.chats[w.chat], w)
+	q.len++
+}
+
+// remove removes the waiter, unless it's not in the queue.
+func (q *queue) remove(w *waiter) bool {
+	waiters := q.chats[w.chat]
+	i := slices.Index(waiters, w)
+	if i < 0 {
+		return false
+	}
+
+	q.len--
+	if waiters = slices.Delete(waiters, i, i+1); len(waiters) > 0 {
+		q.chats[w.chat] = waiters
+		return true
+	}
+
+	delete(q.chats, w.chat)
+	if i := slices.Index(q.turns, w.chat); i >= 0 {
+		q.turns = slices.Delete(q.turns, i, i+1)
+	}
+	return true
+}
+
+// front returns the next waiter to be served, nil if there's none.
+func (q *queue) front() *waiter {
+	if len(q.turns) == 0 {
+		return nil
+	}
+	return q.chats[q.turns[0]][0]
+}
+
+// pop removes the front waiter, its chat's turn passes to the next one.
+func (q *queue) pop() {
+	chat := q.turns[0]
+	q.turns = q.turns[1:]
+	q.len--
+
+	if waiters := q.chats[chat][1:]; len(waiters) > 0 {
+		q.chats[chat] = waiters
+		q.turns = append(q.turns, chat)
+	} else {
+		delete(q.chats, chat)
+	}
+}
+
+// skip takes the front chat out of turns, until it's restored.
+func (q *queue) skip() string {
+	chat := q.turns[0]
+	q.turns = q.turns[1:]
+	if q.skipped == nil {
+		q.skipped = map[string]bool{}
+	}
+	q.skipped[chat] = true
+	return chat
+}
+
+// restore returns the skipped chats to the front, so that they keep their turns.
+// The chats, which waiters have been removed since, are dropped.
+func (q *queue) restore(skipped []string) {
+	kept := skipped[:0]
+	for _, chat := range skipped {
+		delete(q.skipped, chat)
+		if len(q.chats[chat]) > 0 {
+			kept = append(kept, chat)
+		}
+	}
+	if len(kept) > 0 {
+		q.turns = append(kept, q.turns...)
+	}
+}
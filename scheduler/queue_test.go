@@ -0,0 +1,125 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/graphomania/tg/clock"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeBucket returns a bucket over the store, both running on the clock.
+func fakeBucket(store *memoryStore) (*bucket, *clock.Fake) {
+	fake := clock.NewFake(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	sch := newBucket(store)
+	sch.UseClock(fake)
+	return sch, fake
+}
+
+// enqueue adds the waiter as SyncFuncContext does, without waiting for it to be dispatched.
+func enqueue(sch *bucket, count int, chat string) *waiter {
+	w := &waiter{
+		ctx:      context.Background(),
+		count:    count,
+		chat:     chat,
+		ready:    make(chan struct{}),
+		enqueued: sch.clock.Now(),
+	}
+
+	sch.mu.Lock()
+	defer sch.mu.Unlock()
+	sch.enqueue(Normal.lane(), w)
+	return w
+}
+
+func TestQueue(t *testing.T) {
+	var q queue
+	a1, a2 := &waiter{chat: "a"}, &waiter{chat: "a"}
+	b1, c1 := &waiter{chat: "b"}, &waiter{chat: "c"}
+	for _, w := range []*waiter{a1, a2, b1, c1} {
+		q.push(w)
+	}
+	assert.Equal(t, 4, q.len)
+
+	assert.Same(t, a1, q.front())
+	q.pop()
+	assert.Same(t, b1, q.front())
+
+	// the skipped chat keeps its turn
+	assert.Equal(t, "b", q.skip())
+	assert.Same(t, c1, q.front())
+	q.restore([]string{"b"})
+	assert.Same(t, b1, q.front())
+
+	assert.True(t, q.remove(b1))
+	assert.False(t, q.remove(b1))
+	assert.Same(t, c1, q.front())
+	q.pop()
+	assert.Same(t, a2, q.front())
+	q.pop()
+
+	assert.Nil(t, q.front())
+	assert.Zero(t, q.len)
+	assert.Empty(t, q.chats)
+}
+
+func TestBucketFairness(t *testing.T) {
+	sch, clock := fakeBucket(newMemoryStore(30, time.Second, 100, time.Minute))
+
+	start := clock.Now()
+
+	// the albums of one chat take the whole global quota for the next 16 seconds
+	var albums, messages []*waiter
+	for i := 0; i < 50; i++ {
+		albums = append(albums, enqueue(sch, 10, "1"))
+	}
+	for i := 0; i < 5; i++ {
+		messages = append(messages, enqueue(sch, 1, "2"))
+	}
+	small := enqueue(sch, 1, "3")
+
+	for i := 0; i < 20; i++ {
+		clock.Advance(time.Second)
+	}
+
+	// the chats take turns, the albums get the rest
+	assert.Equal(t, start.Add(time.Second), small.dispatched)
+	for i, at := range []time.Duration{1, 1, 2, 2, 3} {
+		assert.Equal(t, start.Add(at*time.Second), messages[i].dispatched)
+	}
+	for _, w := range albums {
+		assert.False(t, w.dispatched.IsZero())
+	}
+	assert.Equal(t, GlobalQuota, small.reason)
+}
+
+func TestBucketChatTurns(t *testing.T) {
+	sch, clock := fakeBucket(newMemoryStore(100, time.Second, 2, time.Minute))
+	start := clock.Now()
+
+	var group []*waiter
+	for i := 0; i < 5; i++ {
+		group = append(group, enqueue(sch, 1, "-1"))
+	}
+	// the blocked chat doesn't hold the others
+	other := enqueue(sch, 1, "-2")
+	assert.Equal(t, start, other.dispatched)
+
+	for _, w := range group[:2] {
+		assert.Equal(t, start, w.dispatched)
+	}
+	for _, w := range group[2:] {
+		assert.True(t, w.dispatched.IsZero())
+	}
+
+	clock.Advance(time.Minute)
+	for _, w := range group[2:4] {
+		assert.Equal(t, start.Add(time.Minute), w.dispatched)
+		assert.Equal(t, ChatQuota, w.reason)
+	}
+	assert.True(t, group[4].dispatched.IsZero())
+
+	clock.Advance(time.Minute)
+	assert.Equal(t, start.Add(2*time.Minute), group[4].dispatched)
+}
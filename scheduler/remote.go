@@ -0,0 +1,152 @@
+package scheduler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// takeRequest and takeResponse are the coordinator's wire format.
+type takeRequest struct {
+	Count  int    `json:"count"`
+	Chat   string `json:"chat"`
+	Method string `json:"method,omitempty"`
+	Paid   bool   `json:"paid,omitempty"`
+}
+
+type takeResponse struct {
+	Wait   time.Duration `json:"wait"`
+	Global bool          `json:"global"`
+}
+
+type floodRequest struct {
+	Chat string        `json:"chat"`
+	Wait time.Duration `json:"wait"`
+}
+
+var _ Store = &remoteStore{}
+
+// RemoteTimeout limits the round trips to the coordinator made by the default client of RemoteStore.
+const RemoteTimeout = 5 * time.Second
+
+// RemoteStore is a Store kept by the Coordinator served at url,
+// it lets the bot replicas agree on one budget. If client is nil, the one
+// timing out in RemoteTimeout is used, so that a hung coordinator fails the sends instead of freezing them.
+//
+// Every request waiting for the quota costs a round trip to the coordinator,
+// so it's better to be deployed close to the bots.
+func RemoteStore(url string, client *http.Client) Store {
+	if client == nil {
+		client = &http.Client{Timeout: RemoteTimeout}
+	}
+	return &remoteStore{url: strings.TrimSuffix(url, "/"), client: client}
+}
+
+type remoteStore struct {
+	url    string
+	client *http.Client
+}
+
+func (s *remoteStore) Take(ctx context.Context, count int, chat string) (time.Duration, bool, error) {
+	var took takeResponse
+	req := takeRequest{
+		Count:  count,
+		Chat:   chat,
+		Method: MethodFrom(ctx),
+		Paid:   IsPaidBroadcast(ctx),
+	}
+	if err := s.call(ctx, "/take", req, &took); err != nil {
+		return 0, false, err
+	}
+	return took.Wait, took.Global, nil
+}
+
+func (s *remoteStore) Flood(ctx context.Context, chat string, wait time.Duration) error {
+	return s.call(ctx, "/flood", floodRequest{Chat: chat, Wait: wait}, nil)
+}
+
+func (s *remoteStore) call(ctx context.Context, path string, in, out interface{}) error {
+	body, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url+path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("scheduler: coordinator is unavailable: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("scheduler: coordinator responded with %s", resp.Status)
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("scheduler: bad coordinator response: %w", err)
+	}
+	return nil
+}
+
+// Coordinator serves the store over HTTP for the RemoteStore clients.
+//
+// Example:
+//
+//	http.ListenAndServe(":8030", scheduler.Coordinator(scheduler.MemoryStore(30, 20)))
+func Coordinator(store Store) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/take", func(w http.ResponseWriter, r *http.Request) {
+		var req takeRequest
+		if !decodeRequest(w, r, &req) {
+			return
+		}
+
+		ctx := WithMethod(r.Context(), req.Method)
+		if req.Paid {
+			ctx = WithPaidBroadcast(ctx)
+		}
+
+		wait, global, err := store.Take(ctx, req.Count, req.Chat)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(takeResponse{Wait: wait, Global: global})
+	})
+	mux.HandleFunc("/flood", func(w http.ResponseWriter, r *http.Request) {
+		var req floodRequest
+		if !decodeRequest(w, r, &req) {
+			return
+		}
+
+		if err := store.Flood(r.Context(), req.Chat, req.Wait); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+	return mux
+}
+
+func decodeRequest(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return false
+	}
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 4<<10)).Decode(v); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return false
+	}
+	return true
+}
@@ -1,9 +1,25 @@
 package scheduler
 
+import (
+	"context"
+
+	"github.com/graphomania/tg/clock"
+)
+
 type RawFunc func() ([]byte, error)
 
 type Scheduler interface {
 	SyncFunc(count int, chat string, fn RawFunc) ([]byte, error)
+
+	// SyncFuncContext is SyncFunc, which gives up waiting for the quota as soon as ctx is done,
+	// returning ctx.Err(). The priority of the request is taken from ctx, see WithPriority.
+	SyncFuncContext(ctx context.Context, count int, chat string, fn RawFunc) ([]byte, error)
+}
+
+// Clocked is implemented by the schedulers and the stores able to run on another clock,
+// NewBot passes telebot.Settings.Clock through it.
+type Clocked interface {
+	UseClock(c clock.Clock)
 }
 
 // Nil scheduler does nothing, performing all functions ASAP.
@@ -15,6 +31,13 @@ func (sch *nilScheduler) SyncFunc(count int, chat string, fn RawFunc) ([]byte, e
 	return fn()
 }
 
+func (sch *nilScheduler) SyncFuncContext(ctx context.Context, count int, chat string, fn RawFunc) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return fn()
+}
+
 type nilScheduler struct{}
 
 var _ Scheduler = &nilScheduler{}
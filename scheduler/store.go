@@ -0,0 +1,320 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/graphomania/tg/clock"
+)
+
+// FloodRelief is for how long the chat's quota stays reduced after a flood error.
+const FloodRelief = 10 * time.Minute
+
+// Store keeps track of the quota usage. Sharing a Store lets several schedulers,
+// i.e. the ones of the bot replicas using the same token, spend one budget.
+type Store interface {
+	// Take takes `count` units of the global quota and of the chat's one, only if both are available.
+	// Otherwise, nothing is taken and the time until the blocking quota frees up is returned,
+	// `global` tells whether it's the global quota blocking.
+	// The empty chat stands for the requests to no chat, only the global quota limits them.
+	Take(ctx context.Context, count int, chat string) (wait time.Duration, global bool, err error)
+
+	// Flood reports the request to the chat was rejected by telegram, which asked to wait.
+	// The chat is blocked for the time and its quota is halved for FloodRelief after.
+	// The empty chat stands for the requests to no chat, it blocks the global quota.
+	Flood(ctx context.Context, chat string, wait time.Duration) error
+}
+
+// Flood is implemented by the errors of the requests rejected for flooding, i.e. telebot.FloodError.
+// Schedulers learn from them, see Store.Flood.
+type Flood interface {
+	error
+	RetryIn() time.Duration
+}
+
+// floodOf tells whether err is a Flood and how long it asks to wait.
+func floodOf(err error) (time.Duration, bool) {
+	var flood Flood
+	if err == nil || !errors.As(err, &flood) {
+		return 0, false
+	}
+	return flood.RetryIn(), true
+}
+
+var (
+	_ Store   = &memoryStore{}
+	_ Usage   = &memoryStore{}
+	_ Clocked = &memoryStore{}
+)
+
+// MemoryStore is an in-process Store, which allows `global` units per second and
+// `perChat` units per minute for every non-personal chat.
+func MemoryStore(global, perChat int) Store {
+	return ProfileStore(CustomProfile(global, perChat))
+}
+
+// ProfileStore is an in-process Store following the profile.
+func ProfileStore(profile Profile) Store {
+	return newProfileStore(profile)
+}
+
+func newMemoryStore(global int, globalPeriod time.Duration, perChat int, perChatPeriod time.Duration) *memoryStore {
+	profile := CustomProfile(global, perChat)
+	profile.Global.Period = globalPeriod
+	profile.Rules[0].Period = perChatPeriod
+	return newProfileStore(profile)
+}
+
+func newProfileStore(profile Profile) *memoryStore {
+	s := &memoryStore{
+		profile: profile,
+		clock:   clock.Real(),
+		global:  newWindow(profile.Global.Count, profile.Global.Period),
+		shared:  make([]*window, len(profile.Rules)),
+		perChat: map[chatRule]*window{},
+		floods:  map[string]*flood{},
+	}
+	for i, rule := range profile.Rules {
+		if rule.Shared {
+			s.shared[i] = newWindow(rule.Count, rule.Period)
+		}
+		s.period = max(s.period, rule.Period)
+	}
+	return s
+}
+
+type memoryStore struct {
+	mu sync.Mutex
+
+	profile Profile
+	clock   clock.Clock
+	global  *window
+	shared  []*window // by rule, for the shared ones
+	perChat map[chatRule]*window
+	period  time.Duration // the longest one, any window is restored after
+	sweep   time.Time
+
+	globalBlocked time.Time
+	floods        map[string]*flood
+}
+
+type chatRule struct {
+	chat string
+	rule int
+}
+
+// flood is a penalty of the chat, which has flooded.
+type flood struct {
+	blocked time.Time // no requests till
+	relief  time.Time // reduced quota till
+	divisor int
+}
+
+// charge is the units to be taken from the window.
+type charge struct {
+	w     *window
+	count int
+}
+
+func (s *memoryStore) Take(ctx context.Context, count int, chat string) (time.Duration, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.clock.Now()
+	s.global.expire(now)
+	if now.After(s.sweep) {
+		s.cleanup(now)
+	}
+
+	if s.globalBlocked.After(now) {
+		return s.globalBlocked.Sub(now), true, nil
+	}
+	if f, ok := s.floods[chat]; ok && f.blocked.After(now) {
+		return f.blocked.Sub(now), false, nil
+	}
+
+	var (
+		method  = MethodFrom(ctx)
+		kind    = KindOf(chat)
+		paid    = IsPaidBroadcast(ctx) && s.paidRule(method, kind)
+		charges []charge
+	)
+	for i := range s.profile.Rules {
+		rule := &s.profile.Rules[i]
+		if !rule.matches(method, kind, paid) {
+			continue
+		}
+
+		c := charge{w: s.shared[i], count: count}
+		if rule.Cost > 0 {
+			c.count = rule.Cost
+		}
+		if c.w == nil {
+			if chat == "" {
+				continue
+			}
+			c.w = s.chatWindow(chat, i, now)
+		}
+
+		c.w.expire(now)
+		if at := c.w.readyAt(c.count, now); at.After(now) {
+			return at.Sub(now), false, nil
+		}
+		charges = append(charges, c)
+	}
+
+	if !paid {
+		if at := s.global.readyAt(count, now); at.After(now) {
+			return at.Sub(now), true, nil
+		}
+		s.global.take(count, now)
+	}
+	for _, c := range charges {
+		c.w.take(c.count, now)
+	}
+	return 0, false, nil
+}
+
+func (s *memoryStore) Flood(ctx context.Context, chat string, wait time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.clock.Now()
+	blocked := now.Add(wait)
+	if chat == "" {
+		if blocked.After(s.globalBlocked) {
+			s.globalBlocked = blocked
+		}
+		return nil
+	}
+
+	f, ok := s.floods[chat]
+	if !ok || !f.relief.After(now) {
+		f = &flood{divisor: 1}
+		s.floods[chat] = f
+	}
+	if blocked.After(f.blocked) {
+		f.blocked = blocked
+	}
+	f.relief = f.blocked.Add(FloodRelief)
+	f.divisor *= 2
+	return nil
+}
+
+func (s *memoryStore) UseClock(c clock.Clock) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.clock = c
+}
+
+func (s *memoryStore) Usage() (int, map[string]int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.clock.Now()
+	s.global.expire(now)
+
+	chats := map[string]int{}
+	for key, w := range s.perChat {
+		if w.expire(now); w.used > 0 {
+			chats[key.chat] = max(chats[key.chat], w.used)
+		}
+	}
+	return s.global.used, chats
+}
+
+// paidRule tells whether a Paid rule matches the paid broadcast.
+// The ones matching none are limited as any other request.
+func (s *memoryStore) paidRule(method string, kind ChatKind) bool {
+	for i := range s.profile.Rules {
+		if rule := &s.profile.Rules[i]; rule.Paid && rule.matches(method, kind, true) {
+			return true
+		}
+	}
+	return false
+}
+
+// chatWindow returns the chat's window of the rule.
+// The window's limit is reduced, while the chat is penalized for flooding.
+func (s *memoryStore) chatWindow(chat string, rule int, now time.Time) *window {
+	key := chatRule{chat: chat, rule: rule}
+	limit := s.profile.Rules[rule].Limit
+
+	w, ok := s.perChat[key]
+	if !ok {
+		w = newWindow(limit.Count, limit.Period)
+		s.perChat[key] = w
+	}
+
+	w.limit = limit.Count
+	if f, ok := s.floods[chat]; ok && f.relief.After(now) {
+		w.limit = max(limit.Count/f.divisor, 1)
+	}
+	return w
+}
+
+// cleanup forgets the chats with fully restored quota.
+func (s *memoryStore) cleanup(now time.Time) {
+	for key, w := range s.perChat {
+		if w.expire(now); w.used == 0 {
+			delete(s.perChat, key)
+		}
+	}
+	for chat, f := range s.floods {
+		if !f.relief.After(now) {
+			delete(s.floods, chat)
+		}
+	}
+	s.sweep = now.Add(s.period)
+}
+
+// window is a sliding window quota: every taken unit is returned exactly one period later.
+type window struct {
+	limit  int
+	period time.Duration
+	used   int
+	events []event // ordered by time, which is the moment of return
+}
+
+func newWindow(limit int, period time.Duration) *window {
+	return &window{limit: limit, period: period}
+}
+
+func (w *window) expire(now time.Time) {
+	handled := 0
+	for _, e := range w.events {
+		if e.time.After(now) {
+			break
+		}
+		handled += 1
+		w.used -= e.count
+	}
+	if handled == len(w.events) {
+		w.events = w.events[:0]
+	} else {
+		w.events = w.events[handled:]
+	}
+}
+
+// readyAt returns the moment `count` units become available, `now` if they already are.
+// Requests bigger than the limit are treated as if they take the whole limit.
+func (w *window) readyAt(count int, now time.Time) time.Time {
+	count = min(count, w.limit)
+	excess := w.used + count - w.limit
+	for _, e := range w.events {
+		if excess <= 0 {
+			break
+		}
+		excess -= e.count
+		now = e.time
+	}
+	return now
+}
+
+func (w *window) take(count int, now time.Time) {
+	count = min(count, w.limit)
+	w.used += count
+	w.events = append(w.events, event{time: now.Add(w.period), count: count})
+}
@@ -1,43 +1,223 @@
 package telebot
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"github.com/graphomania/tg/clock"
 	"github.com/graphomania/tg/scheduler"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
-	"log"
+	"net/http"
+	"net/http/httptest"
 	"os"
-	"strconv"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 )
 
+// fakeAPI records the sizes of the albums sent and the moments of the clock they were sent at.
+type fakeAPI struct {
+	mu     sync.Mutex
+	clock  clock.Clock
+	times  []time.Time
+	counts []int
+}
+
+func (api *fakeAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var media []json.RawMessage
+	if err := json.Unmarshal([]byte(r.FormValue("media")), &media); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	api.mu.Lock()
+	api.times = append(api.times, api.clock.Now())
+	api.counts = append(api.counts, len(media))
+	api.mu.Unlock()
+
+	w.Write([]byte(`{"ok":true,"result":[` + strings.Repeat(`{"message_id":1},`, len(media)-1) + `{"message_id":1}]}`))
+}
+
+// maxInWindow returns the biggest amount of units sent within any period.
+func (api *fakeAPI) maxInWindow(period time.Duration) (most int) {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+
+	for i, lhs := range api.times {
+		n := 0
+		for j, rhs := range api.times[i:] {
+			if rhs.Sub(lhs) < period {
+				n += api.counts[i+j]
+			}
+		}
+		most = max(most, n)
+	}
+	return most
+}
+
+// drive advances the clock, while anybody is waiting for it, until done is closed.
+func drive(fake *clock.Fake, step time.Duration, done <-chan struct{}) {
+	for {
+		select {
+		case <-done:
+			return
+		default:
+		}
+		if fake.Timers() > 0 {
+			fake.Advance(step)
+		} else {
+			time.Sleep(100 * time.Microsecond)
+		}
+	}
+}
+
 func TestConservative(t *testing.T) {
-	token := os.Getenv("TG_TEST_TOKEN")
-	chat, err := strconv.ParseInt(os.Getenv("TG_TEST_CHAT"), 10, 64)
-	require.NoError(t, err)
+	photo := filepath.Join(t.TempDir(), "pic.jpg")
+	require.NoError(t, os.WriteFile(photo, []byte("jpeg"), 0o600))
 
 	alb := Album{}
 	for i := 0; i < 10; i++ {
-		alb = append(alb, &Photo{File: FromDisk("testdata/pic.jpg"), Caption: fmt.Sprint(i + 1)})
+		alb = append(alb, &Photo{File: FromDisk(photo), Caption: fmt.Sprint(i + 1)})
 	}
 
-	bot, err := NewBot(Settings{
-		Token:  token,
-		Poller: &LongPoller{Timeout: time.Minute},
-		//Verbose: true,
-		Synchronous: true,
-		OnError: func(err error, context Context) {
+	for name, sch := range map[string]scheduler.Scheduler{
+		"polling": scheduler.Conservative(),
+		"bucket":  scheduler.Bucket(scheduler.ApiRequestQuota*4/5, scheduler.ApiRequestQuotaPerChat*4/5),
+	} {
+		t.Run(name, func(t *testing.T) {
+			fake := clock.NewFake(time.Now())
+			api := &fakeAPI{clock: fake}
+			srv := httptest.NewServer(api)
+			defer srv.Close()
+
+			bot, err := NewBot(Settings{URL: srv.URL, Offline: true, Scheduler: sch, Clock: fake})
 			require.NoError(t, err)
-		},
-		Scheduler: scheduler.Default(),
-		Retries:   1,
-	})
-	require.NoError(t, err)
-
-	for i := 0; i < 400; i++ {
-		log.Printf("%d.\n", i)
-		//_, err := bot.SendAlbum(&Chat{ID: chat}, alb[0:(((i+1)*2)%9+1)])
-		_, err := bot.SendAlbum(&Chat{ID: chat}, alb[0:10])
+
+			start := fake.Now()
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				for i := 0; i < 5; i++ {
+					_, err := bot.SendAlbum(&Chat{ID: -100}, alb)
+					assert.NoError(t, err)
+				}
+			}()
+			drive(fake, time.Second, done)
+
+			// 16 units per minute let one album through a minute
+			assert.Len(t, api.times, 5)
+			assert.LessOrEqual(t, maxInWindow(api.times, time.Minute), 1)
+			assert.LessOrEqual(t, api.maxInWindow(time.Minute), scheduler.ApiRequestQuotaPerChat*4/5)
+			assert.GreaterOrEqual(t, fake.Now().Sub(start), 4*time.Minute)
+		})
+	}
+}
+
+// maxInWindow returns the biggest amount of the moments within any period.
+func maxInWindow(times []time.Time, period time.Duration) (most int) {
+	for i, lhs := range times {
+		n := 0
+		for _, rhs := range times[i:] {
+			if rhs.Sub(lhs) < period {
+				n++
+			}
+		}
+		most = max(most, n)
+	}
+	return most
+}
+
+func TestSharedScheduler(t *testing.T) {
+	var sent atomic.Int32
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sent.Add(1)
+		w.Write([]byte(`{"ok":true,"result":{"message_id":1}}`))
+	}))
+	defer api.Close()
+
+	coordinator := httptest.NewServer(scheduler.Coordinator(scheduler.MemoryStore(30, 3)))
+	defer coordinator.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+
+	var (
+		wg      sync.WaitGroup
+		dropped atomic.Int32
+	)
+	for i := 0; i < 2; i++ {
+		bot, err := NewBot(Settings{
+			URL:       api.URL,
+			Offline:   true,
+			Scheduler: scheduler.Shared(scheduler.RemoteStore(coordinator.URL, nil)),
+		})
 		require.NoError(t, err)
+
+		for j := 0; j < 3; j++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if _, err := bot.SendContext(ctx, &Chat{ID: -100}, "hi"); errors.Is(err, context.DeadlineExceeded) {
+					dropped.Add(1)
+				}
+			}()
+		}
+	}
+	wg.Wait()
+
+	// both replicas together stay within the per chat quota
+	assert.EqualValues(t, 3, sent.Load())
+	assert.EqualValues(t, 3, dropped.Load())
+}
+
+func TestSchedulerGlobalFlood(t *testing.T) {
+	for name, sch := range map[string]scheduler.Scheduler{
+		"polling": scheduler.Default(),
+		"bucket":  scheduler.DefaultBucket(),
+	} {
+		t.Run(name, func(t *testing.T) {
+			fake := clock.NewFake(time.Now())
+			var (
+				mu   sync.Mutex
+				sent time.Time
+			)
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if strings.HasSuffix(r.URL.Path, "/getMe") {
+					w.WriteHeader(http.StatusTooManyRequests)
+					w.Write([]byte(`{"ok":false,"error_code":429,"description":"Too Many Requests: retry after 5","parameters":{"retry_after":5}}`))
+					return
+				}
+				mu.Lock()
+				sent = fake.Now()
+				mu.Unlock()
+				w.Write([]byte(`{"ok":true,"result":{"message_id":1}}`))
+			}))
+			defer srv.Close()
+
+			bot, err := NewBot(Settings{URL: srv.URL, Offline: true, Scheduler: sch, Clock: fake, RetryPolicy: &Backoff{}})
+			require.NoError(t, err)
+
+			// the request to no chat floods the global quota
+			start := fake.Now()
+			_, err = bot.Raw("getMe", nil)
+			assert.ErrorAs(t, err, new(FloodError))
+
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				_, err := bot.Send(&Chat{ID: -100}, "hi")
+				assert.NoError(t, err)
+			}()
+			drive(fake, time.Second, done)
+
+			mu.Lock()
+			defer mu.Unlock()
+			assert.GreaterOrEqual(t, sent.Sub(start), 5*time.Second)
+		})
 	}
 }
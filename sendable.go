@@ -43,7 +43,7 @@ func (p *Photo) Send(b *Bot, to Recipient, opt *SendOptions) (*Message, error) {
 	}
 	b.embedSendOptions(params, opt)
 
-	msg, err := b.sendMedia(p, params, nil)
+	msg, err := b.sendMedia(p, params, nil, opt)
 	if err != nil {
 		return nil, err
 	}
@@ -70,7 +70,7 @@ func (a *Audio) Send(b *Bot, to Recipient, opt *SendOptions) (*Message, error) {
 		params["duration"] = strconv.Itoa(a.Duration)
 	}
 
-	msg, err := b.sendMedia(a, params, thumbnailToFilemap(a.Thumbnail))
+	msg, err := b.sendMedia(a, params, thumbnailToFilemap(a.Thumbnail), opt)
 	if err != nil {
 		return nil, err
 	}
@@ -105,7 +105,7 @@ func (d *Document) Send(b *Bot, to Recipient, opt *SendOptions) (*Message, error
 		params["disable_content_type_detection"] = "true"
 	}
 
-	msg, err := b.sendMedia(d, params, thumbnailToFilemap(d.Thumbnail))
+	msg, err := b.sendMedia(d, params, thumbnailToFilemap(d.Thumbnail), opt)
 	if err != nil {
 		return nil, err
 	}
@@ -131,7 +131,7 @@ func (s *Sticker) Send(b *Bot, to Recipient, opt *SendOptions) (*Message, error)
 	}
 	b.embedSendOptions(params, opt)
 
-	msg, err := b.sendMedia(s, params, nil)
+	msg, err := b.sendMedia(s, params, nil, opt)
 	if err != nil {
 		return nil, err
 	}
@@ -176,7 +176,7 @@ func (v *Video) Send(b *Bot, to Recipient, opt *SendOptions) (*Message, error) {
 		params["supports_streaming"] = "true"
 	}
 
-	msg, err := b.sendMedia(v, params, thumbnailToFilemap(v.Thumbnail))
+	msg, err := b.sendMedia(v, params, thumbnailToFilemap(v.Thumbnail), opt)
 	if err != nil {
 		return nil, err
 	}
@@ -235,7 +235,7 @@ func (a *Animation) Send(b *Bot, to Recipient, opt *SendOptions) (*Message, erro
 		params["file_name"] = filepath.Base(a.File.FileLocal)
 	}
 
-	msg, err := b.sendMedia(a, params, thumbnailToFilemap(a.Thumbnail))
+	msg, err := b.sendMedia(a, params, thumbnailToFilemap(a.Thumbnail), opt)
 	if err != nil {
 		return nil, err
 	}
@@ -268,7 +268,7 @@ func (v *Voice) Send(b *Bot, to Recipient, opt *SendOptions) (*Message, error) {
 		params["duration"] = strconv.Itoa(v.Duration)
 	}
 
-	msg, err := b.sendMedia(v, params, nil)
+	msg, err := b.sendMedia(v, params, nil, opt)
 	if err != nil {
 		return nil, err
 	}
@@ -293,7 +293,7 @@ func (v *VideoNote) Send(b *Bot, to Recipient, opt *SendOptions) (*Message, erro
 		params["length"] = strconv.Itoa(v.Length)
 	}
 
-	msg, err := b.sendMedia(v, params, thumbnailToFilemap(v.Thumbnail))
+	msg, err := b.sendMedia(v, params, thumbnailToFilemap(v.Thumbnail), opt)
 	if err != nil {
 		return nil, err
 	}
@@ -323,7 +323,7 @@ func (x *Location) Send(b *Bot, to Recipient, opt *SendOptions) (*Message, error
 	}
 	b.embedSendOptions(params, opt)
 
-	data, err := b.Raw("sendLocation", params)
+	data, err := b.RawContext(opt.context(), "sendLocation", params)
 	if err != nil {
 		return nil, err
 	}
@@ -346,7 +346,7 @@ func (v *Venue) Send(b *Bot, to Recipient, opt *SendOptions) (*Message, error) {
 	}
 	b.embedSendOptions(params, opt)
 
-	data, err := b.Raw("sendVenue", params)
+	data, err := b.RawContext(opt.context(), "sendVenue", params)
 	if err != nil {
 		return nil, err
 	}
@@ -360,7 +360,7 @@ func (i *Invoice) Send(b *Bot, to Recipient, opt *SendOptions) (*Message, error)
 	params["chat_id"] = to.Recipient()
 	b.embedSendOptions(params, opt)
 
-	data, err := b.Raw("sendInvoice", params)
+	data, err := b.RawContext(opt.context(), "sendInvoice", params)
 	if err != nil {
 		return nil, err
 	}
@@ -398,7 +398,7 @@ func (p *Poll) Send(b *Bot, to Recipient, opt *SendOptions) (*Message, error) {
 	opts, _ := json.Marshal(options)
 	params["options"] = string(opts)
 
-	data, err := b.Raw("sendPoll", params)
+	data, err := b.RawContext(opt.context(), "sendPoll", params)
 	if err != nil {
 		return nil, err
 	}
@@ -414,7 +414,7 @@ func (d *Dice) Send(b *Bot, to Recipient, opt *SendOptions) (*Message, error) {
 	}
 	b.embedSendOptions(params, opt)
 
-	data, err := b.Raw("sendDice", params)
+	data, err := b.RawContext(opt.context(), "sendDice", params)
 	if err != nil {
 		return nil, err
 	}
@@ -430,7 +430,7 @@ func (g *Game) Send(b *Bot, to Recipient, opt *SendOptions) (*Message, error) {
 	}
 	b.embedSendOptions(params, opt)
 
-	data, err := b.Raw("sendGame", params)
+	data, err := b.RawContext(opt.context(), "sendGame", params)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,450 @@
+package tgtest
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+
+	tele "github.com/graphomania/tg"
+)
+
+// Record is a line of a cassette: a call of the API or an update the bot got.
+type Record struct {
+	Kind   string            `json:"kind"`
+	Method string            `json:"method,omitempty"`
+	Params map[string]string `json:"params,omitempty"`
+
+	// Files are the sizes of the files uploaded, by the fields of the request.
+	Files map[string]int `json:"files,omitempty"`
+
+	Status   int             `json:"status,omitempty"`
+	Response json.RawMessage `json:"response,omitempty"`
+	Error    string          `json:"error,omitempty"`
+
+	Update json.RawMessage `json:"update,omitempty"`
+}
+
+// The kinds of the records.
+const (
+	KindCall   = "call"
+	KindUpdate = "update"
+)
+
+// redacted replaces the token in the cassettes.
+const redacted = "<token>"
+
+// DefaultVolatile are the params differing from run to run, see Replayer.Volatile.
+var DefaultVolatile = []string{"until_date", "expire_date", "close_date"}
+
+// Recorder writes the traffic of a bot into a cassette, a JSONL file of Records.
+// The calls are recorded by the transport of the bot's client, the updates by its poller,
+// while the getUpdates calls are left out. The token never makes it to the cassette.
+//
+// Example:
+//
+//	rec, _ := tgtest.NewRecorder("session.jsonl", token)
+//	defer rec.Close()
+//
+//	b, _ := tele.NewBot(tele.Settings{
+//		Token:  token,
+//		Client: rec.Client(nil),
+//		Poller: rec.Poller(&tele.LongPoller{Timeout: 10 * time.Second}),
+//	})
+type Recorder struct {
+	mu    sync.Mutex
+	file  *os.File
+	token string
+	next  http.RoundTripper
+}
+
+// NewRecorder creates the cassette, redacting the token in it.
+func NewRecorder(path, token string) (*Recorder, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	return &Recorder{file: f, token: token}, nil
+}
+
+// Close closes the cassette.
+func (r *Recorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.file.Close()
+}
+
+// Client returns the client recording the calls made with the given one, the default if nil.
+func (r *Recorder) Client(c *http.Client) *http.Client {
+	if c == nil {
+		c = &http.Client{}
+	}
+	recording := *c
+	r.next = c.Transport
+	if r.next == nil {
+		r.next = http.DefaultTransport
+	}
+	recording.Transport = r
+	return &recording
+}
+
+// Poller returns the poller recording the updates got by the given one.
+func (r *Recorder) Poller(p tele.Poller) tele.Poller {
+	return tele.NewMiddlewarePoller(p, func(u *tele.Update) bool {
+		data, err := json.Marshal(u)
+		if err == nil {
+			r.write(Record{Kind: KindUpdate, Update: data})
+		}
+		return true
+	})
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	method, ok := methodOf(req.URL.Path)
+	if !ok || method == "getUpdates" {
+		return r.next.RoundTrip(req)
+	}
+
+	var body []byte
+	if req.Body != nil {
+		var err error
+		if body, err = io.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+		req.Body.Close()
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	rec := Record{Kind: KindCall, Method: method}
+	if params, files, err := parseBody(req.Header, body); err == nil {
+		rec.Params = params
+		for field, content := range files {
+			if rec.Files == nil {
+				rec.Files = make(map[string]int)
+			}
+			rec.Files[field] = len(content)
+		}
+	}
+
+	resp, err := r.next.RoundTrip(req)
+	if err != nil {
+		rec.Error = err.Error()
+		r.write(rec)
+		return nil, err
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(data))
+	if err != nil {
+		rec.Error = err.Error()
+	}
+
+	rec.Status = resp.StatusCode
+	if json.Valid(data) {
+		rec.Response = data
+	} else {
+		// e.g. the page of the proxy in front of the API
+		rec.Response, _ = json.Marshal(string(data))
+	}
+
+	r.write(rec)
+	return resp, nil
+}
+
+func (r *Recorder) write(rec Record) {
+	line, err := json.Marshal(rec)
+	if err != nil {
+		return
+	}
+	if r.token != "" {
+		line = bytes.ReplaceAll(line, []byte(r.token), []byte(redacted))
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.file.Write(append(line, '\n'))
+}
+
+// Replayer plays a cassette back to a bot: it answers the calls of the bot
+// with the responses recorded and feeds it the updates recorded. An update comes
+// once the calls recorded before it are made, so the replay is deterministic.
+//
+// Example:
+//
+//	rp, _ := tgtest.NewReplayer("session.jsonl")
+//	b, _ := tele.NewBot(rp.Settings())
+//	// handlers
+//	go b.Start()
+//	<-rp.Done()
+//	b.Stop()
+type Replayer struct {
+	// Key, if set, matches the calls to the recorded ones by it,
+	// instead of by their order and params. The methods must match anyway.
+	Key func(method string, params map[string]string) string
+
+	// Volatile are the params left out, when the calls are matched by their order,
+	// since they differ from run to run. DefaultVolatile if nil.
+	Volatile []string
+
+	mu      sync.Mutex
+	records []Record
+	used    []bool
+	changed chan struct{} // closed and replaced once a record is replayed
+	done    chan struct{}
+	err     error
+}
+
+// NewReplayer reads the cassette.
+func NewReplayer(path string) (*Replayer, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var records []Record
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(nil, 64<<20)
+	for scanner.Scan() {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var rec Record
+		if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil {
+			return nil, fmt.Errorf("tgtest: bad cassette line %d: %w", len(records)+1, err)
+		}
+		records = append(records, rec)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	rp := &Replayer{
+		records: records,
+		used:    make([]bool, len(records)),
+		changed: make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	rp.check()
+	return rp, nil
+}
+
+// Settings returns the settings of a bot, which gets the cassette played back.
+func (rp *Replayer) Settings() tele.Settings {
+	return tele.Settings{
+		URL:    "http://replay.invalid",
+		Token:  redacted,
+		Client: &http.Client{Transport: rp},
+		Poller: rp,
+	}
+}
+
+// Done is closed once the whole cassette has been played back.
+func (rp *Replayer) Done() <-chan struct{} {
+	return rp.done
+}
+
+// Err returns the first call, which didn't match the cassette.
+func (rp *Replayer) Err() error {
+	rp.mu.Lock()
+	defer rp.mu.Unlock()
+	return rp.err
+}
+
+// Remaining returns the records not played back yet.
+func (rp *Replayer) Remaining() []Record {
+	rp.mu.Lock()
+	defer rp.mu.Unlock()
+
+	var left []Record
+	for i, rec := range rp.records {
+		if !rp.used[i] {
+			left = append(left, rec)
+		}
+	}
+	return left
+}
+
+// RoundTrip implements http.RoundTripper.
+func (rp *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	method, _ := methodOf(req.URL.Path)
+
+	var body []byte
+	if req.Body != nil {
+		var err error
+		if body, err = io.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+		req.Body.Close()
+	}
+	params, _, err := parseBody(req.Header, body)
+	if err != nil {
+		return nil, err
+	}
+
+	rp.mu.Lock()
+	defer rp.mu.Unlock()
+
+	var rec Record
+	if i := rp.match(method, params); i >= 0 {
+		rp.used[i] = true
+		rp.check()
+		rec = rp.records[i]
+	} else {
+		// answered as a bad request, so that it's not retried
+		desc := fmt.Sprintf("Bad Request: tgtest: %s %v isn't in the cassette", method, params)
+		if rp.err == nil {
+			rp.err = errors.New(desc)
+		}
+		rec.Status = http.StatusBadRequest
+		rec.Response, _ = json.Marshal(map[string]interface{}{
+			"ok":          false,
+			"error_code":  http.StatusBadRequest,
+			"description": desc,
+		})
+	}
+
+	if rec.Status == 0 {
+		return nil, errors.New(rec.Error)
+	}
+
+	data := []byte(rec.Response)
+	var page string
+	if json.Unmarshal(rec.Response, &page) == nil {
+		data = []byte(page)
+	}
+	return &http.Response{
+		Status:     http.StatusText(rec.Status),
+		StatusCode: rec.Status,
+		Proto:      "HTTP/1.1",
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header:     http.Header{"Content-Type": {"application/json"}},
+		Body:       io.NopCloser(bytes.NewReader(data)),
+		Request:    req,
+	}, nil
+}
+
+// match returns the index of the record answering the call, -1 if none does.
+// Must be called with mu locked.
+func (rp *Replayer) match(method string, params map[string]string) int {
+	for i, rec := range rp.records {
+		if rp.used[i] || rec.Kind != KindCall {
+			continue
+		}
+		if rp.Key == nil {
+			// the next call recorded must be the one
+			if rec.Method == method && rp.sameParams(rec.Params, params) {
+				return i
+			}
+			return -1
+		}
+		if rec.Method == method && rp.Key(rec.Method, rec.Params) == rp.Key(method, params) {
+			return i
+		}
+	}
+	return -1
+}
+
+// sameParams tells whether the params of the calls match, except the volatile ones.
+func (rp *Replayer) sameParams(recorded, params map[string]string) bool {
+	volatile := rp.Volatile
+	if volatile == nil {
+		volatile = DefaultVolatile
+	}
+	for _, m := range []map[string]string{recorded, params} {
+		for k := range m {
+			if recorded[k] != params[k] && !slices.Contains(volatile, k) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// check notifies about the record played back. Must be called with mu locked.
+func (rp *Replayer) check() {
+	close(rp.changed)
+	rp.changed = make(chan struct{})
+
+	for _, used := range rp.used {
+		if !used {
+			return
+		}
+	}
+	select {
+	case <-rp.done:
+	default:
+		close(rp.done)
+	}
+}
+
+// Poll implements tele.Poller, feeding the updates once the calls recorded before them are made.
+func (rp *Replayer) Poll(b *tele.Bot, dest chan tele.Update, stop chan struct{}) {
+	for i, rec := range rp.records {
+		if rec.Kind != KindUpdate {
+			continue
+		}
+
+		for {
+			rp.mu.Lock()
+			ready := rp.ready(i)
+			changed := rp.changed
+			rp.mu.Unlock()
+			if ready {
+				break
+			}
+
+			select {
+			case <-changed:
+			case <-stop:
+				return
+			}
+		}
+
+		var u tele.Update
+		if err := json.Unmarshal(rec.Update, &u); err != nil {
+			continue
+		}
+		select {
+		case dest <- u:
+		case <-stop:
+			return
+		}
+
+		rp.mu.Lock()
+		rp.used[i] = true
+		rp.check()
+		rp.mu.Unlock()
+	}
+
+	<-stop
+}
+
+// ready tells whether the records before the i-th one are played back. Must be called with mu locked.
+func (rp *Replayer) ready(i int) bool {
+	for _, used := range rp.used[:i] {
+		if !used {
+			return false
+		}
+	}
+	return true
+}
+
+// methodOf returns the method called by the path of the request, false for the files downloaded.
+func methodOf(path string) (string, bool) {
+	path = strings.TrimPrefix(path, "/")
+	if !strings.HasPrefix(path, "bot") {
+		return "", false
+	}
+	return path[strings.LastIndex(path, "/")+1:], true
+}
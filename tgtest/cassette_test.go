@@ -0,0 +1,133 @@
+package tgtest
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tele "github.com/graphomania/tg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// echo is the bot recorded and replayed, handling the updates in order.
+func echo(t *testing.T, pref tele.Settings) *tele.Bot {
+	pref.Synchronous = true
+	pref.RetryPolicy = &tele.Backoff{Retries: 1}
+	b, err := tele.NewBot(pref)
+	require.NoError(t, err)
+
+	b.Handle(tele.OnText, func(c tele.Context) error {
+		if err := c.Send(c.Text()); err != nil {
+			return err
+		}
+		return c.Send(&tele.Photo{File: tele.FromReader(strings.NewReader("jpeg"))})
+	})
+	return b
+}
+
+func TestCassette(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	path := filepath.Join(t.TempDir(), "session.jsonl")
+
+	rec, err := NewRecorder(path, srv.Token)
+	require.NoError(t, err)
+
+	pref := srv.Settings()
+	pref.Client = rec.Client(nil)
+	pref.Poller = rec.Poller(pref.Poller)
+	b := echo(t, pref)
+	go b.Start()
+
+	srv.Fail("sendMessage", 1, 502)
+	srv.Receive(&tele.Message{Chat: &tele.Chat{ID: 1}, Text: "hi"})
+	srv.Receive(&tele.Message{Chat: &tele.Chat{ID: 1}, Text: "bye"})
+	require.Eventually(t, func() bool {
+		return len(srv.Sent()) == 4
+	}, time.Second, 10*time.Millisecond)
+	b.Stop()
+	require.NoError(t, rec.Close())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), srv.Token)
+	assert.NotContains(t, string(data), "getUpdates")
+	// getMe, 2 updates, 2 texts with the one failed, 2 photos
+	assert.Len(t, strings.Split(strings.TrimSpace(string(data)), "\n"), 8)
+
+	// the replay is done offline
+	srv.Close()
+
+	rp, err := NewReplayer(path)
+	require.NoError(t, err)
+	b = echo(t, rp.Settings())
+	go b.Start()
+	defer b.Stop()
+
+	select {
+	case <-rp.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatalf("not replayed: %v", rp.Remaining())
+	}
+	assert.NoError(t, rp.Err())
+
+	// the calls not recorded fail
+	_, err = b.Send(&tele.Chat{ID: 1}, "more")
+	assert.Error(t, err)
+	assert.Error(t, rp.Err())
+}
+
+func TestCassetteKey(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session.jsonl")
+	require.NoError(t, os.WriteFile(path, []byte(strings.Join([]string{
+		`{"kind":"call","method":"getMe","status":200,"response":{"ok":true,"result":{"id":1,"is_bot":true}}}`,
+		`{"kind":"call","method":"sendMessage","params":{"chat_id":"1","text":"a"},"status":200,"response":{"ok":true,"result":{"message_id":1,"chat":{"id":1},"text":"a"}}}`,
+		`{"kind":"call","method":"sendMessage","params":{"chat_id":"2","text":"b"},"status":200,"response":{"ok":true,"result":{"message_id":1,"chat":{"id":2},"text":"b"}}}`,
+	}, "\n")), 0o600))
+
+	rp, err := NewReplayer(path)
+	require.NoError(t, err)
+	rp.Key = func(method string, params map[string]string) string {
+		return params["chat_id"]
+	}
+
+	b, err := tele.NewBot(rp.Settings())
+	require.NoError(t, err)
+
+	msg, err := b.Send(&tele.Chat{ID: 2}, "b")
+	require.NoError(t, err)
+	assert.Equal(t, "b", msg.Text)
+	msg, err = b.Send(&tele.Chat{ID: 1}, "a")
+	require.NoError(t, err)
+	assert.Equal(t, "a", msg.Text)
+
+	<-rp.Done()
+	assert.Empty(t, rp.Remaining())
+}
+
+func TestCassetteParams(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session.jsonl")
+	require.NoError(t, os.WriteFile(path, []byte(strings.Join([]string{
+		`{"kind":"call","method":"getMe","status":200,"response":{"ok":true,"result":{"id":1,"is_bot":true}}}`,
+		`{"kind":"call","method":"banChatMember","params":{"chat_id":"1","user_id":"2","until_date":"100"},"status":200,"response":{"ok":true,"result":true}}`,
+		`{"kind":"call","method":"sendMessage","params":{"chat_id":"1","text":"a"},"status":200,"response":{"ok":true,"result":{"message_id":1,"chat":{"id":1},"text":"a"}}}`,
+	}, "\n")), 0o600))
+
+	rp, err := NewReplayer(path)
+	require.NoError(t, err)
+	b, err := tele.NewBot(rp.Settings())
+	require.NoError(t, err)
+
+	// the dates differ from run to run
+	_, err = b.Raw("banChatMember", map[string]string{"chat_id": "1", "user_id": "2", "until_date": "200"})
+	require.NoError(t, err)
+
+	// the rest must match
+	_, err = b.Send(&tele.Chat{ID: 1}, "b")
+	assert.Error(t, err)
+	assert.ErrorContains(t, rp.Err(), "sendMessage")
+	assert.Len(t, rp.Remaining(), 1)
+}
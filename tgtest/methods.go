@@ -0,0 +1,297 @@
+package tgtest
+
+import (
+	"encoding/json"
+	"maps"
+	"strconv"
+	"strings"
+	"time"
+
+	tele "github.com/graphomania/tg"
+)
+
+// mediaFields are the fields of the files sent by the methods, which name the media of the messages.
+var mediaFields = map[string]string{
+	"sendPhoto":     "photo",
+	"sendDocument":  "document",
+	"sendVideo":     "video",
+	"sendAudio":     "audio",
+	"sendAnimation": "animation",
+	"sendVoice":     "voice",
+	"sendVideoNote": "video_note",
+	"sendSticker":   "sticker",
+}
+
+var errEditNotFound = tele.NewError(400, "Bad Request: message to edit not found")
+
+// call answers the method. Must be called with mu locked.
+func (s *Server) call(method string, params map[string]string, files map[string][]byte) (interface{}, error) {
+	switch method {
+	case "getMe":
+		return s.Me, nil
+	case "getChat":
+		c, err := s.chatOf(params)
+		if err != nil {
+			return nil, err
+		}
+		return c.info, nil
+	case "getFile":
+		f, ok := s.files[params["file_id"]]
+		if !ok {
+			return nil, tele.ErrWrongFileID
+		}
+		return f.file(), nil
+	case "sendChatAction", "answerCallbackQuery", "deleteWebhook":
+		return true, nil
+
+	case "sendMessage":
+		if params["text"] == "" {
+			return nil, tele.ErrEmptyText
+		}
+		return s.send(params, func(m message) error {
+			m["text"] = params["text"]
+			return nil
+		})
+	case "sendMediaGroup":
+		return s.sendMediaGroup(params, files)
+	case "deleteMessage":
+		c, err := s.chatOf(params)
+		if err != nil {
+			return nil, err
+		}
+		id, _ := strconv.Atoi(params["message_id"])
+		if !c.remove(id) {
+			return nil, tele.ErrNotFoundToDelete
+		}
+		return true, nil
+
+	case "editMessageText":
+		return s.edit(params, func(m message) error {
+			if m["text"] == params["text"] && markupOf(m) == params["reply_markup"] {
+				return tele.ErrSameMessageContent
+			}
+			m["text"] = params["text"]
+			setMarkup(m, params)
+			return nil
+		})
+	case "editMessageCaption":
+		return s.edit(params, func(m message) error {
+			setCaption(m, params)
+			setMarkup(m, params)
+			return nil
+		})
+	case "editMessageReplyMarkup":
+		return s.edit(params, func(m message) error {
+			setMarkup(m, params)
+			return nil
+		})
+	case "editMessageMedia":
+		var input tele.InputMedia
+		if err := json.Unmarshal([]byte(params["media"]), &input); err != nil {
+			return nil, tele.NewError(400, "Bad Request: can't parse input media")
+		}
+		f, err := s.resolve(input.Media, files)
+		if err != nil {
+			return nil, err
+		}
+		return s.edit(params, func(m message) error {
+			for _, field := range mediaFields {
+				delete(m, field)
+			}
+			m[input.Type] = media(input.Type, f)
+			setCaption(m, map[string]string{"caption": input.Caption})
+			setMarkup(m, params)
+			return nil
+		})
+	}
+
+	if field, ok := mediaFields[method]; ok {
+		ref, uploaded := params[field], files[field]
+		if uploaded != nil {
+			ref = "attach://" + field
+		}
+		f, err := s.resolve(ref, files)
+		if err != nil {
+			return nil, err
+		}
+		return s.send(params, func(m message) error {
+			m[field] = media(field, f)
+			setCaption(m, params)
+			return nil
+		})
+	}
+
+	return nil, tele.ErrNotFound
+}
+
+// send stores the message sent to the chat, filled by fill.
+func (s *Server) send(params map[string]string, fill func(message) error) (message, error) {
+	c, err := s.chatOf(params)
+	if err != nil {
+		return nil, err
+	}
+
+	m := message{
+		"message_id": c.lastID + 1,
+		"date":       time.Now().Unix(),
+		"chat":       c.info,
+		"from":       encode(s.Me),
+	}
+	if id, err := strconv.Atoi(params["message_thread_id"]); err == nil {
+		m["message_thread_id"] = id
+	}
+	if id, err := strconv.Atoi(params["reply_to_message_id"]); err == nil {
+		if replied := c.find(id); replied != nil {
+			m["reply_to_message"] = maps.Clone(replied)
+		}
+	}
+	setMarkup(m, params)
+
+	if err := fill(m); err != nil {
+		return nil, err
+	}
+
+	c.lastID++
+	c.messages = append(c.messages, m)
+	s.sent = append(s.sent, ref{chat: c.id, id: c.lastID})
+	return maps.Clone(m), nil
+}
+
+func (s *Server) sendMediaGroup(params map[string]string, files map[string][]byte) ([]message, error) {
+	var inputs []tele.InputMedia
+	if err := json.Unmarshal([]byte(params["media"]), &inputs); err != nil || len(inputs) == 0 {
+		return nil, tele.NewError(400, "Bad Request: can't parse media JSON object")
+	}
+
+	resolved := make([]*file, len(inputs))
+	for i, input := range inputs {
+		f, err := s.resolve(input.Media, files)
+		if err != nil {
+			return nil, err
+		}
+		resolved[i] = f
+	}
+
+	album := "album" + strconv.Itoa(len(s.sent)+1)
+	msgs := make([]message, len(inputs))
+	for i, input := range inputs {
+		m, err := s.send(params, func(m message) error {
+			m["media_group_id"] = album
+			m[input.Type] = media(input.Type, resolved[i])
+			setCaption(m, map[string]string{"caption": input.Caption})
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		msgs[i] = m
+	}
+	return msgs, nil
+}
+
+// edit changes the message with fill, returning it, or true for the inline ones.
+func (s *Server) edit(params map[string]string, fill func(message) error) (interface{}, error) {
+	if params["inline_message_id"] != "" {
+		return true, nil
+	}
+
+	c, err := s.chatOf(params)
+	if err != nil {
+		return nil, err
+	}
+	id, _ := strconv.Atoi(params["message_id"])
+	m := c.find(id)
+	if m == nil {
+		return nil, errEditNotFound
+	}
+
+	if err := fill(m); err != nil {
+		return nil, err
+	}
+	m["edit_date"] = time.Now().Unix()
+	return maps.Clone(m), nil
+}
+
+// resolve returns the file the request refers to: uploaded with it (attach://<field>),
+// sent before (its file_id) or to be fetched by telegram (URL).
+func (s *Server) resolve(ref string, files map[string][]byte) (*file, error) {
+	if field, ok := strings.CutPrefix(ref, "attach://"); ok {
+		content, ok := files[field]
+		if !ok {
+			return nil, tele.NewError(400, "Bad Request: file "+field+" not found in the request")
+		}
+		return s.addFile(content, ""), nil
+	}
+
+	switch {
+	case ref == "":
+		return nil, tele.NewError(400, "Bad Request: there is no file in the request")
+	case s.files[ref] != nil:
+		return s.files[ref], nil
+	case strings.HasPrefix(ref, "http://"), strings.HasPrefix(ref, "https://"):
+		return s.addFile(nil, ref), nil
+	default:
+		return nil, tele.ErrWrongFileID
+	}
+}
+
+// addFile stores the file. Must be called with mu locked.
+func (s *Server) addFile(content []byte, url string) *file {
+	f := &file{id: "file" + strconv.Itoa(len(s.files)+1), content: content, url: url}
+	s.files[f.id] = f
+	return f
+}
+
+// chatOf returns the chat of the request.
+func (s *Server) chatOf(params map[string]string) (*chat, error) {
+	id, err := strconv.ParseInt(params["chat_id"], 10, 64)
+	if err != nil {
+		return nil, tele.ErrChatNotFound
+	}
+	return s.chat(id), nil
+}
+
+func (c *chat) remove(id int) bool {
+	for i, m := range c.messages {
+		if m.id() == id {
+			c.messages = append(c.messages[:i], c.messages[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// media returns the object of the file, as the message has it in the field.
+func media(field string, f *file) interface{} {
+	obj := map[string]interface{}{
+		"file_id":        f.id,
+		"file_unique_id": "u" + f.id,
+		"file_size":      len(f.content),
+	}
+	if field == "photo" {
+		obj["width"], obj["height"] = 1, 1
+		return []interface{}{obj}
+	}
+	return obj
+}
+
+func setCaption(m message, params map[string]string) {
+	if caption := params["caption"]; caption != "" {
+		m["caption"] = caption
+	} else {
+		delete(m, "caption")
+	}
+}
+
+func setMarkup(m message, params map[string]string) {
+	if markup := params["reply_markup"]; markup != "" {
+		m["reply_markup"] = json.RawMessage(markup)
+	} else {
+		delete(m, "reply_markup")
+	}
+}
+
+func markupOf(m message) string {
+	markup, _ := m["reply_markup"].(json.RawMessage)
+	return string(markup)
+}
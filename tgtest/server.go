@@ -0,0 +1,554 @@
+// Package tgtest provides a fake Bot API server, so that the bots can be tested offline.
+//
+// The server keeps the chats, the messages and the files in memory,
+// answering the most used methods the way telegram does:
+// sending messages, albums and files, editing and deleting them, getting updates and files.
+// The other methods are answered with 404, as the unknown ones are.
+//
+// Example:
+//
+//	srv := tgtest.NewServer()
+//	defer srv.Close()
+//
+//	b, _ := tele.NewBot(srv.Settings())
+//	b.Handle("/start", func(c tele.Context) error {
+//		return c.Send("hello")
+//	})
+//	go b.Start()
+//	defer b.Stop()
+//
+//	srv.Receive(&tele.Message{Chat: &tele.Chat{ID: 1}, Text: "/start"})
+//	// ...
+//	srv.Sent() // [hello]
+//
+// The real sessions can be recorded into cassettes and played back, see Recorder and Replayer.
+package tgtest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	tele "github.com/graphomania/tg"
+)
+
+// DefaultToken is the token of the bot, which the server expects.
+const DefaultToken = "123456:TEST"
+
+// Server is a fake Bot API server.
+type Server struct {
+	*httptest.Server
+
+	// Token is the token of the bot served, the requests with other ones are unauthorized.
+	Token string
+
+	// Me is the bot, as returned by getMe.
+	Me tele.User
+
+	mu       sync.Mutex
+	closed   chan struct{}
+	updated  chan struct{} // closed and replaced once an update comes
+	updates  []json.RawMessage
+	updateID int
+	chats    map[int64]*chat
+	sent     []ref
+	calls    []Call
+	files    map[string]*file
+	failures []failure
+}
+
+// Call is a request the server got.
+type Call struct {
+	Method string
+	Params map[string]string
+
+	// Files are the contents uploaded, by the fields of the request.
+	Files map[string][]byte
+}
+
+type (
+	// message is stored as telegram would send it, so it's decoded as the bot sees it.
+	message map[string]interface{}
+
+	chat struct {
+		id       int64
+		info     map[string]interface{}
+		messages []message
+		lastID   int
+	}
+
+	ref struct {
+		chat int64
+		id   int
+	}
+
+	file struct {
+		id      string
+		content []byte
+		url     string
+	}
+
+	failure struct {
+		method     string
+		times      int
+		code       int
+		retryAfter int
+	}
+)
+
+// NewServer starts a fake Bot API server, which must be closed.
+func NewServer() *Server {
+	s := &Server{
+		Token:   DefaultToken,
+		Me:      tele.User{ID: 123456, FirstName: "Test", Username: "test_bot", IsBot: true},
+		closed:  make(chan struct{}),
+		updated: make(chan struct{}),
+		chats:   make(map[int64]*chat),
+		files:   make(map[string]*file),
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+	return s
+}
+
+// Close stops the server, cutting the long polls short.
+func (s *Server) Close() {
+	s.mu.Lock()
+	select {
+	case <-s.closed:
+	default:
+		close(s.closed)
+	}
+	s.mu.Unlock()
+
+	s.Server.Close()
+}
+
+// Settings returns the settings of a bot, which talks to the server.
+func (s *Server) Settings() tele.Settings {
+	return tele.Settings{
+		URL:    s.URL,
+		Token:  s.Token,
+		Poller: &tele.LongPoller{Timeout: time.Second},
+	}
+}
+
+// AddChat makes the chat known, e.g. as a group. The unknown chats are private ones.
+func (s *Server) AddChat(c tele.Chat) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.chat(c.ID).info = encode(c)
+}
+
+// AddFile stores the file, as if it's been sent to the bot.
+func (s *Server) AddFile(content []byte) tele.File {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.addFile(content, "").file()
+}
+
+// File returns the content of the file uploaded or added.
+func (s *Server) File(id string) ([]byte, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, ok := s.files[id]
+	if !ok {
+		return nil, false
+	}
+	return f.content, true
+}
+
+// Inject makes the update available to getUpdates, returning its ID.
+func (s *Server) Inject(u tele.Update) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.inject(u)
+}
+
+// Receive stores the message, as if it's been sent to the bot, and injects its update.
+// The message must have a chat, its ID and date are filled in,
+// as well as the sender of the private ones.
+func (s *Server) Receive(msg *tele.Message) *tele.Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.chats[msg.Chat.ID]; !ok && msg.Chat.Type != "" {
+		s.chats[msg.Chat.ID] = &chat{id: msg.Chat.ID, info: encode(msg.Chat)}
+	}
+	c := s.chat(msg.Chat.ID)
+	c.lastID++
+	msg.ID = c.lastID
+	msg.Unixtime = time.Now().Unix()
+	msg.Chat = decodeChat(c.info)
+	if msg.Sender == nil && msg.Chat.Type == tele.ChatPrivate {
+		msg.Sender = &tele.User{ID: msg.Chat.ID, FirstName: msg.Chat.FirstName, Username: msg.Chat.Username}
+	}
+
+	c.messages = append(c.messages, encode(msg))
+	s.inject(tele.Update{Message: msg})
+	return msg
+}
+
+// Sent returns the messages sent by the bot in order, as they're now.
+// The deleted ones are left out.
+func (s *Server) Sent() []*tele.Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var sent []*tele.Message
+	for _, r := range s.sent {
+		if m := s.chat(r.chat).find(r.id); m != nil {
+			sent = append(sent, decode(m))
+		}
+	}
+	return sent
+}
+
+// Messages returns the messages of the chat in order, both sent and received by the bot.
+func (s *Server) Messages(chatID int64) []*tele.Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var msgs []*tele.Message
+	for _, m := range s.chat(chatID).messages {
+		msgs = append(msgs, decode(m))
+	}
+	return msgs
+}
+
+// Calls returns the requests the server got for the methods, for all of them if none are given.
+func (s *Server) Calls(methods ...string) []Call {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var calls []Call
+	for _, c := range s.calls {
+		if len(methods) == 0 || slices.Contains(methods, c.Method) {
+			calls = append(calls, c)
+		}
+	}
+	return calls
+}
+
+// Flood makes the next n requests of the method, of any one if it's empty,
+// fail with 429 asking to retry after the seconds.
+func (s *Server) Flood(method string, n, retryAfter int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures = append(s.failures, failure{method: method, times: n, code: http.StatusTooManyRequests, retryAfter: retryAfter})
+}
+
+// Fail makes the next n requests of the method, of any one if it's empty,
+// fail with the status code, e.g. 500 or 502.
+func (s *Server) Fail(method string, n, code int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures = append(s.failures, failure{method: method, times: n, code: code})
+}
+
+func (s *Server) serve(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/")
+
+	if strings.HasPrefix(path, "file/") {
+		s.serveFile(w, r, path)
+		return
+	}
+
+	token, method, ok := strings.Cut(strings.TrimPrefix(path, "bot"), "/")
+	if !ok || token != s.Token {
+		reply(w, nil, tele.ErrUnauthorized)
+		return
+	}
+
+	params, files, err := parse(r)
+	if err != nil {
+		reply(w, nil, tele.NewError(http.StatusBadRequest, "Bad Request: "+err.Error()))
+		return
+	}
+
+	s.mu.Lock()
+	s.calls = append(s.calls, Call{Method: method, Params: params, Files: files})
+	if f := s.failure(method); f != nil {
+		s.mu.Unlock()
+		f.reply(w)
+		return
+	}
+	s.mu.Unlock()
+
+	if method == "getUpdates" {
+		s.getUpdates(w, r.Context(), params)
+		return
+	}
+
+	s.mu.Lock()
+	result, err := s.call(method, params, files)
+	s.mu.Unlock()
+
+	reply(w, result, err)
+}
+
+// serveFile serves the content of the file, ranges included.
+func (s *Server) serveFile(w http.ResponseWriter, r *http.Request, path string) {
+	token, path, _ := strings.Cut(strings.TrimPrefix(path, "file/bot"), "/")
+	if token != s.Token {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	s.mu.Lock()
+	f, ok := s.files[strings.TrimPrefix(path, "files/")]
+	s.mu.Unlock()
+
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	http.ServeContent(w, r, "", time.Time{}, bytes.NewReader(f.content))
+}
+
+// failure takes the failure due for the method, if any. Must be called with mu locked.
+func (s *Server) failure(method string) *failure {
+	for i := range s.failures {
+		f := &s.failures[i]
+		if f.method != "" && f.method != method {
+			continue
+		}
+
+		taken := *f
+		if f.times--; f.times <= 0 {
+			s.failures = append(s.failures[:i], s.failures[i+1:]...)
+		}
+		return &taken
+	}
+	return nil
+}
+
+func (f *failure) reply(w http.ResponseWriter) {
+	if f.code == http.StatusTooManyRequests {
+		w.WriteHeader(f.code)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"ok":          false,
+			"error_code":  f.code,
+			"description": "Too Many Requests: retry after " + strconv.Itoa(f.retryAfter),
+			"parameters":  map[string]int{"retry_after": f.retryAfter},
+		})
+		return
+	}
+
+	// the proxy in front of the API answers with no JSON
+	w.WriteHeader(f.code)
+	io.WriteString(w, "<html><body>"+http.StatusText(f.code)+"</body></html>")
+}
+
+func (s *Server) getUpdates(w http.ResponseWriter, ctx context.Context, params map[string]string) {
+	offset, _ := strconv.Atoi(params["offset"])
+	limit, _ := strconv.Atoi(params["limit"])
+	if limit <= 0 || limit > 100 {
+		limit = 100
+	}
+	timeout, _ := strconv.Atoi(params["timeout"])
+	deadline := time.After(time.Duration(timeout) * time.Second)
+
+	for {
+		s.mu.Lock()
+		// the updates before the offset are confirmed
+		for len(s.updates) > 0 && s.updateID-len(s.updates)+1 < offset {
+			s.updates = s.updates[1:]
+		}
+		updates := s.updates[:min(limit, len(s.updates))]
+		updated := s.updated
+		s.mu.Unlock()
+
+		if len(updates) > 0 {
+			reply(w, updates, nil)
+			return
+		}
+
+		select {
+		case <-updated:
+		case <-deadline:
+			reply(w, []json.RawMessage{}, nil)
+			return
+		case <-ctx.Done():
+			return
+		case <-s.closed:
+			reply(w, []json.RawMessage{}, nil)
+			return
+		}
+	}
+}
+
+// inject queues the update. Must be called with mu locked.
+func (s *Server) inject(u tele.Update) int {
+	s.updateID++
+	u.ID = s.updateID
+
+	data, _ := json.Marshal(u)
+	s.updates = append(s.updates, data)
+
+	close(s.updated)
+	s.updated = make(chan struct{})
+	return u.ID
+}
+
+// chat returns the chat, making it known. Must be called with mu locked.
+func (s *Server) chat(id int64) *chat {
+	c, ok := s.chats[id]
+	if !ok {
+		kind := tele.ChatPrivate
+		if id < 0 {
+			kind = tele.ChatSuperGroup
+		}
+		c = &chat{id: id, info: encode(tele.Chat{ID: id, Type: kind})}
+		s.chats[id] = c
+	}
+	return c
+}
+
+func (c *chat) find(id int) message {
+	for _, m := range c.messages {
+		if m.id() == id {
+			return m
+		}
+	}
+	return nil
+}
+
+func (m message) id() int {
+	switch id := m["message_id"].(type) {
+	case int:
+		return id
+	case float64: // decoded
+		return int(id)
+	}
+	return 0
+}
+
+func (f *file) file() tele.File {
+	return tele.File{
+		FileID:   f.id,
+		UniqueID: "u" + f.id,
+		FileSize: int64(len(f.content)),
+		FilePath: "files/" + f.id,
+	}
+}
+
+// reply writes the response as the API does.
+func reply(w http.ResponseWriter, result interface{}, err error) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if err != nil {
+		code := http.StatusBadRequest
+		desc := err.Error()
+		if e, ok := err.(*tele.Error); ok {
+			code, desc = e.Code, e.Description
+		}
+		w.WriteHeader(code)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"ok":          false,
+			"error_code":  code,
+			"description": desc,
+		})
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"ok":     true,
+		"result": result,
+	})
+}
+
+// parse returns the params of the request and the contents of the files uploaded.
+func parse(r *http.Request) (map[string]string, map[string][]byte, error) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+	return parseBody(r.Header, body)
+}
+
+func parseBody(header http.Header, body []byte) (map[string]string, map[string][]byte, error) {
+	params := make(map[string]string)
+	files := make(map[string][]byte)
+
+	ct, ctParams, _ := mime.ParseMediaType(header.Get("Content-Type"))
+	switch ct {
+	case "application/json":
+		var values map[string]json.RawMessage
+		if err := json.Unmarshal(body, &values); err != nil && len(bytes.TrimSpace(body)) > 0 {
+			return nil, nil, err
+		}
+		for k, v := range values {
+			var s string
+			if json.Unmarshal(v, &s) != nil {
+				s = string(v)
+			}
+			params[k] = s
+		}
+
+	case "multipart/form-data":
+		mr := multipart.NewReader(bytes.NewReader(body), ctParams["boundary"])
+		for {
+			part, err := mr.NextPart()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return nil, nil, err
+			}
+			data, err := io.ReadAll(part)
+			if err != nil {
+				return nil, nil, err
+			}
+			// the files uploaded without a name are told apart by their type
+			if part.FileName() != "" || part.Header.Get("Content-Type") == "application/octet-stream" {
+				files[part.FormName()] = data
+			} else {
+				params[part.FormName()] = string(data)
+			}
+		}
+
+	default:
+		values, err := url.ParseQuery(string(body))
+		if err != nil {
+			return nil, nil, err
+		}
+		for k, v := range values {
+			params[k] = v[0]
+		}
+	}
+
+	return params, files, nil
+}
+
+func encode(v interface{}) map[string]interface{} {
+	data, _ := json.Marshal(v)
+	var m map[string]interface{}
+	json.Unmarshal(data, &m)
+	return m
+}
+
+func decode(m message) *tele.Message {
+	data, _ := json.Marshal(m)
+	var msg tele.Message
+	json.Unmarshal(data, &msg)
+	return &msg
+}
+
+func decodeChat(info map[string]interface{}) *tele.Chat {
+	data, _ := json.Marshal(info)
+	var c tele.Chat
+	json.Unmarshal(data, &c)
+	return &c
+}
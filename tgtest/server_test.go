@@ -0,0 +1,134 @@
+package tgtest
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	tele "github.com/graphomania/tg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServer(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	b, err := tele.NewBot(srv.Settings())
+	require.NoError(t, err)
+	assert.Equal(t, "test_bot", b.Me.Username)
+
+	chat := &tele.Chat{ID: 1}
+	msg, err := b.Send(chat, "hi")
+	require.NoError(t, err)
+	assert.Equal(t, 1, msg.ID)
+	assert.Equal(t, tele.ChatPrivate, msg.Chat.Type)
+
+	msg, err = b.Edit(msg, "hello")
+	require.NoError(t, err)
+	assert.Equal(t, "hello", msg.Text)
+	_, err = b.Edit(msg, "hello")
+	assert.ErrorIs(t, err, tele.ErrSameMessageContent)
+
+	photo, err := b.Send(chat, &tele.Photo{File: tele.FromReader(strings.NewReader("jpeg")), Caption: "pic"})
+	require.NoError(t, err)
+	require.NotNil(t, photo.Photo)
+	assert.Equal(t, "pic", photo.Caption)
+
+	album, err := b.SendAlbum(chat, tele.Album{
+		&tele.Photo{File: tele.File{FileID: photo.Photo.FileID}},
+		&tele.Document{File: tele.FromReader(strings.NewReader("pdf")), FileName: "doc.pdf"},
+	})
+	require.NoError(t, err)
+	require.Len(t, album, 2)
+	assert.Equal(t, album[0].AlbumID, album[1].AlbumID)
+
+	require.NoError(t, b.Delete(&album[0]))
+	assert.ErrorIs(t, b.Delete(&album[0]), tele.ErrNotFoundToDelete)
+
+	sent := srv.Sent()
+	require.Len(t, sent, 3)
+	assert.Equal(t, "hello", sent[0].Text)
+	require.NotNil(t, sent[2].Document)
+
+	content, ok := srv.File(sent[2].Document.FileID)
+	require.True(t, ok)
+	assert.Equal(t, "pdf", string(content))
+
+	// the files are downloaded
+	reader, err := b.File(&tele.File{FileID: photo.Photo.FileID})
+	require.NoError(t, err)
+	defer reader.Close()
+	data, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	assert.Equal(t, "jpeg", string(data))
+
+	assert.Len(t, srv.Calls("sendMessage", "editMessageText"), 3)
+}
+
+func TestServerUpdates(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	b, err := tele.NewBot(srv.Settings())
+	require.NoError(t, err)
+
+	b.Handle("/start", func(c tele.Context) error {
+		return c.Reply("hello, " + c.Sender().FirstName)
+	})
+	go b.Start()
+	defer b.Stop()
+
+	in := srv.Receive(&tele.Message{
+		Chat: &tele.Chat{ID: 7, Type: tele.ChatPrivate, FirstName: "Ann"},
+		Text: "/start",
+	})
+
+	require.Eventually(t, func() bool {
+		return len(srv.Sent()) == 1
+	}, time.Second, 10*time.Millisecond)
+
+	out := srv.Sent()[0]
+	assert.Equal(t, "hello, Ann", out.Text)
+	require.NotNil(t, out.ReplyTo)
+	assert.Equal(t, in.ID, out.ReplyTo.ID)
+	assert.Len(t, srv.Messages(7), 2)
+}
+
+func TestServerFailures(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	pref := srv.Settings()
+	pref.RetryPolicy = &tele.Backoff{}
+	b, err := tele.NewBot(pref)
+	require.NoError(t, err)
+
+	srv.Flood("sendMessage", 1, 5)
+	_, err = b.Send(&tele.Chat{ID: 1}, "hi")
+	var flood tele.FloodError
+	require.ErrorAs(t, err, &flood)
+	assert.Equal(t, 5, flood.RetryAfter)
+
+	srv.Fail("", 2, http.StatusBadGateway)
+	_, err = b.Send(&tele.Chat{ID: 1}, "hi")
+	assert.Equal(t, tele.NewError(http.StatusBadGateway, "Bad Gateway"), err)
+	_, err = b.Send(&tele.Chat{ID: 1}, &tele.Photo{File: tele.FromReader(bytes.NewReader([]byte("jpeg")))})
+	assert.Error(t, err)
+
+	_, err = b.Send(&tele.Chat{ID: 1}, "hi")
+	require.NoError(t, err)
+
+	// the retries see the failures through
+	pref = srv.Settings()
+	pref.Retries = 1
+	b, err = tele.NewBot(pref)
+	require.NoError(t, err)
+	srv.Fail("sendMessage", 1, http.StatusInternalServerError)
+	_, err = b.Send(&tele.Chat{ID: 1}, "hi")
+	require.NoError(t, err)
+	assert.Len(t, srv.Sent(), 2)
+}
@@ -0,0 +1,52 @@
+package telebot
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// transports are the default transports by the URLs of the API, see DefaultTransport.
+var transports sync.Map
+
+// DefaultTransport returns the transport the bots use by default to talk to the API at url.
+// The bots sharing the URL share the transport, so the connections to the API are pooled
+// and kept alive across the requests. HTTP/2 is used where the server supports it.
+//
+// The transport keeps more idle connections to the API than http.DefaultTransport does,
+// as the bots send lots of concurrent requests to a single host.
+func DefaultTransport(url string) *http.Transport {
+	if t, ok := transports.Load(url); ok {
+		return t.(*http.Transport)
+	}
+	t, _ := transports.LoadOrStore(url, newTransport())
+	return t.(*http.Transport)
+}
+
+func newTransport() *http.Transport {
+	return &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          100,
+		MaxIdleConnsPerHost:   100,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: time.Second,
+	}
+}
+
+// maxDrain is how much of the body left unread is drained for the connection to be reused,
+// the connections with more left are closed instead.
+const maxDrain = 64 << 10
+
+// drain reads the rest of the body and closes it, so that its connection goes back to the pool.
+func drain(body io.ReadCloser) {
+	io.Copy(io.Discard, io.LimitReader(body, maxDrain))
+	body.Close()
+}
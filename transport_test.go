@@ -0,0 +1,102 @@
+package telebot
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// apiServer returns the server answering every request with a message, and 404 to the downloads,
+// counting the connections made to it.
+func apiServer(tb testing.TB, tls bool) (*httptest.Server, *atomic.Int64) {
+	var conns atomic.Int64
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/file/") {
+			http.Error(w, "Not Found", http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"ok":true,"result":{"message_id":1,"chat":{"id":1}}}`))
+	}))
+	srv.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			conns.Add(1)
+		}
+	}
+	if tls {
+		srv.StartTLS()
+	} else {
+		srv.Start()
+	}
+	tb.Cleanup(srv.Close)
+	return srv, &conns
+}
+
+func TestDefaultTransport(t *testing.T) {
+	assert.Same(t, DefaultTransport(DefaultApiURL), DefaultTransport(DefaultApiURL))
+	assert.NotSame(t, DefaultTransport(DefaultApiURL), DefaultTransport("http://localhost:8081"))
+
+	srv, conns := apiServer(t, false)
+	b, err := NewBot(Settings{URL: srv.URL, Offline: true})
+	require.NoError(t, err)
+	assert.Same(t, DefaultTransport(srv.URL), b.client.Transport)
+
+	for i := 0; i < 10; i++ {
+		_, err := b.Send(ChatID(1), "hi")
+		require.NoError(t, err)
+	}
+	assert.Equal(t, int64(1), conns.Load())
+
+	// the bodies left unread are drained
+	_, err = b.File(&File{FilePath: "a.jpg"})
+	require.Error(t, err)
+	_, err = b.Send(ChatID(1), "hi")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), conns.Load())
+}
+
+func BenchmarkRaw(b *testing.B) {
+	for _, bc := range []struct {
+		name      string
+		tls       bool
+		keepAlive bool
+	}{
+		{"http/keepalive", false, true},
+		{"http/close", false, false},
+		{"https/keepalive", true, true},
+		{"https/close", true, false},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			srv, conns := apiServer(b, bc.tls)
+
+			transport := srv.Client().Transport.(*http.Transport).Clone()
+			transport.MaxIdleConnsPerHost = 100
+			// as every connection was closed after the request before
+			transport.DisableKeepAlives = !bc.keepAlive
+
+			bot, err := NewBot(Settings{
+				URL:     srv.URL,
+				Offline: true,
+				Client:  &http.Client{Transport: transport, Timeout: time.Minute},
+			})
+			require.NoError(b, err)
+
+			params := map[string]string{"chat_id": "1", "text": "hi"}
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					if _, err := bot.Raw("sendMessage", params); err != nil {
+						b.Error(err)
+					}
+				}
+			})
+			b.ReportMetric(float64(conns.Load())/float64(b.N), "conns/op")
+		})
+	}
+}
@@ -312,6 +312,11 @@ func (b *Bot) ProcessUpdate(u Update) {
 
 func (b *Bot) handle(end string, c Context) bool {
 	if handler, ok := b.handlers[end]; ok {
+		if nc, ok := c.(*nativeContext); ok {
+			nc.lock.Lock()
+			nc.endpoint = end
+			nc.lock.Unlock()
+		}
 		b.runHandler(handler, c)
 		return true
 	}
@@ -3,7 +3,7 @@ package videoutil
 import (
 	"fmt"
 	telebot "github.com/graphomania/tg"
-	"log"
+	"log/slog"
 	"os"
 	"os/exec"
 	"time"
@@ -165,13 +165,16 @@ func Converted(opts ...*Opt) telebot.VideoModifier {
 	}
 }
 
-// Timed logs time each telebot.VideoModifier to complete the task. Could be useful for testing.
+// Timed logs time each telebot.VideoModifier to complete the task by slog.Default(). Could be useful for testing.
 func Timed(mods ...telebot.VideoModifier) telebot.VideoModifier {
 	return func(video *telebot.Video) (temporaries []string, err error) {
 		for _, mod := range mods {
 			start := time.Now()
 			temp, err := mod(video)
-			log.Printf("%s for %s took %s", getFunctionName(mod), video.FileName, time.Since(start).String())
+			slog.Info("videoutil: modified",
+				"modifier", getFunctionName(mod),
+				"file", video.FileName,
+				telebot.LogLatency, time.Since(start))
 			temporaries = append(temporaries, temp...)
 			if err != nil {
 				return temporaries, err
@@ -2,10 +2,15 @@ package telebot
 
 import (
 	"context"
+	"crypto/subtle"
+	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
+	"sync"
+	"time"
 )
 
 // A WebhookTLS specifies the path to a key and a cert so the poller can open
@@ -13,6 +18,22 @@ import (
 type WebhookTLS struct {
 	Key  string `json:"key"`
 	Cert string `json:"cert"`
+
+	// SelfSigned is the IP or the hostname to generate the self-signed certificate for.
+	// The certificate is cached in Key and Cert, and generated only if they don't have
+	// a valid one for it. It's uploaded to telegram and renewed RenewBefore its expiry.
+	// With the webhook served on a mux, use GetCertificate to serve the renewed ones.
+	SelfSigned string `json:"self_signed"`
+
+	// Validity is how long the certificates generated are valid, a year by default.
+	Validity time.Duration `json:"-"`
+
+	// RenewBefore is how long before the expiry the certificate is renewed,
+	// 30 days by default, but at most half of Validity.
+	RenewBefore time.Duration `json:"-"`
+
+	mu   sync.Mutex
+	cert *tls.Certificate
 }
 
 // A WebhookEndpoint describes the endpoint to which telegram will send its requests.
@@ -56,18 +77,53 @@ type Webhook struct {
 	TLS      *WebhookTLS
 	Endpoint *WebhookEndpoint
 
+	// Path is the only path the updates are accepted at, any if empty.
+	// It's appended to the URL of the webhook, unless there's the Endpoint.
+	Path string `json:"-"`
+
+	// HealthPath is the path of the health endpoint, answering 200 while
+	// the webhook accepts the updates and 503 otherwise. None if empty.
+	HealthPath string `json:"-"`
+
+	// MaxBodySize limits the size of the requests, 1 MB by default.
+	MaxBodySize int64 `json:"-"`
+
+	// ReadTimeout limits the time the request is read for, 10 seconds by default.
+	ReadTimeout time.Duration `json:"-"`
+
+	// QueueTimeout is how long the update waits for the bot to take it, 5 seconds by default.
+	// Telegram gets 503 once it's out and sends the update again later.
+	QueueTimeout time.Duration `json:"-"`
+
+	// DrainTimeout is how long the requests being served are waited for on Bot.Stop,
+	// 10 seconds by default.
+	DrainTimeout time.Duration `json:"-"`
+
 	dest chan<- Update
 	bot  *Bot
+
+	mu       sync.Mutex
+	running  bool
+	inflight sync.WaitGroup
 }
 
+// The defaults of the webhook settings.
+const (
+	DefaultWebhookMaxBodySize  = 1 << 20
+	DefaultWebhookReadTimeout  = 10 * time.Second
+	DefaultWebhookQueueTimeout = 5 * time.Second
+	DefaultWebhookDrainTimeout = 10 * time.Second
+)
+
 func (h *Webhook) getFiles() map[string]File {
 	m := make(map[string]File)
 
 	if h.TLS != nil {
 		m["certificate"] = FromDisk(h.TLS.Cert)
 	}
-	// check if it is overwritten by an endpoint
-	if h.Endpoint != nil {
+	// check if it is overwritten by an endpoint,
+	// the self-signed certificate is always the one telegram is to trust
+	if h.Endpoint != nil && !h.selfSigned() {
 		if h.Endpoint.Cert == "" {
 			// this can be the case if there is a loadbalancer or reverseproxy in
 			// front with a public cert. in this case we do not need to upload it
@@ -103,13 +159,13 @@ func (h *Webhook) getParams() map[string]string {
 	}
 
 	if h.TLS != nil {
-		params["url"] = "https://" + h.Listen
+		params["url"] = "https://" + h.addr() + h.Path
 	} else {
 		// this will not work with telegram, they want TLS
 		// but i allow this because telegram will send an error
 		// when you register this hook. in their docs they write
 		// that port 80/http is allowed ...
-		params["url"] = "http://" + h.Listen
+		params["url"] = "http://" + h.Listen + h.Path
 	}
 	if h.Endpoint != nil {
 		params["url"] = h.Endpoint.PublicURL
@@ -117,58 +173,193 @@ func (h *Webhook) getParams() map[string]string {
 	return params
 }
 
+// Poll sets the webhook and serves it on Listen, if it's set, until stop.
+// On stop, the webhook stops accepting the updates and waits for the requests
+// being served up to DrainTimeout. The errors of the listener go to Bot.OnError.
 func (h *Webhook) Poll(b *Bot, dest chan Update, stop chan struct{}) {
+	var renewAt time.Time
+	if h.selfSigned() {
+		var err error
+		if renewAt, err = h.TLS.loadCert(b.clock.Now()); err != nil {
+			b.OnError(err, nil)
+			<-stop
+			return
+		}
+	}
+
 	if err := b.SetWebhook(h); err != nil {
 		b.OnError(err, nil)
-		close(stop)
+		<-stop
 		return
 	}
 
 	// store the variables so the HTTP-handler can use 'em
+	h.mu.Lock()
 	h.dest = dest
 	h.bot = b
+	h.running = true
+	h.mu.Unlock()
+
+	if h.selfSigned() {
+		go h.renewCert(b, renewAt, stop)
+	}
 
 	if h.Listen == "" {
-		h.waitForStop(stop)
+		<-stop
+		h.drain()
 		return
 	}
 
 	s := &http.Server{
-		Addr:    h.Listen,
-		Handler: h,
+		Addr:              h.Listen,
+		Handler:           h,
+		ReadHeaderTimeout: h.readTimeout(),
+		ReadTimeout:       h.readTimeout(),
 	}
 
-	go func(stop chan struct{}) {
-		h.waitForStop(stop)
-		s.Shutdown(context.Background())
-	}(stop)
+	go func() {
+		var err error
+		if h.selfSigned() {
+			s.TLSConfig = &tls.Config{GetCertificate: h.TLS.GetCertificate}
+			err = s.ListenAndServeTLS("", "")
+		} else if h.TLS != nil {
+			err = s.ListenAndServeTLS(h.TLS.Cert, h.TLS.Key)
+		} else {
+			err = s.ListenAndServe()
+		}
+		if !errors.Is(err, http.ErrServerClosed) {
+			b.OnError(fmt.Errorf("telebot: webhook: %w", err), nil)
+		}
+	}()
 
-	if h.TLS != nil {
-		s.ListenAndServeTLS(h.TLS.Cert, h.TLS.Key)
-	} else {
-		s.ListenAndServe()
+	<-stop
+	h.drain()
+
+	ctx, cancel := context.WithTimeout(context.Background(), h.drainTimeout())
+	defer cancel()
+	if err := s.Shutdown(ctx); err != nil {
+		b.OnError(fmt.Errorf("telebot: webhook: %w", err), nil)
 	}
 }
 
-func (h *Webhook) waitForStop(stop chan struct{}) {
-	<-stop
-	close(stop)
+// drain stops accepting the updates and waits for the requests being served up to DrainTimeout.
+func (h *Webhook) drain() {
+	h.mu.Lock()
+	h.running = false
+	h.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		h.inflight.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(h.drainTimeout()):
+	}
 }
 
-// The handler simply reads the update from the body of the requests
-// and writes them to the update channel.
+// ServeHTTP reads the update from the body of the request and passes it to the bot.
+// It answers 401 to the requests without the secret token, 400 to the malformed ones,
+// 413 to the ones over MaxBodySize and 503 if the bot doesn't take the update in QueueTimeout.
 func (h *Webhook) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	if h.SecretToken != "" && r.Header.Get("X-Telegram-Bot-Api-Secret-Token") != h.SecretToken {
-		h.bot.debug(fmt.Errorf("invalid secret token in request"))
+	if h.HealthPath != "" && r.URL.Path == h.HealthPath {
+		h.serveHealth(w)
+		return
+	}
+	if h.Path != "" && r.URL.Path != h.Path {
+		http.NotFound(w, r)
+		return
+	}
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	h.mu.Lock()
+	if !h.running {
+		h.mu.Unlock()
+		http.Error(w, "webhook is not running", http.StatusServiceUnavailable)
+		return
+	}
+	h.inflight.Add(1)
+	dest, b := h.dest, h.bot
+	h.mu.Unlock()
+	defer h.inflight.Done()
+
+	secret := r.Header.Get("X-Telegram-Bot-Api-Secret-Token")
+	if h.SecretToken != "" && subtle.ConstantTimeCompare([]byte(secret), []byte(h.SecretToken)) != 1 {
+		b.debug(fmt.Errorf("telebot: webhook: invalid secret token in request"))
+		http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
 		return
 	}
 
 	var update Update
-	if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
-		h.bot.debug(fmt.Errorf("cannot decode update: %v", err))
+	body := http.MaxBytesReader(w, r.Body, h.maxBodySize())
+	if err := json.NewDecoder(body).Decode(&update); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			http.Error(w, http.StatusText(http.StatusRequestEntityTooLarge), http.StatusRequestEntityTooLarge)
+			return
+		}
+		b.debug(fmt.Errorf("telebot: webhook: cannot decode update: %w", err))
+		http.Error(w, "cannot decode update", http.StatusBadRequest)
+		return
+	}
+
+	timer := time.NewTimer(h.queueTimeout())
+	defer timer.Stop()
+
+	select {
+	case dest <- update:
+		w.WriteHeader(http.StatusOK)
+	case <-timer.C:
+		b.debug(fmt.Errorf("telebot: webhook: update %d is not taken in %v", update.ID, h.queueTimeout()))
+		http.Error(w, "bot is busy", http.StatusServiceUnavailable)
+	case <-r.Context().Done():
+	}
+}
+
+func (h *Webhook) serveHealth(w http.ResponseWriter) {
+	h.mu.Lock()
+	running := h.running
+	h.mu.Unlock()
+
+	if !running {
+		http.Error(w, "not running", http.StatusServiceUnavailable)
 		return
 	}
-	h.dest <- update
+	w.Write([]byte("ok"))
+}
+
+func (h *Webhook) maxBodySize() int64 {
+	if h.MaxBodySize > 0 {
+		return h.MaxBodySize
+	}
+	return DefaultWebhookMaxBodySize
+}
+
+func (h *Webhook) readTimeout() time.Duration {
+	if h.ReadTimeout > 0 {
+		return h.ReadTimeout
+	}
+	return DefaultWebhookReadTimeout
+}
+
+func (h *Webhook) queueTimeout() time.Duration {
+	if h.QueueTimeout > 0 {
+		return h.QueueTimeout
+	}
+	return DefaultWebhookQueueTimeout
+}
+
+func (h *Webhook) drainTimeout() time.Duration {
+	if h.DrainTimeout > 0 {
+		return h.DrainTimeout
+	}
+	return DefaultWebhookDrainTimeout
 }
 
 // Webhook returns the current webhook status.
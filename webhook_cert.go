@@ -0,0 +1,179 @@
+package telebot
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"time"
+)
+
+// The defaults of the self-signed certificates, see WebhookTLS.SelfSigned.
+const (
+	DefaultCertValidity    = 365 * 24 * time.Hour
+	DefaultCertRenewBefore = 30 * 24 * time.Hour
+)
+
+// certRetry is how long the failed renewal of the certificate is retried after.
+const certRetry = time.Hour
+
+// GenerateCert generates the self-signed certificate for the IP or the hostname,
+// valid from notBefore to notAfter. It returns the certificate and its key PEM-encoded,
+// as telegram expects them.
+func GenerateCert(host string, notBefore, notAfter time.Time) (certPEM, keyPEM []byte, err error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, nil, wrapError(err)
+	}
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, nil, wrapError(err)
+	}
+
+	tmpl := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: host},
+		NotBefore:             notBefore,
+		NotAfter:              notAfter,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		// self-signed, it's the root trusted by telegram
+		IsCA: true,
+	}
+	if ip := net.ParseIP(host); ip != nil {
+		tmpl.IPAddresses = []net.IP{ip}
+	} else {
+		tmpl.DNSNames = []string{host}
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		return nil, nil, wrapError(err)
+	}
+	certPEM = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM = pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	return certPEM, keyPEM, nil
+}
+
+// GetCertificate returns the current self-signed certificate, see tls.Config.GetCertificate.
+func (t *WebhookTLS) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.cert == nil {
+		return nil, errors.New("telebot: webhook certificate is not generated yet")
+	}
+	return t.cert, nil
+}
+
+// loadCert loads the certificate cached in Key and Cert, or generates a new one,
+// if there's no valid one for SelfSigned at now, or it's due to be renewed.
+// It returns the time the certificate is due to be renewed at.
+func (t *WebhookTLS) loadCert(now time.Time) (time.Time, error) {
+	cert, err := tls.LoadX509KeyPair(t.Cert, t.Key)
+	if err == nil {
+		cert.Leaf, err = x509.ParseCertificate(cert.Certificate[0])
+	}
+	if err == nil && cert.Leaf.VerifyHostname(t.SelfSigned) == nil && now.Before(t.renewAt(cert.Leaf)) {
+		t.setCert(&cert)
+		return t.renewAt(cert.Leaf), nil
+	}
+
+	// backdated a bit, in case the clock of telegram is behind
+	certPEM, keyPEM, err := GenerateCert(t.SelfSigned, now.Add(-time.Hour), now.Add(t.validity()))
+	if err != nil {
+		return time.Time{}, err
+	}
+	if err := writeFileAtomic(t.Key, keyPEM, 0o600); err != nil {
+		return time.Time{}, err
+	}
+	if err := writeFileAtomic(t.Cert, certPEM, 0o644); err != nil {
+		return time.Time{}, err
+	}
+
+	cert, err = tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return time.Time{}, wrapError(err)
+	}
+	if cert.Leaf, err = x509.ParseCertificate(cert.Certificate[0]); err != nil {
+		return time.Time{}, wrapError(err)
+	}
+	t.setCert(&cert)
+	return t.renewAt(cert.Leaf), nil
+}
+
+func (t *WebhookTLS) setCert(cert *tls.Certificate) {
+	t.mu.Lock()
+	t.cert = cert
+	t.mu.Unlock()
+}
+
+func (t *WebhookTLS) renewAt(leaf *x509.Certificate) time.Time {
+	return leaf.NotAfter.Add(-t.renewBefore())
+}
+
+func (t *WebhookTLS) validity() time.Duration {
+	if t.Validity > 0 {
+		return t.Validity
+	}
+	return DefaultCertValidity
+}
+
+func (t *WebhookTLS) renewBefore() time.Duration {
+	d := DefaultCertRenewBefore
+	if t.RenewBefore > 0 {
+		d = t.RenewBefore
+	}
+	// so that the new certificate isn't due at once
+	return min(d, t.validity()/2)
+}
+
+// selfSigned tells whether the webhook generates its certificate.
+func (h *Webhook) selfSigned() bool {
+	return h.TLS != nil && h.TLS.SelfSigned != ""
+}
+
+// addr returns the address of the webhook for its URL, which is
+// the host of the self-signed certificate with the port of Listen, if there's one.
+func (h *Webhook) addr() string {
+	if !h.selfSigned() {
+		return h.Listen
+	}
+	_, port, err := net.SplitHostPort(h.Listen)
+	if err != nil || port == "" {
+		return h.TLS.SelfSigned
+	}
+	return net.JoinHostPort(h.TLS.SelfSigned, port)
+}
+
+// renewCert renews the self-signed certificate at renewAt and uploads it to telegram,
+// until stop. The failed renewals go to Bot.OnError and are retried in an hour.
+func (h *Webhook) renewCert(b *Bot, renewAt time.Time, stop chan struct{}) {
+	for {
+		select {
+		case <-b.clock.After(renewAt.Sub(b.clock.Now())):
+		case <-stop:
+			return
+		}
+
+		next, err := h.TLS.loadCert(b.clock.Now())
+		if err == nil {
+			// the pending updates are dropped on start only
+			params := h.getParams()
+			delete(params, "drop_pending_updates")
+			_, err = b.sendFiles("setWebhook", h.getFiles(), params)
+		}
+		if err != nil {
+			b.OnError(fmt.Errorf("telebot: renewing webhook certificate: %w", err), nil)
+			next = b.clock.Now().Add(certRetry)
+		}
+		renewAt = next
+	}
+}
@@ -0,0 +1,172 @@
+package telebot
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/graphomania/tg/clock"
+)
+
+func TestGenerateCert(t *testing.T) {
+	now := time.Now()
+	for _, host := range []string{"127.0.0.1", "example.com"} {
+		certPEM, keyPEM, err := GenerateCert(host, now, now.Add(time.Hour))
+		require.NoError(t, err)
+
+		cert, err := tls.X509KeyPair(certPEM, keyPEM)
+		require.NoError(t, err)
+		leaf, err := x509.ParseCertificate(cert.Certificate[0])
+		require.NoError(t, err)
+
+		assert.NoError(t, leaf.VerifyHostname(host))
+		assert.Equal(t, host, leaf.Subject.CommonName)
+		assert.NoError(t, leaf.CheckSignatureFrom(leaf))
+	}
+}
+
+// setWebhook is the request setting the webhook.
+type setWebhook struct{ url, cert, drop string }
+
+// setWebhookServer returns the API server, which sends the webhooks set to the channel.
+func setWebhookServer(t *testing.T) (*httptest.Server, chan setWebhook) {
+	set := make(chan setWebhook, 10)
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/setWebhook") {
+			require.NoError(t, r.ParseMultipartForm(1<<20))
+			// sent without the file name, so it's a value
+			set <- setWebhook{
+				url:  r.FormValue("url"),
+				cert: r.FormValue("certificate"),
+				drop: r.FormValue("drop_pending_updates"),
+			}
+		}
+		w.Write([]byte(`{"ok":true,"result":true}`))
+	}))
+	t.Cleanup(api.Close)
+	return api, set
+}
+
+func TestWebhookSelfSigned(t *testing.T) {
+	api, set := setWebhookServer(t)
+
+	fake := clock.NewFake(time.Now())
+	b, err := NewBot(Settings{URL: api.URL, Offline: true, Clock: fake})
+	require.NoError(t, err)
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	_, port, _ := net.SplitHostPort(l.Addr().String())
+	l.Close()
+
+	dir := t.TempDir()
+	h := &Webhook{
+		Listen:      ":" + port,
+		Path:        "/hook",
+		DropUpdates: true,
+		TLS: &WebhookTLS{
+			Key:         filepath.Join(dir, "key.pem"),
+			Cert:        filepath.Join(dir, "cert.pem"),
+			SelfSigned:  "127.0.0.1",
+			Validity:    48 * time.Hour,
+			RenewBefore: 12 * time.Hour,
+		},
+	}
+
+	// served is the certificate the webhook serves
+	served := func() []byte {
+		conn, err := tls.Dial("tcp", "127.0.0.1:"+port, &tls.Config{InsecureSkipVerify: true})
+		if err != nil {
+			return nil
+		}
+		defer conn.Close()
+		return conn.ConnectionState().PeerCertificates[0].Raw
+	}
+	readCert := func() string { return readFile(t, h.TLS.Cert) }
+	cached := func() []byte {
+		cert, err := tls.LoadX509KeyPair(h.TLS.Cert, h.TLS.Key)
+		require.NoError(t, err)
+		return cert.Certificate[0]
+	}
+
+	stop, done := make(chan struct{}), make(chan struct{})
+	go func() {
+		h.Poll(b, make(chan Update), stop)
+		close(done)
+	}()
+
+	first := <-set
+	assert.Equal(t, "https://127.0.0.1:"+port+"/hook", first.url)
+	assert.Equal(t, "true", first.drop)
+	assert.Equal(t, readCert(), first.cert)
+	require.Eventually(t, func() bool { return served() != nil }, time.Second, 10*time.Millisecond)
+	assert.Equal(t, cached(), served())
+
+	// the valid certificate is reused
+	renewAt, err := h.TLS.loadCert(fake.Now())
+	require.NoError(t, err)
+	assert.Equal(t, first.cert, readCert())
+
+	// and renewed before it expires
+	fake.BlockUntil(1)
+	fake.Advance(renewAt.Sub(fake.Now()))
+	second := <-set
+	assert.NotEqual(t, first.cert, second.cert)
+	assert.Empty(t, second.drop, "pending updates are kept on renewal")
+	assert.Equal(t, second.cert, readCert())
+	assert.Equal(t, cached(), served())
+
+	close(stop)
+	<-done
+}
+
+func TestWebhookRouterSelfSigned(t *testing.T) {
+	api, set := setWebhookServer(t)
+	b, err := NewBot(Settings{URL: api.URL, Offline: true})
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	h := &Webhook{
+		Path: "/hook",
+		TLS: &WebhookTLS{
+			Key:        filepath.Join(dir, "key.pem"),
+			Cert:       filepath.Join(dir, "cert.pem"),
+			SelfSigned: "127.0.0.1",
+		},
+	}
+	router := NewWebhookRouter("https://127.0.0.1:8443")
+	require.NoError(t, router.Add(b, h))
+
+	stop, done := make(chan struct{}), make(chan struct{})
+	go func() {
+		h.Poll(b, make(chan Update), stop)
+		close(done)
+	}()
+
+	got := <-set
+	assert.Equal(t, "https://127.0.0.1:8443/hook", got.url)
+	assert.Equal(t, readFile(t, h.TLS.Cert), got.cert)
+
+	cert, err := h.TLS.GetCertificate(nil)
+	require.NoError(t, err)
+	assert.NotNil(t, cert)
+
+	close(stop)
+	<-done
+}
+
+func readFile(t *testing.T, path string) string {
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	return string(data)
+}
@@ -0,0 +1,157 @@
+package telebot
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// WebhookRouter serves the webhooks of many bots on a single HTTP server.
+// The bots are told apart by the paths of their webhooks, or by their secret
+// tokens, if they share a path. It's an http.Handler, so it's either served
+// on its own or mounted into an existing mux.
+//
+// Example:
+//
+//	router := tele.NewWebhookRouter("https://example.com/tg")
+//	router.Add(shop, &tele.Webhook{Path: "/shop", SecretToken: shopSecret})
+//	router.Add(support, &tele.Webhook{Path: "/support", SecretToken: supportSecret})
+//
+//	go shop.Start()
+//	go support.Start()
+//	http.ListenAndServe(":8080", router)
+//
+// With the router mounted under a prefix, strip it, so that the paths match:
+//
+//	mux.Handle("/tg/", http.StripPrefix("/tg", router))
+type WebhookRouter struct {
+	// PublicURL is the URL the router is reached at by telegram,
+	// the paths of the webhooks are appended to it.
+	PublicURL string
+
+	// HealthPath is the path of the health endpoint of the router, answering 200
+	// while all the webhooks accept the updates and 503 otherwise. None if empty.
+	HealthPath string
+
+	mu    sync.RWMutex
+	paths map[string][]*Webhook
+}
+
+// NewWebhookRouter creates the router reached at publicURL.
+func NewWebhookRouter(publicURL string) *WebhookRouter {
+	return &WebhookRouter{
+		PublicURL: strings.TrimSuffix(publicURL, "/"),
+		paths:     make(map[string][]*Webhook),
+	}
+}
+
+// Add makes the webhook the poller of the bot and serves it on the router.
+// The webhook is set on Bot.Start with the URL of the router and its Path,
+// unless it has the Endpoint. The webhooks sharing a path must have
+// distinct secret tokens, and the webhook must not Listen on its own.
+func (r *WebhookRouter) Add(b *Bot, h *Webhook) error {
+	if h.Listen != "" {
+		return errors.New("telebot: webhook of the router must not listen on its own")
+	}
+	if h.Path != "" && !strings.HasPrefix(h.Path, "/") {
+		return fmt.Errorf("telebot: webhook path %q must start with /", h.Path)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	shared := r.paths[h.Path]
+	for _, other := range shared {
+		if other == h {
+			return errors.New("telebot: webhook is already added")
+		}
+		if h.SecretToken == "" || other.SecretToken == "" || h.SecretToken == other.SecretToken {
+			return fmt.Errorf("telebot: webhooks sharing path %q must have distinct secret tokens", h.Path)
+		}
+	}
+
+	if h.Endpoint == nil {
+		h.Endpoint = &WebhookEndpoint{PublicURL: r.PublicURL + h.Path}
+	}
+	r.paths[h.Path] = append(shared, h)
+	b.Poller = h
+	return nil
+}
+
+// Remove stops serving the webhook. It's to be removed from telegram
+// with Bot.RemoveWebhook separately.
+func (r *WebhookRouter) Remove(h *Webhook) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	hooks := r.paths[h.Path]
+	for i, other := range hooks {
+		if other == h {
+			hooks = append(hooks[:i:i], hooks[i+1:]...)
+			break
+		}
+	}
+	if len(hooks) == 0 {
+		delete(r.paths, h.Path)
+	} else {
+		r.paths[h.Path] = hooks
+	}
+}
+
+// ServeHTTP passes the request to the webhook of the bot it's sent to.
+// It answers 404 to the unknown paths, and 401 if no webhook sharing the path
+// has the secret token of the request.
+func (r *WebhookRouter) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if r.HealthPath != "" && req.URL.Path == r.HealthPath {
+		r.serveHealth(w)
+		return
+	}
+
+	r.mu.RLock()
+	hooks, ok := r.paths[req.URL.Path]
+	if !ok {
+		// the webhooks accepting the updates at any path
+		hooks, ok = r.paths[""]
+	}
+	r.mu.RUnlock()
+	if !ok {
+		http.NotFound(w, req)
+		return
+	}
+
+	if len(hooks) == 1 {
+		hooks[0].ServeHTTP(w, req)
+		return
+	}
+
+	secret := []byte(req.Header.Get("X-Telegram-Bot-Api-Secret-Token"))
+	for _, h := range hooks {
+		if subtle.ConstantTimeCompare(secret, []byte(h.SecretToken)) == 1 {
+			h.ServeHTTP(w, req)
+			return
+		}
+	}
+	http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+}
+
+func (r *WebhookRouter) serveHealth(w http.ResponseWriter) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, hooks := range r.paths {
+		for _, h := range hooks {
+			h.mu.Lock()
+			running := h.running
+			h.mu.Unlock()
+
+			if !running {
+				http.Error(w, "not running", http.StatusServiceUnavailable)
+				return
+			}
+		}
+	}
+	w.Write([]byte("ok"))
+}
@@ -0,0 +1,118 @@
+package telebot
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookRouter(t *testing.T) {
+	var (
+		mu   sync.Mutex
+		urls []string
+	)
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/setWebhook") {
+			var params struct {
+				URL string `json:"url"`
+			}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&params))
+			mu.Lock()
+			urls = append(urls, params.URL)
+			mu.Unlock()
+		}
+		w.Write([]byte(`{"ok":true,"result":true}`))
+	}))
+	defer api.Close()
+
+	newBot := func() *Bot {
+		b, err := NewBot(Settings{URL: api.URL, Offline: true})
+		require.NoError(t, err)
+		return b
+	}
+
+	router := NewWebhookRouter("https://example.com/tg/")
+	router.HealthPath = "/health"
+
+	shop, support, admin := newBot(), newBot(), newBot()
+	shopHook := &Webhook{Path: "/shop"}
+	supportHook := &Webhook{Path: "/support", SecretToken: "support"}
+	adminHook := &Webhook{Path: "/support", SecretToken: "admin"}
+	require.NoError(t, router.Add(shop, shopHook))
+	require.NoError(t, router.Add(support, supportHook))
+	require.NoError(t, router.Add(admin, adminHook))
+	assert.Equal(t, shopHook, shop.Poller)
+
+	assert.Error(t, router.Add(newBot(), &Webhook{Path: "/support", SecretToken: "admin"}))
+	assert.Error(t, router.Add(newBot(), &Webhook{Path: "/support"}))
+	assert.Error(t, router.Add(newBot(), &Webhook{Path: "/other", Listen: ":8443"}))
+	assert.Error(t, router.Add(newBot(), &Webhook{Path: "other"}))
+
+	srv := httptest.NewServer(http.StripPrefix("/tg", router))
+	defer srv.Close()
+
+	post := func(path, secret, body string) int {
+		req, _ := http.NewRequest(http.MethodPost, srv.URL+"/tg"+path, strings.NewReader(body))
+		req.Header.Set("X-Telegram-Bot-Api-Secret-Token", secret)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+	health := func() int {
+		resp, err := http.Get(srv.URL + "/tg/health")
+		require.NoError(t, err)
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	assert.Equal(t, http.StatusServiceUnavailable, health())
+
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	poll := func(b *Bot, h *Webhook) chan Update {
+		dest := make(chan Update, 1)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			h.Poll(b, dest, stop)
+		}()
+		return dest
+	}
+	shopUpdates := poll(shop, shopHook)
+	supportUpdates := poll(support, supportHook)
+	adminUpdates := poll(admin, adminHook)
+	require.Eventually(t, func() bool { return health() == http.StatusOK }, time.Second, time.Millisecond)
+
+	mu.Lock()
+	assert.ElementsMatch(t, []string{
+		"https://example.com/tg/shop",
+		"https://example.com/tg/support",
+		"https://example.com/tg/support",
+	}, urls)
+	mu.Unlock()
+
+	assert.Equal(t, http.StatusOK, post("/shop", "", `{"update_id":1}`))
+	assert.Equal(t, http.StatusOK, post("/support", "support", `{"update_id":2}`))
+	assert.Equal(t, http.StatusOK, post("/support", "admin", `{"update_id":3}`))
+	assert.Equal(t, 1, (<-shopUpdates).ID)
+	assert.Equal(t, 2, (<-supportUpdates).ID)
+	assert.Equal(t, 3, (<-adminUpdates).ID)
+
+	assert.Equal(t, http.StatusUnauthorized, post("/support", "wrong", `{"update_id":4}`))
+	assert.Equal(t, http.StatusNotFound, post("/other", "", `{"update_id":4}`))
+
+	router.Remove(shopHook)
+	assert.Equal(t, http.StatusNotFound, post("/shop", "", `{"update_id":4}`))
+
+	close(stop)
+	wg.Wait()
+	assert.Equal(t, http.StatusServiceUnavailable, health())
+}
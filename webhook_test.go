@@ -0,0 +1,212 @@
+package telebot
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// webhookBot returns the bot, which API takes any webhook set.
+func webhookBot(t *testing.T, onError func(error, Context)) *Bot {
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"ok":true,"result":true}`))
+	}))
+	t.Cleanup(api.Close)
+
+	b, err := NewBot(Settings{URL: api.URL, Offline: true, OnError: onError})
+	require.NoError(t, err)
+	return b
+}
+
+func TestWebhook(t *testing.T) {
+	b := webhookBot(t, nil)
+	h := &Webhook{
+		SecretToken:  "secret",
+		Path:         "/hook",
+		HealthPath:   "/health",
+		MaxBodySize:  100,
+		QueueTimeout: 20 * time.Millisecond,
+	}
+	srv := httptest.NewServer(h)
+	defer srv.Close()
+
+	post := func(path, secret, body string) int {
+		req, _ := http.NewRequest(http.MethodPost, srv.URL+path, strings.NewReader(body))
+		req.Header.Set("X-Telegram-Bot-Api-Secret-Token", secret)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+	health := func() int {
+		resp, err := http.Get(srv.URL + "/health")
+		require.NoError(t, err)
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	assert.Equal(t, http.StatusServiceUnavailable, post("/hook", "secret", `{"update_id":1}`))
+
+	dest, stop, done := make(chan Update, 1), make(chan struct{}), make(chan struct{})
+	go func() {
+		h.Poll(b, dest, stop)
+		close(done)
+	}()
+	require.Eventually(t, func() bool { return health() == http.StatusOK }, time.Second, time.Millisecond)
+
+	resp, err := http.Get(srv.URL + "/hook")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+
+	assert.Equal(t, http.StatusNotFound, post("/other", "secret", `{"update_id":1}`))
+	assert.Equal(t, http.StatusUnauthorized, post("/hook", "wrong", `{"update_id":1}`))
+	assert.Equal(t, http.StatusBadRequest, post("/hook", "secret", `{"update_id":`))
+	assert.Equal(t, http.StatusRequestEntityTooLarge, post("/hook", "secret", `{"update_id":1,"x":"`+strings.Repeat("x", 100)+`"}`))
+
+	assert.Equal(t, http.StatusOK, post("/hook", "secret", `{"update_id":1}`))
+	assert.Equal(t, 1, (<-dest).ID)
+
+	// the bot doesn't take the updates
+	assert.Equal(t, http.StatusOK, post("/hook", "secret", `{"update_id":2}`))
+	assert.Equal(t, http.StatusServiceUnavailable, post("/hook", "secret", `{"update_id":3}`))
+
+	close(stop)
+	<-done
+	assert.Equal(t, http.StatusServiceUnavailable, health())
+	assert.Equal(t, http.StatusServiceUnavailable, post("/hook", "secret", `{"update_id":4}`))
+}
+
+func TestWebhookListen(t *testing.T) {
+	busy, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer busy.Close()
+
+	errs := make(chan error, 1)
+	b := webhookBot(t, func(err error, c Context) { errs <- err })
+
+	// the address is taken
+	h := &Webhook{Listen: busy.Addr().String()}
+	stop, done := make(chan struct{}), make(chan struct{})
+	go func() {
+		h.Poll(b, make(chan Update), stop)
+		close(done)
+	}()
+	assert.ErrorContains(t, <-errs, "address already in use")
+	close(stop)
+	<-done
+
+	addr := busy.Addr().String()
+	busy.Close()
+
+	h = &Webhook{Listen: addr, Path: "/hook"}
+	dest := make(chan Update, 1)
+	stop, done = make(chan struct{}), make(chan struct{})
+	go func() {
+		h.Poll(b, dest, stop)
+		close(done)
+	}()
+
+	var resp *http.Response
+	require.Eventually(t, func() bool {
+		resp, err = http.Post("http://"+addr+"/hook", "application/json", strings.NewReader(`{"update_id":1}`))
+		return err == nil
+	}, time.Second, 5*time.Millisecond)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, 1, (<-dest).ID)
+
+	close(stop)
+	<-done
+	select {
+	case err := <-errs:
+		t.Fatal(err)
+	default:
+	}
+}
+
+func TestWebhookDrain(t *testing.T) {
+	b := webhookBot(t, nil)
+	h := &Webhook{HealthPath: "/health"}
+	b.Poller = h
+
+	handled := make(chan int, 1)
+	b.Handle(OnText, func(c Context) error {
+		handled <- c.Update().ID
+		return nil
+	})
+
+	// reading signals the request is in flight
+	reading := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			r.Body = &signalReader{ReadCloser: r.Body, read: reading}
+		}
+		h.ServeHTTP(w, r)
+	}))
+	defer srv.Close()
+
+	go b.Start()
+	require.Eventually(t, func() bool {
+		resp, err := http.Get(srv.URL + "/health")
+		require.NoError(t, err)
+		resp.Body.Close()
+		return resp.StatusCode == http.StatusOK
+	}, time.Second, time.Millisecond)
+
+	// the request is being read, when the bot stops
+	body, write := io.Pipe()
+	status := make(chan int, 1)
+	go func() {
+		resp, err := http.Post(srv.URL, "application/json", body)
+		require.NoError(t, err)
+		resp.Body.Close()
+		status <- resp.StatusCode
+	}()
+	write.Write([]byte(`{"update_id":7,`))
+	<-reading
+
+	stopped := make(chan struct{})
+	go func() {
+		b.Stop()
+		close(stopped)
+	}()
+	require.Eventually(t, func() bool {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		return !h.running
+	}, time.Second, time.Millisecond)
+
+	write.Write([]byte(`"message":{"message_id":1,"chat":{"id":1},"text":"hi"}}`))
+	write.Close()
+	<-stopped
+
+	// acknowledged, so it's handled
+	require.Equal(t, http.StatusOK, <-status)
+	select {
+	case id := <-handled:
+		assert.Equal(t, 7, id)
+	default:
+		t.Fatal("update acknowledged during the drain is lost")
+	}
+}
+
+// signalReader closes read on the first read.
+type signalReader struct {
+	io.ReadCloser
+	read chan struct{}
+	once sync.Once
+}
+
+func (r *signalReader) Read(p []byte) (int, error) {
+	r.once.Do(func() { close(r.read) })
+	return r.ReadCloser.Read(p)
+}